@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+type apiAppsResponse struct {
+	apiStatus
+	Response struct {
+		Apps []model.DNSApp `json:"apps"`
+	} `json:"response"`
+}
+
+type apiAppConfigResponse struct {
+	apiStatus
+	Response struct {
+		Config *string `json:"config"`
+	} `json:"response"`
+}
+
+// ListApps retrieves the installed DNS apps.
+func (c Client) ListApps(ctx context.Context) ([]model.DNSApp, error) {
+	var apiResponse apiAppsResponse
+	err := c.makeRequest(ctx, APPS_URL+"/list", http.MethodGet, nil, nil, &apiResponse)
+	if err != nil {
+		return nil, err
+	}
+	return apiResponse.Response.Apps, nil
+}
+
+// GetAppConfig retrieves the JSON config of an installed DNS app, empty if it has none.
+func (c Client) GetAppConfig(ctx context.Context, appName string) (string, error) {
+	params := url.Values{
+		"name": {appName},
+	}
+
+	var apiResponse apiAppConfigResponse
+	err := c.makeRequest(ctx, APPS_URL+"/config/get", http.MethodGet, params, nil, &apiResponse)
+	if err != nil {
+		return "", err
+	}
+
+	if apiResponse.Response.Config == nil {
+		return "", nil
+	}
+	return *apiResponse.Response.Config, nil
+}
+
+// SetAppConfig replaces the JSON config of an installed DNS app.
+func (c Client) SetAppConfig(ctx context.Context, appName string, config string) error {
+	formData := url.Values{
+		"name":   {appName},
+		"config": {config},
+	}
+
+	var apiResponse apiEmptyResponse
+	return c.makeRequest(ctx, APPS_URL+"/config/set", http.MethodPost, nil, formData, &apiResponse)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,15 +13,42 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/kevynb/terraform-provider-technitium/internal/model"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	HTTP_TIMEOUT               = 10
+	HTTP_TIMEOUT               = 10  // to connect, long operations are bounded by the request context
+	HTTP_REQUEST_TIMEOUT       = 120 // for requests made without a deadline
+	HTTP_MAX_IDLE_CONNS        = 16  // kept open per server, requests are mostly sequential
+	HTTP_IDLE_CONN_TIMEOUT     = 90
 	DOMAINS_URL                = "/api/zones/records"
 	ZONES_URL                  = "/api/zones"
+	DASHBOARD_URL              = "/api/dashboard"
+	LOGS_URL                   = "/api/logs"
+	APPS_URL                   = "/api/apps"
+	DHCP_URL                   = "/api/dhcp"
+	DNS_CLIENT_URL             = "/api/dnsClient"
+	SETTINGS_URL               = "/api/settings"
 	TERRAFORM_PROVIDER_COMMENT = "Managed by terraform"
+	USER_AGENT_PRODUCT         = "terraform-provider-technitium"
+	REDACTED                   = "REDACTED"
+)
+
+// request parameters never to be logged in clear; app configs are JSON
+// documents holding headers, passwords and connection strings
+var sensitiveParams = []string{
+	"token", "pass", "password", "proxyPassword", "sharedSecret", "tsigKeySecret",
+	"pemKskPrivateKey", "pemZskPrivateKey", "pemPrivateKey", "config",
+}
+
+// what UpdateRecord does with the current record comment
+const (
+	CommentPolicyEnforce = "enforce" // replace it with the managed comment
+	CommentPolicyIgnore  = "ignore"  // keep it as is
+	CommentPolicyAppend  = "append"  // add the managed comment to it, if not there yet
 )
 
 const (
@@ -32,35 +60,185 @@ const (
 var _ model.DNSApiClient = Client{}
 
 type Client struct {
-	apiURL     string
-	token      string
+	endpoints  *endpointPool
+	session    *session
+	server     *serverInfo
+	headers    map[string]string
+	userAgent  string
+	metrics    []MetricsHook
+	tracer     trace.Tracer
+	comment    string
+	commentPol string
 	httpClient http.Client
 }
 
-func NewClient(apiURL string, token string, skipCertificateVerification bool) (*Client, error) {
+// client settings, from provider configuration
+type Config struct {
+	APIURLs                     []string // primary first, the others used when it is unreachable
+	Token                       string
+	Username                    string // with Password, log in for a session token instead of Token
+	Password                    string
+	SkipCertificateVerification bool
+	CACertificate               string // PEM, verify server certificate against it instead of system roots
+	ProxyURL                    string // http(s):// or socks5:// proxy, HTTPS_PROXY & co. env vars when empty
+	Headers                     map[string]string
+	BasePath                    string // path the API is mounted under, e.g. /dns for https://host/dns/api/...
+	Version                     string // provider version, for User-Agent
+	UserAgentSuffix             string
+	DebugHTTP                   bool                 // log every HTTP exchange with status and timing
+	Metrics                     MetricsHook          // called after every HTTP exchange, e.g. to report API latency
+	TracerProvider              trace.TracerProvider // spans of API calls, the global OpenTelemetry one if nil
+	ManagedComment              string               // comment set on added/updated records, TERRAFORM_PROVIDER_COMMENT if empty
+	CommentPolicy               string               // CommentPolicyEnforce if empty
+}
+
+func NewClient(conf Config) (*Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: conf.SkipCertificateVerification}
+	if conf.CACertificate != "" {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM([]byte(conf.CACertificate)) {
+			return nil, errors.New("cannot parse CA certificate: no valid PEM certificate found")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if len(conf.APIURLs) == 0 {
+		return nil, errors.New("no API URL configured")
+	}
+	apiURLs := make([]string, 0, len(conf.APIURLs))
+	for _, apiURL := range conf.APIURLs {
+		apiURL, err := url.JoinPath(apiURL, conf.BasePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse API URL")
+		}
+		apiURLs = append(apiURLs, apiURL)
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if conf.ProxyURL != "" {
+		proxyURL, err := url.Parse(conf.ProxyURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse proxy URL")
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, errors.Errorf("unsupported proxy scheme %q, expected http, https or socks5", proxyURL.Scheme)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	// connections are reused across the many calls of an apply; HTTP/2 is
+	// negotiated when the server offers it, which a custom TLS config disables
+	// unless forced
 	httpTransport := &http.Transport{
+		Proxy: proxy,
 		DialContext: (&net.Dialer{
-			Timeout: HTTP_TIMEOUT * time.Second}).DialContext,
-		TLSHandshakeTimeout:   HTTP_TIMEOUT * time.Second,
-		ResponseHeaderTimeout: HTTP_TIMEOUT * time.Second,
-		TLSClientConfig:       &tls.Config{InsecureSkipVerify: skipCertificateVerification},
+			Timeout:   HTTP_TIMEOUT * time.Second,
+			KeepAlive: 30 * time.Second}).DialContext,
+		TLSHandshakeTimeout: HTTP_TIMEOUT * time.Second,
+		TLSClientConfig:     tlsConfig,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        HTTP_MAX_IDLE_CONNS,
+		MaxIdleConnsPerHost: HTTP_MAX_IDLE_CONNS,
+		IdleConnTimeout:     HTTP_IDLE_CONN_TIMEOUT * time.Second,
 	}
 
 	httpClient := http.Client{
 		Transport: httpTransport,
 	}
+	userAgent := fmt.Sprintf("%s/%s", USER_AGENT_PRODUCT, conf.Version)
+	if conf.UserAgentSuffix != "" {
+		userAgent = userAgent + " " + conf.UserAgentSuffix
+	}
+
+	comment := conf.ManagedComment
+	if comment == "" {
+		comment = TERRAFORM_PROVIDER_COMMENT
+	}
+
 	return &Client{
-		apiURL:     apiURL,
-		token:      token,
+		endpoints:  newEndpointPool(apiURLs),
+		session:    &session{token: conf.Token, username: conf.Username, password: conf.Password},
+		server:     &serverInfo{},
+		headers:    conf.Headers,
+		userAgent:  userAgent,
+		metrics:    metricsHooks(conf),
+		tracer:     newTracer(conf.TracerProvider),
+		comment:    comment,
+		commentPol: conf.CommentPolicy,
 		httpClient: httpClient,
 	}, nil
 }
 
+// status part of every API response
+type apiStatus struct {
+	Status            string `json:"status"`
+	ErrorMessage      string `json:"errorMessage,omitempty"`
+	InnerErrorMessage string `json:"innerErrorMessage,omitempty"`
+}
+
+// API responses, checked for errors once decoded
+type apiStatusResponse interface {
+	apiError() error
+}
+
+// API error, with the status it was reported with
+type apiStatusError struct {
+	status  string
+	message string
+}
+
+func (e *apiStatusError) Error() string {
+	return e.message
+}
+
+func (s apiStatus) apiError() error {
+	if s.Status == StatusOK {
+		return nil
+	}
+	logMessage := fmt.Sprintf("API error: %s", s.ErrorMessage)
+	if s.Status == StatusInvalidToken && s.ErrorMessage == "" {
+		logMessage = "API error: invalid token"
+	}
+	if s.InnerErrorMessage != "" {
+		logMessage = fmt.Sprintf("%s (Inner: %s)", logMessage, s.InnerErrorMessage)
+	}
+	return errors.WithStack(&apiStatusError{status: s.Status, message: logMessage})
+}
+
+// whether the call was rejected for an invalid or expired token
+func isInvalidTokenError(err error) bool {
+	var statusErr *apiStatusError
+	return errors.As(err, &statusErr) && statusErr.status == StatusInvalidToken
+}
+
 type apiResponse struct {
-	Status            string          `json:"status"`
-	Response          apiResponseBody `json:"response,omitempty"`
-	ErrorMessage      string          `json:"errorMessage,omitempty"`
-	InnerErrorMessage string          `json:"innerErrorMessage,omitempty"`
+	apiStatus
+	Response apiResponseBody `json:"response,omitempty"`
+}
+
+// zone API responses
+type apiZonesListResponse struct {
+	apiStatus
+	Response struct {
+		Zones []model.DNSZone `json:"zones"`
+	} `json:"response"`
+}
+type apiZoneOptionsResponse struct {
+	apiStatus
+	Response model.DNSZoneOptions `json:"response"`
+}
+type apiZoneCreateResponse struct {
+	apiStatus
+	Response struct {
+		Domain string `json:"domain"`
+	} `json:"response"`
+}
+
+// for calls returning nothing but the status
+type apiEmptyResponse struct {
+	apiStatus
 }
 type apiResponseBody struct {
 	Records []apiDNSRecordResponseItem `json:"records"`
@@ -144,147 +322,245 @@ type apiDNSRecordResponseItemRdata struct {
 	RecordData                     string `json:"data,omitempty"`
 }
 
-func (c Client) makeRecordsRequest(ctx context.Context, path string, method string, queryParams url.Values, formData url.Values, apiResponse *apiResponse) error {
-	// Ensure the token is always set
-	switch method {
-	case http.MethodGet:
-		if queryParams == nil {
-			queryParams = url.Values{}
-		}
-		queryParams.Set("token", c.token)
-	case http.MethodPost:
-		if formData == nil {
-			formData = url.Values{}
-		}
-		formData.Set("token", c.token)
+// build API request, with configured extra headers
+func (c Client) newRequest(ctx context.Context, method string, requestURL string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create HTTP request")
 	}
 
-	var requestURL string
-	var body io.Reader
-	if method == http.MethodGet {
-		requestURL = fmt.Sprintf("%s%s%s?%s", c.apiURL, DOMAINS_URL, path, queryParams.Encode())
-	} else {
-		requestURL = fmt.Sprintf("%s%s%s", c.apiURL, DOMAINS_URL, path)
-		body = strings.NewReader(formData.Encode())
-		print("\n\n", formData.Encode(), "\n\n")
+	req.Header.Set("User-Agent", c.userAgent)
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
-	if err != nil {
-		return errors.Wrap(err, "cannot create HTTP request")
+	return req, nil
+}
+
+// copy of params with sensitive values replaced
+func redactParams(params url.Values) url.Values {
+	redacted := url.Values{}
+	for key, values := range params {
+		redacted[key] = values
+		for _, sensitive := range sensitiveParams {
+			if strings.EqualFold(key, sensitive) {
+				redacted[key] = []string{REDACTED}
+				break
+			}
+		}
 	}
+	return redacted
+}
 
-	if method == http.MethodPost {
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+func logRequest(ctx context.Context, method string, path string, queryParams url.Values, formData url.Values) {
+	fields := map[string]interface{}{
+		"method": method,
+		"path":   path,
+	}
+	if len(queryParams) > 0 {
+		fields["query"] = redactParams(queryParams).Encode()
 	}
+	if len(formData) > 0 {
+		fields["form"] = redactParams(formData).Encode()
+	}
+	tflog.Debug(ctx, "technitium API request", fields)
+}
 
-	resp, err := c.httpClient.Do(req)
+func redactURL(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
-		return errors.Wrap(err, "HTTP request error")
+		return rawURL
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	// Parse response to check for API errors
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return errors.Wrap(err, "cannot decode JSON response into the provided structure")
+	parsedURL.RawQuery = redactParams(parsedURL.Query()).Encode()
+	if parsedURL.User != nil {
+		parsedURL.User = url.User(parsedURL.User.Username())
 	}
+	return parsedURL.String()
+}
 
-	if apiResponse.Status != StatusOK {
-		logMessage := fmt.Sprintf("API error: %s", apiResponse.ErrorMessage)
-		if apiResponse.InnerErrorMessage != "" {
-			logMessage = fmt.Sprintf("%s (Inner: %s)", logMessage, apiResponse.InnerErrorMessage)
-		}
-		return errors.New(logMessage)
+// net/http errors embed the full request URL, token query param included
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactURL(urlErr.URL)
 	}
+	return err
+}
 
-	return nil
+// read the rest of the body before closing it, so the connection is reused
+func closeBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
 }
 
-func (c Client) makeZonesRequest(ctx context.Context, path string, method string, queryParams url.Values, formData url.Values, apiResponse interface{}) error {
-	// Ensure the token is always set
-	switch method {
-	case http.MethodGet:
+// send API request with token, failing over to other endpoints when unreachable.
+// Caller must close the response body
+func (c Client) doRequest(ctx context.Context, token string, apiPath string, method string, queryParams url.Values, formData url.Values) (*http.Response, error) {
+	// Ensure the token is always set, but on login
+	switch {
+	case token == "":
+	case method == http.MethodGet:
 		if queryParams == nil {
 			queryParams = url.Values{}
 		}
-		queryParams.Set("token", c.token)
-	case http.MethodPost:
+		queryParams.Set("token", token)
+	case method == http.MethodPost:
 		if formData == nil {
 			formData = url.Values{}
 		}
-		formData.Set("token", c.token)
+		formData.Set("token", token)
 	}
+	logRequest(ctx, method, apiPath, queryParams, formData)
 
-	var requestURL string
-	var body io.Reader
-	if method == http.MethodGet {
-		requestURL = fmt.Sprintf("%s%s%s?%s", c.apiURL, ZONES_URL, path, queryParams.Encode())
-	} else {
-		requestURL = fmt.Sprintf("%s%s%s", c.apiURL, ZONES_URL, path)
-		body = strings.NewReader(formData.Encode())
-	}
+	var lastErr error
+	for attempt, i := range c.endpoints.order() {
+		requestURL, err := url.JoinPath(c.endpoints.urls[i], apiPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot build request URL")
+		}
+		var body io.Reader
+		if method == http.MethodGet {
+			requestURL = requestURL + "?" + queryParams.Encode()
+		} else {
+			body = strings.NewReader(formData.Encode())
+		}
 
-	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
-	if err != nil {
-		return errors.Wrap(err, "cannot create HTTP request")
-	}
+		req, err := c.newRequest(ctx, method, requestURL, body)
+		if err != nil {
+			return nil, err
+		}
 
-	if method == http.MethodPost {
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	}
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		c.reportMetrics(ctx, RequestMetrics{
+			Endpoint: redactURL(c.endpoints.urls[i]),
+			Method:   method,
+			Path:     apiPath,
+			URL:      redactURL(req.URL.String()),
+			Duration: time.Since(start),
+			Status:   httpStatus(resp),
+			Retries:  attempt,
+			Err:      redactURLError(err),
+		})
+		if err == nil {
+			c.endpoints.markUp(i)
+			return resp, nil
+		}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return errors.Wrap(err, "HTTP request error")
+		lastErr = errors.Wrap(redactURLError(err), "HTTP request error")
+		if len(c.endpoints.urls) == 1 || !canFailover(ctx, method, err) {
+			return nil, lastErr
+		}
+		c.endpoints.markDown(i)
+		tflog.Warn(ctx, "technitium API endpoint unreachable, trying next one", map[string]interface{}{
+			"endpoint": redactURL(c.endpoints.urls[i]),
+			"error":    lastErr.Error(),
+		})
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	return nil, lastErr
+}
 
-	// Parse response to check for API errors
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return errors.Wrap(err, "cannot decode JSON response into the provided structure")
+func (c Client) makeRecordsRequest(ctx context.Context, path string, method string, queryParams url.Values, formData url.Values, response *apiResponse) error {
+	if response == nil {
+		response = &apiResponse{}
 	}
+	return c.makeRequest(ctx, DOMAINS_URL+path, method, queryParams, formData, response)
+}
 
-	// Check for API errors - this assumes the response has Status field
-	if responseMap, ok := apiResponse.(map[string]interface{}); ok {
-		if status, exists := responseMap["status"]; exists && status != StatusOK {
-			logMessage := "API error"
-			if errorMsg, exists := responseMap["errorMessage"]; exists {
-				logMessage = fmt.Sprintf("API error: %s", errorMsg)
-			}
-			if innerErrorMsg, exists := responseMap["innerErrorMessage"]; exists && innerErrorMsg != "" {
-				logMessage = fmt.Sprintf("%s (Inner: %s)", logMessage, innerErrorMsg)
-			}
-			return errors.New(logMessage)
+func (c Client) makeZonesRequest(ctx context.Context, path string, method string, queryParams url.Values, formData url.Values, apiResponse apiStatusResponse) error {
+	return c.makeRequest(ctx, ZONES_URL+path, method, queryParams, formData, apiResponse)
+}
+
+// API call returning a JSON response with status, for any API path
+func (c Client) makeRequest(ctx context.Context, apiPath string, method string, queryParams url.Values, formData url.Values, apiResponse apiStatusResponse) (err error) {
+	ctx, span := c.startSpan(ctx, apiPath, method, queryParams, formData)
+	defer func() { endSpan(span, err) }()
+
+	return c.withSession(ctx, func(ctx context.Context, token string) error {
+		resp, err := c.doRequest(ctx, token, apiPath, method, queryParams, formData)
+		if err != nil {
+			return err
+		}
+		defer closeBody(resp)
+
+		// Parse response to check for API errors
+		dec := json.NewDecoder(resp.Body)
+		if streamed, ok := apiResponse.(apiStreamedResponse); ok {
+			err = streamed.decode(dec)
+		} else {
+			err = dec.Decode(apiResponse)
+		}
+		if err != nil {
+			return errors.Wrap(err, "cannot decode JSON response into the provided structure")
 		}
-	}
 
-	return nil
+		return apiResponse.apiError()
+	})
 }
 
-// GetRecords retrieves all DNS records for a given domain name (zone is inferred automatically).
+// GetRecords retrieves the DNS records of a domain name (zone is inferred automatically),
+// or of every domain of the zone when empty.
+// The server answers with the whole zone: records of other domains are
+// dropped while the response is decoded.
 func (c Client) GetRecords(ctx context.Context, domain model.DNSRecordName) ([]model.DNSRecord, error) {
 	params := url.Values{}
+	apiResponse := apiRecordsStream{}
 	if domain != "" {
 		params.Add("domain", string(domain))
+		apiResponse.keep = func(record model.DNSRecord) bool {
+			return sameOwner(record.Domain, domain)
+		}
 	}
 	params.Add("listZone", "true")
 
-	var apiResponse apiResponse
-	err := c.makeRecordsRequest(ctx, "/get", http.MethodGet, params, nil, &apiResponse)
+	err := c.makeRequest(ctx, DOMAINS_URL+"/get", http.MethodGet, params, nil, &apiResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	res := make([]model.DNSRecord, len(apiResponse.Response.Records))
-	for i, rr := range apiResponse.Response.Records {
-		res[i] = mapAPIDNSRecordToDNSRecord(rr, apiResponse.Response.Zone.Name)
+	return apiResponse.records, nil
+}
+
+// owner names are case-insensitive, with or without the root dot
+func sameOwner(name model.DNSRecordName, other model.DNSRecordName) bool {
+	return strings.EqualFold(strings.TrimSuffix(string(name), "."), strings.TrimSuffix(string(other), "."))
+}
+
+// ManagedComment returns the comment set on the records the provider adds.
+func (c Client) ManagedComment() string {
+	return c.comment
+}
+
+// comment to set on update, depending on the comment policy and current comment
+func (c Client) updatedComment(ctx context.Context, oldRecord model.DNSRecord) (string, error) {
+	if c.commentPol == "" || c.commentPol == CommentPolicyEnforce {
+		// Reset it on update in case it was missed or updated manually the first time.
+		return c.comment, nil
 	}
 
-	return res, nil
+	records, err := c.GetRecords(ctx, oldRecord.Domain)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot read current record comment")
+	}
+	current := ""
+	for _, record := range records {
+		if record.SameKey(oldRecord) {
+			current = record.Comments
+			break
+		}
+	}
+
+	switch {
+	case current == "":
+		return c.comment, nil
+	case c.commentPol == CommentPolicyIgnore, strings.Contains(current, c.comment):
+		return current, nil
+	default:
+		return current + "\n" + c.comment, nil
+	}
 }
 
 // AddRecord adds DNS record for a given domain.
@@ -295,190 +571,12 @@ func (c Client) AddRecord(ctx context.Context, record model.DNSRecord) error {
 		"ttl":    {fmt.Sprintf("%d", record.TTL)},
 	}
 
-	formData.Add("comments", TERRAFORM_PROVIDER_COMMENT)
+	formData.Add("comments", c.comment)
 
-	if record.ExpiryTTL > 0 {
-		formData.Add("expiryTtl", fmt.Sprintf("%d", record.ExpiryTTL))
-	}
-	if record.IPAddress != "" {
-		formData.Add("ipAddress", record.IPAddress)
-	}
-	if record.Ptr {
-		formData.Add("ptr", "true")
-	}
-	if record.CreatePtrZone {
-		formData.Add("createPtrZone", "true")
-	}
-	if record.UpdateSvcbHints {
-		formData.Add("updateSvcbHints", "true")
-	}
-	if record.NameServer != "" {
-		formData.Add("nameServer", record.NameServer)
-	}
-	if record.Glue != "" {
-		formData.Add("glue", record.Glue)
-	}
-	if record.CName != "" {
-		formData.Add("cname", record.CName)
-	}
-	if record.PtrName != "" {
-		formData.Add("ptrName", record.PtrName)
-	}
-	if record.Exchange != "" {
-		formData.Add("exchange", record.Exchange)
-	}
-	if record.Preference > 0 {
-		formData.Add("preference", fmt.Sprintf("%d", record.Preference))
-	}
-	if record.Text != "" {
-		formData.Add("text", record.Text)
-	}
-	if record.SplitText {
-		formData.Add("splitText", "true")
-	}
-	if record.Mailbox != "" {
-		formData.Add("mailbox", record.Mailbox)
-	}
-	if record.TxtDomain != "" {
-		formData.Add("txtDomain", record.TxtDomain)
-	}
-	if record.Priority > 0 {
-		formData.Add("priority", fmt.Sprintf("%d", record.Priority))
-	}
-	if record.Weight > 0 {
-		formData.Add("weight", fmt.Sprintf("%d", record.Weight))
-	}
-	if record.Port > 0 {
-		formData.Add("port", fmt.Sprintf("%d", record.Port))
-	}
-	if record.Target != "" {
-		formData.Add("target", string(record.Target))
-	}
-	if record.NaptrOrder > 0 {
-		formData.Add("naptrOrder", fmt.Sprintf("%d", record.NaptrOrder))
-	}
-	if record.NaptrPreference > 0 {
-		formData.Add("naptrPreference", fmt.Sprintf("%d", record.NaptrPreference))
-	}
-	if record.NaptrFlags != "" {
-		formData.Add("naptrFlags", record.NaptrFlags)
-	}
-	if record.NaptrServices != "" {
-		formData.Add("naptrServices", record.NaptrServices)
-	}
-	if record.NaptrRegexp != "" {
-		formData.Add("naptrRegexp", record.NaptrRegexp)
-	}
-	if record.NaptrReplacement != "" {
-		formData.Add("naptrReplacement", record.NaptrReplacement)
-	}
-	if record.DName != "" {
-		formData.Add("dName", record.DName)
-	}
-	if record.KeyTag > 0 {
-		formData.Add("keyTag", fmt.Sprintf("%d", record.KeyTag))
-	}
-	if record.Algorithm != "" {
-		formData.Add("algorithm", record.Algorithm)
-	}
-	if record.DigestType != "" {
-		formData.Add("digestType", record.DigestType)
-	}
-	if record.Digest != "" {
-		formData.Add("digest", record.Digest)
-	}
-	if record.SshfpAlgorithm != "" {
-		formData.Add("sshfpAlgorithm", record.SshfpAlgorithm)
-	}
-	if record.SshfpFingerprintType != "" {
-		formData.Add("sshfpFingerprintType", record.SshfpFingerprintType)
-	}
-	if record.SshfpFingerprint != "" {
-		formData.Add("sshfpFingerprint", record.SshfpFingerprint)
-	}
-	if record.TlsaCertificateUsage != "" {
-		formData.Add("tlsaCertificateUsage", record.TlsaCertificateUsage)
-	}
-	if record.TlsaSelector != "" {
-		formData.Add("tlsaSelector", record.TlsaSelector)
-	}
-	if record.TlsaMatchingType != "" {
-		formData.Add("tlsaMatchingType", record.TlsaMatchingType)
-	}
-	if record.TlsaCertificateAssociationData != "" {
-		formData.Add("tlsaCertificateAssociationData", record.TlsaCertificateAssociationData)
-	}
-	if record.SvcPriority > 0 {
-		formData.Add("svcPriority", fmt.Sprintf("%d", record.SvcPriority))
-	}
-	if record.SvcTargetName != "" {
-		formData.Add("svcTargetName", record.SvcTargetName)
-	}
-	if record.SvcParams != "" {
-		formData.Add("svcParams", record.SvcParams)
-	}
-	if record.AutoIpv4Hint {
-		formData.Add("autoIpv4Hint", "true")
-	}
-	if record.AutoIpv6Hint {
-		formData.Add("autoIpv6Hint", "true")
-	}
-	if record.UriPriority > 0 {
-		formData.Add("uriPriority", fmt.Sprintf("%d", record.UriPriority))
-	}
-	if record.UriWeight > 0 {
-		formData.Add("uriWeight", fmt.Sprintf("%d", record.UriWeight))
-	}
-	if record.Uri != "" {
-		formData.Add("uri", record.Uri)
-	}
-	if record.Flags != "" {
-		formData.Add("flags", record.Flags)
-	}
-	if record.Tag != "" {
-		formData.Add("tag", record.Tag)
-	}
-	if record.Value != "" {
-		formData.Add("value", record.Value)
-	}
-	if record.AName != "" {
-		formData.Add("aName", record.AName)
-	}
-	if record.Forwarder != "" {
-		formData.Add("forwarder", record.Forwarder)
-	}
-	if record.ForwarderPriority > 0 {
-		formData.Add("forwarderPriority", fmt.Sprintf("%d", record.ForwarderPriority))
-	}
-	if record.DnssecValidation {
-		formData.Add("dnssecValidation", "true")
-	}
-	if record.ProxyType != "" {
-		formData.Add("proxyType", record.ProxyType)
-	}
-	if record.ProxyAddress != "" {
-		formData.Add("proxyAddress", record.ProxyAddress)
-	}
-	if record.ProxyPort > 0 {
-		formData.Add("proxyPort", fmt.Sprintf("%d", record.ProxyPort))
-	}
-	if record.ProxyUsername != "" {
-		formData.Add("proxyUsername", record.ProxyUsername)
-	}
-	if record.ProxyPassword != "" {
-		formData.Add("proxyPassword", record.ProxyPassword)
-	}
-	if record.AppName != "" {
-		formData.Add("appName", record.AppName)
-	}
-	if record.ClassPath != "" {
-		formData.Add("classPath", record.ClassPath)
-	}
-	if record.RecordData != "" {
-		formData.Add("recordData", record.RecordData)
-	}
+	encodeForm(formData, record, formAdd)
+	encodeExtraForm(formData, record.ExtraRData)
 
-	formData.Add("overwrite", "false")
+	formData.Add("overwrite", fmt.Sprintf("%t", record.Overwrite))
 
 	if err := c.makeRecordsRequest(ctx, "/add", http.MethodPost, nil, formData, nil); err != nil {
 		return err
@@ -501,339 +599,14 @@ func (c Client) UpdateRecord(ctx context.Context, oldRecord model.DNSRecord, new
 		formData.Add("newDomain", string(newRecord.Domain))
 	}
 
-	if oldRecord.IPAddress != "" {
-		formData.Add("ipAddress", oldRecord.IPAddress)
-	}
-	if newRecord.IPAddress != "" {
-		formData.Add("newIpAddress", newRecord.IPAddress)
-	}
-
-	// Reset it on update in case it was missed or updated manually the first time.
-	formData.Add("comments", TERRAFORM_PROVIDER_COMMENT)
-
-	if newRecord.ExpiryTTL > 0 {
-		formData.Add("expiryTtl", fmt.Sprintf("%d", newRecord.ExpiryTTL))
-	}
-
-	if newRecord.Ptr {
-		formData.Add("ptr", "true")
-	}
-	if newRecord.CreatePtrZone {
-		formData.Add("createPtrZone", "true")
-	}
-	if newRecord.UpdateSvcbHints {
-		formData.Add("updateSvcbHints", "true")
-	}
-
-	if oldRecord.NameServer != "" {
-		formData.Add("nameServer", oldRecord.NameServer)
-	}
-	if newRecord.NameServer != "" {
-		formData.Add("newNameServer", newRecord.NameServer)
-	}
-	if newRecord.Glue != "" {
-		formData.Add("glue", newRecord.Glue)
-	}
-
-	if newRecord.CName != "" {
-		formData.Add("cname", newRecord.CName)
-	}
-
-	if oldRecord.PtrName != "" {
-		formData.Add("ptrName", oldRecord.PtrName)
-	}
-	if newRecord.PtrName != "" {
-		formData.Add("newPtrName", newRecord.PtrName)
-	}
-
-	if oldRecord.Exchange != "" {
-		formData.Add("exchange", oldRecord.Exchange)
-	}
-	if newRecord.Exchange != "" {
-		formData.Add("newExchange", newRecord.Exchange)
-	}
-
-	if oldRecord.Preference > 0 {
-		formData.Add("preference", fmt.Sprintf("%d", oldRecord.Preference))
-	}
-	if newRecord.Preference > 0 {
-		formData.Add("newPreference", fmt.Sprintf("%d", newRecord.Preference))
-	}
-
-	if oldRecord.Text != "" {
-		formData.Add("text", oldRecord.Text)
-	}
-	if newRecord.Text != "" {
-		formData.Add("newText", newRecord.Text)
-	}
-
-	if oldRecord.SplitText {
-		formData.Add("splitText", "true")
-	}
-	if newRecord.SplitText {
-		formData.Add("newSplitText", "true")
-	}
-
-	if oldRecord.Mailbox != "" {
-		formData.Add("mailbox", oldRecord.Mailbox)
-	}
-	if newRecord.Mailbox != "" {
-		formData.Add("newMailbox", newRecord.Mailbox)
-	}
-
-	if oldRecord.TxtDomain != "" {
-		formData.Add("txtDomain", oldRecord.TxtDomain)
-	}
-	if newRecord.TxtDomain != "" {
-		formData.Add("newTxtDomain", newRecord.TxtDomain)
-	}
-
-	if oldRecord.Priority > 0 {
-		formData.Add("priority", fmt.Sprintf("%d", oldRecord.Priority))
-	}
-	if newRecord.Priority > 0 {
-		formData.Add("newPriority", fmt.Sprintf("%d", newRecord.Priority))
-	}
-
-	if oldRecord.Weight > 0 {
-		formData.Add("weight", fmt.Sprintf("%d", oldRecord.Weight))
-	}
-	if newRecord.Weight > 0 {
-		formData.Add("newWeight", fmt.Sprintf("%d", newRecord.Weight))
-	}
-
-	if oldRecord.Port > 0 {
-		formData.Add("port", fmt.Sprintf("%d", oldRecord.Port))
-	}
-	if newRecord.Port > 0 {
-		formData.Add("newPort", fmt.Sprintf("%d", newRecord.Port))
-	}
-
-	if oldRecord.Target != "" {
-		formData.Add("target", string(oldRecord.Target))
-	}
-	if newRecord.Target != "" {
-		formData.Add("newTarget", string(newRecord.Target))
-	}
-
-	if oldRecord.NaptrOrder > 0 {
-		formData.Add("naptrOrder", fmt.Sprintf("%d", oldRecord.NaptrOrder))
-	}
-	if oldRecord.NaptrPreference > 0 {
-		formData.Add("naptrPreference", fmt.Sprintf("%d", oldRecord.NaptrPreference))
-	}
-	if oldRecord.NaptrFlags != "" {
-		formData.Add("naptrFlags", oldRecord.NaptrFlags)
-	}
-	if oldRecord.NaptrServices != "" {
-		formData.Add("naptrServices", oldRecord.NaptrServices)
-	}
-	if oldRecord.NaptrRegexp != "" {
-		formData.Add("naptrRegexp", oldRecord.NaptrRegexp)
-	}
-	if oldRecord.NaptrReplacement != "" {
-		formData.Add("naptrReplacement", oldRecord.NaptrReplacement)
-	}
-	if newRecord.NaptrOrder > 0 {
-		formData.Add("newNaptrOrder", fmt.Sprintf("%d", newRecord.NaptrOrder))
-	}
-	if newRecord.NaptrPreference > 0 {
-		formData.Add("newNaptrPreference", fmt.Sprintf("%d", newRecord.NaptrPreference))
-	}
-	if newRecord.NaptrFlags != "" {
-		formData.Add("newNaptrFlags", newRecord.NaptrFlags)
-	}
-	if newRecord.NaptrServices != "" {
-		formData.Add("newNaptrServices", newRecord.NaptrServices)
-	}
-	if newRecord.NaptrRegexp != "" {
-		formData.Add("newNaptrRegexp", newRecord.NaptrRegexp)
-	}
-	if newRecord.NaptrReplacement != "" {
-		formData.Add("newNaptrReplacement", newRecord.NaptrReplacement)
-	}
-
-	if oldRecord.DName != "" {
-		formData.Add("dName", oldRecord.DName)
-	}
-
-	if oldRecord.KeyTag > 0 {
-		formData.Add("keyTag", fmt.Sprintf("%d", oldRecord.KeyTag))
-	}
-	if newRecord.KeyTag > 0 {
-		formData.Add("newKeyTag", fmt.Sprintf("%d", newRecord.KeyTag))
-	}
-
-	if oldRecord.Algorithm != "" {
-		formData.Add("algorithm", oldRecord.Algorithm)
-	}
-	if newRecord.Algorithm != "" {
-		formData.Add("newAlgorithm", newRecord.Algorithm)
-	}
-	if oldRecord.DigestType != "" {
-		formData.Add("digestType", oldRecord.DigestType)
-	}
-	if newRecord.DigestType != "" {
-		formData.Add("newDigestType", newRecord.DigestType)
-	}
-	if oldRecord.Digest != "" {
-		formData.Add("digest", oldRecord.Digest)
-	}
-	if newRecord.Digest != "" {
-		formData.Add("newDigest", newRecord.Digest)
-	}
-
-	if oldRecord.SshfpAlgorithm != "" {
-		formData.Add("sshfpAlgorithm", oldRecord.SshfpAlgorithm)
-	}
-	if newRecord.SshfpAlgorithm != "" {
-		formData.Add("newSshfpAlgorithm", newRecord.SshfpAlgorithm)
-	}
-	if oldRecord.SshfpFingerprintType != "" {
-		formData.Add("sshfpFingerprintType", oldRecord.SshfpFingerprintType)
-	}
-	if newRecord.SshfpFingerprintType != "" {
-		formData.Add("newSshfpFingerprintType", newRecord.SshfpFingerprintType)
-	}
-	if oldRecord.SshfpFingerprint != "" {
-		formData.Add("sshfpFingerprint", oldRecord.SshfpFingerprint)
-	}
-	if newRecord.SshfpFingerprint != "" {
-		formData.Add("newSshfpFingerprint", newRecord.SshfpFingerprint)
-	}
-
-	if oldRecord.TlsaCertificateUsage != "" {
-		formData.Add("tlsaCertificateUsage", oldRecord.TlsaCertificateUsage)
-	}
-	if newRecord.TlsaCertificateUsage != "" {
-		formData.Add("newTlsaCertificateUsage", newRecord.TlsaCertificateUsage)
-	}
-	if oldRecord.TlsaSelector != "" {
-		formData.Add("tlsaSelector", oldRecord.TlsaSelector)
-	}
-	if newRecord.TlsaSelector != "" {
-		formData.Add("newTlsaSelector", newRecord.TlsaSelector)
-	}
-	if oldRecord.TlsaMatchingType != "" {
-		formData.Add("tlsaMatchingType", oldRecord.TlsaMatchingType)
-	}
-	if newRecord.TlsaMatchingType != "" {
-		formData.Add("newTlsaMatchingType", newRecord.TlsaMatchingType)
-	}
-	if oldRecord.TlsaCertificateAssociationData != "" {
-		formData.Add("tlsaCertificateAssociationData", oldRecord.TlsaCertificateAssociationData)
-	}
-	if newRecord.TlsaCertificateAssociationData != "" {
-		formData.Add("newTlsaCertificateAssociationData", newRecord.TlsaCertificateAssociationData)
-	}
-
-	if oldRecord.SvcPriority > 0 {
-		formData.Add("svcPriority", fmt.Sprintf("%d", oldRecord.SvcPriority))
-	}
-	if newRecord.SvcPriority > 0 {
-		formData.Add("newSvcPriority", fmt.Sprintf("%d", newRecord.SvcPriority))
-	}
-	if oldRecord.SvcTargetName != "" {
-		formData.Add("svcTargetName", oldRecord.SvcTargetName)
-	}
-	if newRecord.SvcTargetName != "" {
-		formData.Add("newSvcTargetName", newRecord.SvcTargetName)
-	}
-	if oldRecord.SvcParams != "" {
-		formData.Add("svcParams", oldRecord.SvcParams)
-	}
-	if newRecord.SvcParams != "" {
-		formData.Add("newSvcParams", newRecord.SvcParams)
+	comment, err := c.updatedComment(ctx, oldRecord)
+	if err != nil {
+		return err
 	}
+	formData.Add("comments", comment)
 
-	if newRecord.AutoIpv4Hint {
-		formData.Add("autoIpv4Hint", "true")
-	}
-	if newRecord.AutoIpv6Hint {
-		formData.Add("autoIpv6Hint", "true")
-	}
-
-	if oldRecord.UriPriority > 0 {
-		formData.Add("uriPriority", fmt.Sprintf("%d", oldRecord.UriPriority))
-	}
-	if newRecord.UriPriority > 0 {
-		formData.Add("newUriPriority", fmt.Sprintf("%d", newRecord.UriPriority))
-	}
-	if oldRecord.UriWeight > 0 {
-		formData.Add("uriWeight", fmt.Sprintf("%d", oldRecord.UriWeight))
-	}
-	if newRecord.UriWeight > 0 {
-		formData.Add("newUriWeight", fmt.Sprintf("%d", newRecord.UriWeight))
-	}
-	if oldRecord.Uri != "" {
-		formData.Add("uri", oldRecord.Uri)
-	}
-	if newRecord.Uri != "" {
-		formData.Add("newUri", newRecord.Uri)
-	}
-	if oldRecord.Flags != "" {
-		formData.Add("flags", oldRecord.Flags)
-	}
-	if newRecord.Flags != "" {
-		formData.Add("newFlags", newRecord.Flags)
-	}
-	if oldRecord.Tag != "" {
-		formData.Add("tag", oldRecord.Tag)
-	}
-	if newRecord.Tag != "" {
-		formData.Add("newTag", newRecord.Tag)
-	}
-	if oldRecord.Value != "" {
-		formData.Add("value", oldRecord.Value)
-	}
-	if newRecord.Value != "" {
-		formData.Add("newValue", newRecord.Value)
-	}
-	if oldRecord.AName != "" {
-		formData.Add("aname", oldRecord.AName)
-	}
-	if newRecord.AName != "" {
-		formData.Add("newAName", newRecord.AName)
-	}
-	if oldRecord.Forwarder != "" {
-		formData.Add("forwarder", oldRecord.Forwarder)
-	}
-	if newRecord.Forwarder != "" {
-		formData.Add("newForwarder", newRecord.Forwarder)
-	}
-	if oldRecord.ForwarderPriority > 0 {
-		formData.Add("forwarderPriority", fmt.Sprintf("%d", oldRecord.ForwarderPriority))
-	}
-	if newRecord.ForwarderPriority > 0 {
-		formData.Add("newForwarderPriority", fmt.Sprintf("%d", newRecord.ForwarderPriority))
-	}
-	if newRecord.DnssecValidation {
-		formData.Add("dnssecValidation", "true")
-	}
-	if newRecord.ProxyType != "" {
-		formData.Add("proxyType", newRecord.ProxyType)
-	}
-	if newRecord.ProxyAddress != "" {
-		formData.Add("proxyAddress", newRecord.ProxyAddress)
-	}
-	if newRecord.ProxyPort > 0 {
-		formData.Add("proxyPort", fmt.Sprintf("%d", newRecord.ProxyPort))
-	}
-	if newRecord.ProxyUsername != "" {
-		formData.Add("proxyUsername", newRecord.ProxyUsername)
-	}
-	if newRecord.ProxyPassword != "" {
-		formData.Add("proxyPassword", newRecord.ProxyPassword)
-	}
-	if oldRecord.AppName != "" {
-		formData.Add("appName", oldRecord.AppName)
-	}
-	if oldRecord.ClassPath != "" {
-		formData.Add("classPath", oldRecord.ClassPath)
-	}
-	if newRecord.RecordData != "" {
-		formData.Add("recordData", newRecord.RecordData)
-	}
+	encodeUpdateForm(formData, oldRecord, newRecord)
+	encodeExtraForm(formData, newRecord.ExtraRData)
 
 	// Keep this to force update the record.
 	formData.Add("overwrite", "true")
@@ -854,168 +627,7 @@ func (c Client) DeleteRecord(ctx context.Context, record model.DNSRecord) error
 	}
 	params.Add("type", string(record.Type))
 
-	if record.IPAddress != "" {
-		params.Add("ipAddress", record.IPAddress)
-	}
-	if record.Ptr {
-		params.Add("ptr", "true")
-	}
-	if record.NameServer != "" {
-		params.Add("nameServer", record.NameServer)
-	}
-	if record.Glue != "" {
-		params.Add("glue", record.Glue)
-	}
-	if record.CName != "" {
-		params.Add("cname", record.CName)
-	}
-	if record.PtrName != "" {
-		params.Add("ptrName", record.PtrName)
-	}
-	if record.Exchange != "" {
-		params.Add("exchange", record.Exchange)
-	}
-	if record.Preference > 0 {
-		params.Add("preference", fmt.Sprintf("%d", record.Preference))
-	}
-	if record.Text != "" {
-		params.Add("text", record.Text)
-	}
-	if record.SplitText {
-		params.Add("splitText", "true")
-	}
-	if record.Mailbox != "" {
-		params.Add("mailbox", record.Mailbox)
-	}
-	if record.TxtDomain != "" {
-		params.Add("txtDomain", record.TxtDomain)
-	}
-	if record.Priority > 0 {
-		params.Add("priority", fmt.Sprintf("%d", record.Priority))
-	}
-	if record.Weight > 0 {
-		params.Add("weight", fmt.Sprintf("%d", record.Weight))
-	}
-	if record.Port > 0 {
-		params.Add("port", fmt.Sprintf("%d", record.Port))
-	}
-	if record.Target != "" {
-		params.Add("target", string(record.Target))
-	}
-	if record.NaptrOrder > 0 {
-		params.Add("naptrOrder", fmt.Sprintf("%d", record.NaptrOrder))
-	}
-	if record.NaptrPreference > 0 {
-		params.Add("naptrPreference", fmt.Sprintf("%d", record.NaptrPreference))
-	}
-	if record.NaptrFlags != "" {
-		params.Add("naptrFlags", record.NaptrFlags)
-	}
-	if record.NaptrServices != "" {
-		params.Add("naptrServices", record.NaptrServices)
-	}
-	if record.NaptrRegexp != "" {
-		params.Add("naptrRegexp", record.NaptrRegexp)
-	}
-	if record.NaptrReplacement != "" {
-		params.Add("naptrReplacement", record.NaptrReplacement)
-	}
-	if record.DName != "" {
-		params.Add("dName", record.DName)
-	}
-	if record.KeyTag > 0 {
-		params.Add("keyTag", fmt.Sprintf("%d", record.KeyTag))
-	}
-	if record.Algorithm != "" {
-		params.Add("algorithm", record.Algorithm)
-	}
-	if record.DigestType != "" {
-		params.Add("digestType", record.DigestType)
-	}
-	if record.Digest != "" {
-		params.Add("digest", record.Digest)
-	}
-	if record.SshfpAlgorithm != "" {
-		params.Add("sshfpAlgorithm", record.SshfpAlgorithm)
-	}
-	if record.SshfpFingerprintType != "" {
-		params.Add("sshfpFingerprintType", record.SshfpFingerprintType)
-	}
-	if record.SshfpFingerprint != "" {
-		params.Add("sshfpFingerprint", record.SshfpFingerprint)
-	}
-	if record.TlsaCertificateUsage != "" {
-		params.Add("tlsaCertificateUsage", record.TlsaCertificateUsage)
-	}
-	if record.TlsaSelector != "" {
-		params.Add("tlsaSelector", record.TlsaSelector)
-	}
-	if record.TlsaMatchingType != "" {
-		params.Add("tlsaMatchingType", record.TlsaMatchingType)
-	}
-	if record.TlsaCertificateAssociationData != "" {
-		params.Add("tlsaCertificateAssociationData", record.TlsaCertificateAssociationData)
-	}
-	if record.SvcPriority > 0 {
-		params.Add("svcPriority", fmt.Sprintf("%d", record.SvcPriority))
-	}
-	if record.SvcTargetName != "" {
-		params.Add("svcTargetName", record.SvcTargetName)
-	}
-	if record.SvcParams != "" {
-		params.Add("svcParams", record.SvcParams)
-	}
-	if record.UriPriority > 0 {
-		params.Add("uriPriority", fmt.Sprintf("%d", record.UriPriority))
-	}
-	if record.UriWeight > 0 {
-		params.Add("uriWeight", fmt.Sprintf("%d", record.UriWeight))
-	}
-	if record.Uri != "" {
-		params.Add("uri", record.Uri)
-	}
-	if record.Flags != "" {
-		params.Add("flags", record.Flags)
-	}
-	if record.Tag != "" {
-		params.Add("tag", record.Tag)
-	}
-	if record.Value != "" {
-		params.Add("value", record.Value)
-	}
-	if record.AName != "" {
-		params.Add("aName", record.AName)
-	}
-	if record.Forwarder != "" {
-		params.Add("forwarder", record.Forwarder)
-	}
-	if record.ForwarderPriority > 0 {
-		params.Add("forwarderPriority", fmt.Sprintf("%d", record.ForwarderPriority))
-	}
-	if record.ProxyType != "" {
-		params.Add("proxyType", record.ProxyType)
-	}
-	if record.ProxyAddress != "" {
-		params.Add("proxyAddress", record.ProxyAddress)
-	}
-	if record.ProxyPort > 0 {
-		params.Add("proxyPort", fmt.Sprintf("%d", record.ProxyPort))
-	}
-	if record.ProxyUsername != "" {
-		params.Add("proxyUsername", record.ProxyUsername)
-	}
-	if record.ProxyPassword != "" {
-		params.Add("proxyPassword", record.ProxyPassword)
-	}
-	if record.AppName != "" {
-		params.Add("appName", record.AppName)
-	}
-	if record.ClassPath != "" {
-		params.Add("classPath", record.ClassPath)
-	}
-	if record.RecordData != "" {
-		params.Add("recordData", record.RecordData)
-	}
+	encodeForm(params, record, formDelete)
 
 	return c.makeRecordsRequest(ctx, "/delete", http.MethodGet, params, nil, nil)
 }
@@ -1027,29 +639,18 @@ func (c Client) GetZoneRecords(ctx context.Context, zoneName string) ([]model.DN
 	params.Add("domain", zoneName)
 	params.Add("listZone", "true")
 
-	var apiResponse apiResponse
-	err := c.makeRecordsRequest(ctx, "/get", http.MethodGet, params, nil, &apiResponse)
+	apiResponse := apiRecordsStream{zone: zoneName}
+	err := c.makeRequest(ctx, DOMAINS_URL+"/get", http.MethodGet, params, nil, &apiResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	res := make([]model.DNSRecord, len(apiResponse.Response.Records))
-	for i, rr := range apiResponse.Response.Records {
-		res[i] = mapAPIDNSRecordToDNSRecord(rr, zoneName)
-	}
-
-	return res, nil
+	return apiResponse.records, nil
 }
 
 // ListZones retrieves all DNS zones from the server.
 func (c Client) ListZones(ctx context.Context) ([]model.DNSZone, error) {
-	var apiResponse struct {
-		Response struct {
-			Zones []model.DNSZone `json:"zones"`
-		} `json:"response"`
-		Status string `json:"status"`
-	}
-
+	var apiResponse apiZonesListResponse
 	err := c.makeZonesRequest(ctx, "/list", http.MethodGet, nil, nil, &apiResponse)
 	if err != nil {
 		return nil, err
@@ -1058,10 +659,27 @@ func (c Client) ListZones(ctx context.Context) ([]model.DNSZone, error) {
 	return apiResponse.Response.Zones, nil
 }
 
+// GetZoneOptions retrieves the settings of a DNS zone.
+func (c Client) GetZoneOptions(ctx context.Context, zoneName string) (model.DNSZoneOptions, error) {
+	var apiResponse apiZoneOptionsResponse
+	params := url.Values{
+		"zone": {zoneName},
+	}
+
+	err := c.makeZonesRequest(ctx, "/options/get", http.MethodGet, params, nil, &apiResponse)
+	if err != nil {
+		return model.DNSZoneOptions{}, err
+	}
+
+	return apiResponse.Response, nil
+}
+
 // CreateZone creates a new DNS zone.
 func (c Client) CreateZone(ctx context.Context, zone model.DNSZone) error {
-	if zone.Type == model.ZONE_SECONDARY || zone.Type == model.ZONE_STUB {
-		return errors.New("creating SECONDARY and STUB zones is not yet implemented - missing primary name server configuration")
+	// Secondary and Stub zones find their primary from the zone NS records
+	// when none is given, the others cannot
+	if (zone.Type == model.ZONE_SECONDARYFORWARDER || zone.Type == model.ZONE_SECONDARYCATALOG) && zone.PrimaryNameServerAddresses == "" {
+		return errors.Errorf("primary name server addresses are required to create %s zones", zone.Type)
 	}
 
 	formData := url.Values{
@@ -1116,7 +734,75 @@ func (c Client) CreateZone(ctx context.Context, zone model.DNSZone) error {
 		formData.Set("proxyPassword", zone.ProxyPassword)
 	}
 
-	return c.makeZonesRequest(ctx, "/create", http.MethodPost, nil, formData, nil)
+	var apiResponse apiZoneCreateResponse
+	return c.makeZonesRequest(ctx, "/create", http.MethodPost, nil, formData, &apiResponse)
+}
+
+// SetZoneOptions changes zone settings in place, see model.DNSZoneOptionsUpdate.
+func (c Client) SetZoneOptions(ctx context.Context, zoneName string, options model.DNSZoneOptionsUpdate) error {
+	formData := url.Values{
+		"zone": {zoneName},
+	}
+
+	if options.QueryAccess != "" {
+		formData.Set("queryAccess", options.QueryAccess)
+	}
+	if options.QueryAccessNetworkACL != nil {
+		formData.Set("queryAccessNetworkACL", formList(options.QueryAccessNetworkACL))
+	}
+	if options.Update != "" {
+		formData.Set("update", options.Update)
+	}
+	if options.UpdateNetworkACL != nil {
+		formData.Set("updateNetworkACL", formList(options.UpdateNetworkACL))
+	}
+	if options.UpdateSecurityPolicies != nil {
+		// pipe separated table of key name, domain and comma separated types
+		var table []string
+		for _, policy := range options.UpdateSecurityPolicies {
+			table = append(table, policy.TsigKeyName, policy.Domain, strings.Join(policy.AllowedTypes, ","))
+		}
+		formData.Set("updateSecurityPolicies", formTable(table))
+	}
+	if options.Notify != "" {
+		formData.Set("notify", options.Notify)
+	}
+	if options.NotifyNameServers != nil {
+		formData.Set("notifyNameServers", formList(options.NotifyNameServers))
+	}
+	if options.ZoneTransfer != "" {
+		formData.Set("zoneTransfer", options.ZoneTransfer)
+	}
+	if options.ZoneTransferNetworkACL != nil {
+		formData.Set("zoneTransferNetworkACL", formList(options.ZoneTransferNetworkACL))
+	}
+	if options.ValidateZone != nil {
+		formData.Set("validateZone", fmt.Sprintf("%t", *options.ValidateZone))
+	}
+
+	if len(formData) == 1 {
+		// nothing to change
+		return nil
+	}
+
+	var apiResponse apiEmptyResponse
+	return c.makeZonesRequest(ctx, "/options/set", http.MethodPost, nil, formData, &apiResponse)
+}
+
+// comma separated list parameter, the API clears the list on "false"
+func formList(values []string) string {
+	if len(values) == 0 {
+		return "false"
+	}
+	return strings.Join(values, ",")
+}
+
+// pipe separated table parameter, the API clears the table on "false"
+func formTable(cells []string) string {
+	if len(cells) == 0 {
+		return "false"
+	}
+	return strings.Join(cells, "|")
 }
 
 // DeleteZone deletes a DNS zone.
@@ -1125,7 +811,18 @@ func (c Client) DeleteZone(ctx context.Context, zoneName string) error {
 		"zone": {zoneName},
 	}
 
-	return c.makeZonesRequest(ctx, "/delete", http.MethodPost, nil, formData, nil)
+	var apiResponse apiEmptyResponse
+	return c.makeZonesRequest(ctx, "/delete", http.MethodPost, nil, formData, &apiResponse)
+}
+
+// ResyncZone makes a zone replicated from a primary server refresh from it now.
+func (c Client) ResyncZone(ctx context.Context, zoneName string) error {
+	formData := url.Values{
+		"zone": {zoneName},
+	}
+
+	var apiResponse apiEmptyResponse
+	return c.makeZonesRequest(ctx, "/resync", http.MethodPost, nil, formData, &apiResponse)
 }
 
 func constructFullDomain(name, zone string) string {
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+type apiTopStatsResponse struct {
+	apiStatus
+	Response struct {
+		TopDomains        []model.DNSTopDomain `json:"topDomains"`
+		TopBlockedDomains []model.DNSTopDomain `json:"topBlockedDomains"`
+	} `json:"response"`
+}
+
+// GetTopDomains retrieves the most queried or blocked domains over a period.
+func (c Client) GetTopDomains(ctx context.Context, statsType string, period model.DNSStatsPeriod, limit int64) ([]model.DNSTopDomain, error) {
+	params := url.Values{
+		"type":      {period.Type},
+		"statsType": {statsType},
+		"limit":     {strconv.FormatInt(limit, 10)},
+	}
+	if period.Type == model.STATS_CUSTOM {
+		params.Set("start", period.Start)
+		params.Set("end", period.End)
+	}
+
+	var apiResponse apiTopStatsResponse
+	err := c.makeRequest(ctx, DASHBOARD_URL+"/stats/getTop", http.MethodGet, params, nil, &apiResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if statsType == model.STATS_TOP_BLOCKED_DOMAINS {
+		return apiResponse.Response.TopBlockedDomains, nil
+	}
+	return apiResponse.Response.TopDomains, nil
+}
@@ -0,0 +1,18 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// RemoveDhcpLease removes the lease of a client from a DHCP scope.
+func (c Client) RemoveDhcpLease(ctx context.Context, scopeName string, clientIdentifier string) error {
+	formData := url.Values{
+		"name":             {scopeName},
+		"clientIdentifier": {clientIdentifier},
+	}
+
+	var apiResponse apiEmptyResponse
+	return c.makeRequest(ctx, DHCP_URL+"/leases/remove", http.MethodPost, nil, formData, &apiResponse)
+}
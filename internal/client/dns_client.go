@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+type apiResolveResponse struct {
+	apiStatus
+	Response struct {
+		Result struct {
+			Answer []model.DNSResolvedRecord `json:"Answer"`
+		} `json:"result"`
+	} `json:"response"`
+}
+
+// Resolve queries a name server (or the server itself with
+// model.DNS_CLIENT_THIS_SERVER) through the server DNS client and returns the answers.
+func (c Client) Resolve(ctx context.Context, server string, domain string, recordType model.DNSRecordType) ([]model.DNSResolvedRecord, error) {
+	params := url.Values{
+		"server":   {server},
+		"domain":   {domain},
+		"type":     {string(recordType)},
+		"protocol": {"Udp"},
+		"dnssec":   {"false"},
+	}
+
+	var apiResponse apiResolveResponse
+	err := c.makeRequest(ctx, DNS_CLIENT_URL+"/resolve", http.MethodGet, params, nil, &apiResponse)
+	if err != nil {
+		return nil, err
+	}
+	return apiResponse.Response.Result.Answer, nil
+}
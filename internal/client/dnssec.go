@@ -0,0 +1,275 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+const DNSSEC_URL = ZONES_URL + "/dnssec"
+
+type apiDnssecPropertiesResponse struct {
+	apiStatus
+	Response model.DNSZoneDnssecProperties `json:"response"`
+}
+
+type apiDSRecordsResponse struct {
+	apiStatus
+	Response struct {
+		DSRecords []model.DNSZoneDSRecord `json:"dsRecords"`
+	} `json:"response"`
+}
+
+// SignZone signs a primary zone with DNSSEC.
+func (c Client) SignZone(ctx context.Context, zoneName string, signing model.DNSZoneSigning) error {
+	formData := url.Values{
+		"zone":      {zoneName},
+		"algorithm": {signing.Algorithm},
+	}
+
+	setString := func(name string, value string) {
+		if value != "" {
+			formData.Set(name, value)
+		}
+	}
+	setInt := func(name string, value *int64) {
+		if value != nil {
+			formData.Set(name, strconv.FormatInt(*value, 10))
+		}
+	}
+	setString("hashAlgorithm", signing.HashAlgorithm)
+	if signing.KskKeySize > 0 {
+		setInt("kskKeySize", &signing.KskKeySize)
+	}
+	if signing.ZskKeySize > 0 {
+		setInt("zskKeySize", &signing.ZskKeySize)
+	}
+	setString("curve", signing.Curve)
+	setInt("dnsKeyTtl", signing.DnsKeyTtl)
+	setInt("zskRolloverDays", signing.ZskRolloverDays)
+	setString("nxProof", signing.NxProof)
+	setInt("iterations", signing.Iterations)
+	setInt("saltLength", signing.SaltLength)
+	setString("pemKskPrivateKey", signing.PemKskPrivateKey)
+	setString("pemZskPrivateKey", signing.PemZskPrivateKey)
+
+	var apiResponse apiEmptyResponse
+	return c.makeRequest(ctx, DNSSEC_URL+"/sign", http.MethodPost, nil, formData, &apiResponse)
+}
+
+// UnsignZone removes DNSSEC signing of a zone.
+func (c Client) UnsignZone(ctx context.Context, zoneName string) error {
+	formData := url.Values{
+		"zone": {zoneName},
+	}
+
+	var apiResponse apiEmptyResponse
+	return c.makeRequest(ctx, DNSSEC_URL+"/unsign", http.MethodPost, nil, formData, &apiResponse)
+}
+
+// GetDnssecProperties retrieves DNSSEC settings and private keys of a zone.
+func (c Client) GetDnssecProperties(ctx context.Context, zoneName string) (model.DNSZoneDnssecProperties, error) {
+	params := url.Values{
+		"zone": {zoneName},
+	}
+
+	var apiResponse apiDnssecPropertiesResponse
+	err := c.makeRequest(ctx, DNSSEC_URL+"/properties/get", http.MethodGet, params, nil, &apiResponse)
+	if err != nil {
+		return model.DNSZoneDnssecProperties{}, err
+	}
+	return apiResponse.Response, nil
+}
+
+// GetDSRecords retrieves the DS records (and public keys) of a signed zone.
+func (c Client) GetDSRecords(ctx context.Context, zoneName string) ([]model.DNSZoneDSRecord, error) {
+	params := url.Values{
+		"zone": {zoneName},
+	}
+
+	var apiResponse apiDSRecordsResponse
+	err := c.makeRequest(ctx, DNSSEC_URL+"/viewDS", http.MethodGet, params, nil, &apiResponse)
+	if err != nil {
+		return nil, err
+	}
+	return apiResponse.Response.DSRecords, nil
+}
+
+// UpdateDnsKeyTtl changes the TTL of the DNSKEY records of a signed zone.
+func (c Client) UpdateDnsKeyTtl(ctx context.Context, zoneName string, ttl int64) error {
+	formData := url.Values{
+		"zone": {zoneName},
+		"ttl":  {strconv.FormatInt(ttl, 10)},
+	}
+
+	var apiResponse apiEmptyResponse
+	return c.makeRequest(ctx, DNSSEC_URL+"/properties/updateDnsKeyTtl", http.MethodPost, nil, formData, &apiResponse)
+}
+
+// UpdateDnssecKeyRollover changes the automatic rollover period of the private
+// keys of a type that are not retiring, 0 disables it. Keys are per server, so
+// they are looked up by type rather than by key tag.
+func (c Client) UpdateDnssecKeyRollover(ctx context.Context, zoneName string, keyType string, rolloverDays int64) error {
+	properties, err := c.GetDnssecProperties(ctx, zoneName)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range properties.DnssecPrivateKeys {
+		if key.KeyType != keyType || key.IsRetiring || key.RolloverDays == rolloverDays {
+			continue
+		}
+		formData := url.Values{
+			"zone":         {zoneName},
+			"keyTag":       {strconv.FormatInt(key.KeyTag, 10)},
+			"rolloverDays": {strconv.FormatInt(rolloverDays, 10)},
+		}
+
+		var apiResponse apiEmptyResponse
+		err := c.makeRequest(ctx, DNSSEC_URL+"/properties/updatePrivateKey", http.MethodPost, nil, formData, &apiResponse)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateDnssecNxProof converts a signed zone between NSEC and NSEC3, or
+// changes its NSEC3 iterations and salt length.
+func (c Client) UpdateDnssecNxProof(ctx context.Context, zoneName string, nxProof string, iterations int64, saltLength int64) error {
+	properties, err := c.GetDnssecProperties(ctx, zoneName)
+	if err != nil {
+		return err
+	}
+
+	formData := url.Values{
+		"zone": {zoneName},
+	}
+	var action string
+	switch {
+	case nxProof == model.DNSSEC_NSEC && properties.DNSSecStatus == model.DNSSEC_STATUS_SIGNED_WITH_NSEC:
+		return nil
+	case nxProof == model.DNSSEC_NSEC:
+		action = "/properties/convertToNSEC"
+	case properties.DNSSecStatus == model.DNSSEC_STATUS_SIGNED_WITH_NSEC3:
+		if properties.Nsec3Iterations == iterations && properties.Nsec3SaltLength == saltLength {
+			return nil
+		}
+		action = "/properties/updateNSEC3Params"
+	default:
+		action = "/properties/convertToNSEC3"
+	}
+	if nxProof == model.DNSSEC_NSEC3 {
+		formData.Set("iterations", strconv.FormatInt(iterations, 10))
+		formData.Set("saltLength", strconv.FormatInt(saltLength, 10))
+	}
+
+	var apiResponse apiEmptyResponse
+	return c.makeRequest(ctx, DNSSEC_URL+action, http.MethodPost, nil, formData, &apiResponse)
+}
+
+// RolloverDnssecAlgorithm moves a signed zone to other signing parameters
+// without unsigning it, so the chain of trust is kept: key signing and zone
+// signing keys of the new algorithm are generated and published next to the
+// current ones, and the keys of other algorithms are retired once a successor
+// of the same type is active. Key signing keys only become active after the
+// parent zone publishes their DS record, so the rollover completes over
+// successive calls; calling it again with the same parameters is safe.
+func (c Client) RolloverDnssecAlgorithm(ctx context.Context, zoneName string, signing model.DNSZoneSigning) error {
+	properties, err := c.GetDnssecProperties(ctx, zoneName)
+	if err != nil {
+		return err
+	}
+
+	algorithm := model.DnssecAlgorithmName(signing.Algorithm, signing.HashAlgorithm, signing.Curve)
+	isCurrent := func(key model.DNSSecPrivateKey) bool {
+		return !key.IsRetiring && key.State != model.DNSSEC_KEY_STATE_RETIRED && key.State != model.DNSSEC_KEY_STATE_REVOKED
+	}
+
+	generated := false
+	for _, keyType := range []string{model.DNSSEC_KEY_SIGNING_KEY, model.DNSSEC_ZONE_SIGNING_KEY} {
+		hasSuccessor, successorActive := false, false
+		for _, key := range properties.DnssecPrivateKeys {
+			if key.KeyType == keyType && key.Algorithm == algorithm && isCurrent(key) {
+				hasSuccessor = true
+				successorActive = successorActive || key.State == model.DNSSEC_KEY_STATE_ACTIVE
+			}
+		}
+
+		for _, key := range properties.DnssecPrivateKeys {
+			if key.KeyType != keyType || key.Algorithm == algorithm || !isCurrent(key) {
+				continue
+			}
+			// keys left over by an earlier, unfinished rollover are dropped
+			// before they are published; active keys wait for their successor
+			action := ""
+			switch {
+			case key.State == model.DNSSEC_KEY_STATE_GENERATED:
+				action = "/properties/deletePrivateKey"
+			case key.State == model.DNSSEC_KEY_STATE_ACTIVE && successorActive:
+				action = "/properties/retirePrivateKey"
+			default:
+				continue
+			}
+			formData := url.Values{
+				"zone":   {zoneName},
+				"keyTag": {strconv.FormatInt(key.KeyTag, 10)},
+			}
+
+			var apiResponse apiEmptyResponse
+			err := c.makeRequest(ctx, DNSSEC_URL+action, http.MethodPost, nil, formData, &apiResponse)
+			if err != nil {
+				return err
+			}
+		}
+
+		if hasSuccessor {
+			continue
+		}
+		formData := url.Values{
+			"zone":      {zoneName},
+			"keyType":   {keyType},
+			"algorithm": {signing.Algorithm},
+		}
+		keySize, pemPrivateKey, rolloverDays := signing.KskKeySize, signing.PemKskPrivateKey, int64(0)
+		if keyType == model.DNSSEC_ZONE_SIGNING_KEY {
+			keySize, pemPrivateKey = signing.ZskKeySize, signing.PemZskPrivateKey
+			if signing.ZskRolloverDays != nil {
+				rolloverDays = *signing.ZskRolloverDays
+			}
+		}
+		formData.Set("rolloverDays", strconv.FormatInt(rolloverDays, 10))
+		if signing.HashAlgorithm != "" {
+			formData.Set("hashAlgorithm", signing.HashAlgorithm)
+		}
+		if signing.Curve != "" {
+			formData.Set("curve", signing.Curve)
+		}
+		if keySize > 0 {
+			formData.Set("keySize", strconv.FormatInt(keySize, 10))
+		}
+		if pemPrivateKey != "" {
+			formData.Set("pemPrivateKey", pemPrivateKey)
+		}
+
+		var apiResponse apiEmptyResponse
+		err := c.makeRequest(ctx, DNSSEC_URL+"/properties/generatePrivateKey", http.MethodPost, nil, formData, &apiResponse)
+		if err != nil {
+			return err
+		}
+		generated = true
+	}
+
+	if !generated {
+		return nil
+	}
+	formData := url.Values{
+		"zone": {zoneName},
+	}
+
+	var apiResponse apiEmptyResponse
+	return c.makeRequest(ctx, DNSSEC_URL+"/properties/publishAllPrivateKeys", http.MethodPost, nil, formData, &apiResponse)
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// how long an unreachable endpoint is skipped before being tried again
+const ENDPOINT_RETRY_DELAY = 30 * time.Second
+
+// API endpoints of an HA deployment, first one is the primary.
+// Requests stick to the last endpoint that answered for the run
+type endpointPool struct {
+	mu        sync.Mutex
+	urls      []string
+	current   int
+	downUntil []time.Time
+}
+
+func newEndpointPool(urls []string) *endpointPool {
+	return &endpointPool{
+		urls:      urls,
+		downUntil: make([]time.Time, len(urls)),
+	}
+}
+
+// endpoint indexes to try in order: current one, other healthy ones, then those marked down
+func (p *endpointPool) order() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	order := []int{p.current}
+	var down []int
+	for i := range p.urls {
+		if i == p.current {
+			continue
+		}
+		if now.Before(p.downUntil[i]) {
+			down = append(down, i)
+		} else {
+			order = append(order, i)
+		}
+	}
+	return append(order, down...)
+}
+
+func (p *endpointPool) markDown(i int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.downUntil[i] = time.Now().Add(ENDPOINT_RETRY_DELAY)
+}
+
+func (p *endpointPool) markUp(i int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.downUntil[i] = time.Time{}
+	p.current = i
+}
+
+// whether a failed request can be retried on another endpoint.
+// Writes only when the connection was never established, so they are not applied twice
+func canFailover(ctx context.Context, method string, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if method == http.MethodGet {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// API call a record is encoded for, see form tags of model.DNSRecord
+type formOp int
+
+const (
+	formAdd formOp = iota
+	formUpdateOld
+	formUpdateNew
+	formDelete
+)
+
+// form tag options
+const (
+	FORM_KEY      = "key"
+	FORM_NEW      = "new"
+	FORM_OLD      = "old"
+	FORM_NODELETE = "nodelete"
+
+	FORM_NEW_PREFIX = "new"
+)
+
+// add form tagged fields of a struct to formData, zero values are skipped
+func encodeForm(formData url.Values, v interface{}, op formOp) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		tag, ok := valueType.Field(i).Tag.Lookup("form")
+		if !ok {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		paramName, ok := formParamName(name, strings.Split(options, ","), op)
+		if !ok {
+			continue
+		}
+		if paramValue, ok := formValue(value.Field(i)); ok {
+			formData.Add(paramName, paramValue)
+		}
+	}
+}
+
+// raw parameters, see model.DNSRecord.ExtraRData
+func encodeExtraForm(formData url.Values, extra map[string]string) {
+	for name, value := range extra {
+		formData.Add(name, value)
+	}
+}
+
+// update sends old values of key fields under their name, new ones with "new" prefix
+func encodeUpdateForm(formData url.Values, oldValue interface{}, newValue interface{}) {
+	encodeForm(formData, oldValue, formUpdateOld)
+	encodeForm(formData, newValue, formUpdateNew)
+}
+
+// parameter name of a field for op, false if it is not sent
+func formParamName(name string, options []string, op formOp) (string, bool) {
+	has := func(option string) bool {
+		for _, o := range options {
+			if o == option {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch op {
+	case formUpdateOld:
+		return name, has(FORM_KEY) || has(FORM_OLD)
+	case formUpdateNew:
+		if has(FORM_KEY) {
+			return FORM_NEW_PREFIX + strings.ToUpper(name[:1]) + name[1:], true
+		}
+		return name, has(FORM_NEW)
+	case formDelete:
+		return name, !has(FORM_NODELETE)
+	default:
+		return name, true
+	}
+}
+
+// string form of a field value, false for zero values
+func formValue(field reflect.Value) (string, bool) {
+	if field.IsZero() {
+		return "", false
+	}
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), true
+	case reflect.Bool:
+		return "true", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), true
+	default:
+		return "", false
+	}
+}
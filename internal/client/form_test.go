@@ -0,0 +1,129 @@
+package client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestEncodeForm(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		record model.DNSRecord
+		op     formOp
+		want   url.Values
+	}{
+		{
+			name:   "add A skips untagged and zero fields",
+			record: model.DNSRecord{Type: model.REC_A, Domain: "a.example.com", TTL: 3600, IPAddress: "10.0.0.1", Ptr: true},
+			op:     formAdd,
+			want:   url.Values{"ipAddress": {"10.0.0.1"}, "ptr": {"true"}},
+		},
+		{
+			name:   "add MX formats numbers",
+			record: model.DNSRecord{Type: model.REC_MX, Exchange: "mail.example.com", Preference: 10},
+			op:     formAdd,
+			want:   url.Values{"exchange": {"mail.example.com"}, "preference": {"10"}},
+		},
+		{
+			name:   "add ANAME",
+			record: model.DNSRecord{Type: model.REC_ANAME, AName: "target.example.com"},
+			op:     formAdd,
+			want:   url.Values{"aname": {"target.example.com"}},
+		},
+		{
+			name:   "add SRV with named types",
+			record: model.DNSRecord{Type: model.REC_SRV, Priority: 1, Weight: 5, Port: 389, Target: "ldap.example.com"},
+			op:     formAdd,
+			want:   url.Values{"priority": {"1"}, "weight": {"5"}, "port": {"389"}, "target": {"ldap.example.com"}},
+		},
+		{
+			name:   "delete skips nodelete fields",
+			record: model.DNSRecord{Type: model.REC_A, IPAddress: "10.0.0.1", Ptr: true, CreatePtrZone: true, ExpiryTTL: 60},
+			op:     formDelete,
+			want:   url.Values{"ipAddress": {"10.0.0.1"}, "ptr": {"true"}},
+		},
+		{
+			name:   "delete FWD sends protocol",
+			record: model.DNSRecord{Type: model.REC_FWD, Protocol: "Tls", Forwarder: "1.1.1.1", DnssecValidation: true},
+			op:     formDelete,
+			want:   url.Values{"protocol": {"Tls"}, "forwarder": {"1.1.1.1"}},
+		},
+		{
+			name:   "empty record",
+			record: model.DNSRecord{},
+			op:     formAdd,
+			want:   url.Values{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := url.Values{}
+			encodeForm(got, tt.record, tt.op)
+			if !cmp.Equal(tt.want, got) {
+				t.Error(cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestEncodeUpdateForm(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		oldRecord model.DNSRecord
+		newRecord model.DNSRecord
+		want      url.Values
+	}{
+		{
+			name:      "MX key fields get old and new values",
+			oldRecord: model.DNSRecord{Type: model.REC_MX, Exchange: "mx1.example.com", Preference: 10},
+			newRecord: model.DNSRecord{Type: model.REC_MX, Exchange: "mx2.example.com", Preference: 20},
+			want: url.Values{
+				"exchange": {"mx1.example.com"}, "newExchange": {"mx2.example.com"},
+				"preference": {"10"}, "newPreference": {"20"},
+			},
+		},
+		{
+			name:      "CNAME only sends new value",
+			oldRecord: model.DNSRecord{Type: model.REC_CNAME, CName: "old.example.com"},
+			newRecord: model.DNSRecord{Type: model.REC_CNAME, CName: "new.example.com"},
+			want:      url.Values{"cname": {"new.example.com"}},
+		},
+		{
+			name:      "APP identified by old app and class, new data",
+			oldRecord: model.DNSRecord{Type: model.REC_APP, AppName: "Geo", ClassPath: "Geo.Country", RecordData: "{}"},
+			newRecord: model.DNSRecord{Type: model.REC_APP, AppName: "Other", ClassPath: "Other.Class", RecordData: `{"a":1}`},
+			want:      url.Values{"appName": {"Geo"}, "classPath": {"Geo.Country"}, "recordData": {`{"a":1}`}},
+		},
+		{
+			name:      "A record flags from new record",
+			oldRecord: model.DNSRecord{Type: model.REC_A, IPAddress: "10.0.0.1", Ptr: true},
+			newRecord: model.DNSRecord{Type: model.REC_A, IPAddress: "10.0.0.2", CreatePtrZone: true},
+			want: url.Values{
+				"ipAddress": {"10.0.0.1"}, "newIpAddress": {"10.0.0.2"},
+				"createPtrZone": {"true"},
+			},
+		},
+		{
+			name:      "ANAME new value prefix",
+			oldRecord: model.DNSRecord{Type: model.REC_ANAME, AName: "a.example.com"},
+			newRecord: model.DNSRecord{Type: model.REC_ANAME, AName: "b.example.com"},
+			want:      url.Values{"aname": {"a.example.com"}, "newAname": {"b.example.com"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := url.Values{}
+			encodeUpdateForm(got, tt.oldRecord, tt.newRecord)
+			if !cmp.Equal(tt.want, got) {
+				t.Error(cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+	"github.com/pkg/errors"
+)
+
+const LOG_DOWNLOAD_TIMEOUT = 10 * time.Minute
+
+type apiLogFilesResponse struct {
+	apiStatus
+	Response struct {
+		LogFiles []model.DNSLogFile `json:"logFiles"`
+	} `json:"response"`
+}
+
+// ListLogFiles retrieves the server log files.
+func (c Client) ListLogFiles(ctx context.Context) ([]model.DNSLogFile, error) {
+	var apiResponse apiLogFilesResponse
+	err := c.makeRequest(ctx, LOGS_URL+"/list", http.MethodGet, nil, nil, &apiResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResponse.Response.LogFiles, nil
+}
+
+// DownloadLogFile retrieves the content of a server log file,
+// its last limitMB megabytes if limitMB > 0.
+func (c Client) DownloadLogFile(ctx context.Context, fileName string, limitMB int64) (content []byte, err error) {
+	params := url.Values{
+		"fileName": {fileName},
+	}
+	if limitMB > 0 {
+		params.Set("limit", strconv.FormatInt(limitMB, 10))
+	}
+
+	ctx, span := c.startSpan(ctx, LOGS_URL+"/download", http.MethodGet, params, nil)
+	defer func() { endSpan(span, err) }()
+
+	// large files can take long, unless the caller has a deadline
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, LOG_DOWNLOAD_TIMEOUT)
+		defer cancel()
+	}
+
+	err = c.withSession(ctx, func(ctx context.Context, token string) error {
+		resp, err := c.doRequest(ctx, token, LOGS_URL+"/download", http.MethodGet, params, nil)
+		if err != nil {
+			return err
+		}
+		defer closeBody(resp)
+
+		// file content on success, JSON status on error
+		mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+		if mediaType == "application/json" {
+			var status apiStatus
+			if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+				return errors.Wrap(err, "cannot decode JSON response into the provided structure")
+			}
+			if err := status.apiError(); err != nil {
+				return err
+			}
+			return errors.New("unexpected JSON response instead of log file content")
+		}
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("cannot download log file: HTTP status %s", resp.Status)
+		}
+
+		content, err = io.ReadAll(resp.Body)
+		return errors.Wrap(err, "cannot read log file content")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+type apiQueryLogsResponse struct {
+	apiStatus
+	Response model.DNSQueryLogPage `json:"response"`
+}
+
+// QueryLogs retrieves a page of the query logs kept by a query logger app,
+// the server does the filtering and paging.
+func (c Client) QueryLogs(ctx context.Context, query model.DNSQueryLogQuery) (model.DNSQueryLogPage, error) {
+	params := url.Values{
+		"name":            {query.AppName},
+		"classPath":       {query.ClassPath},
+		"pageNumber":      {strconv.FormatInt(query.PageNumber, 10)},
+		"entriesPerPage":  {strconv.FormatInt(query.EntriesPerPage, 10)},
+		"descendingOrder": {strconv.FormatBool(query.DescendingOrder)},
+	}
+	for key, value := range map[string]string{
+		"start":           query.Start,
+		"end":             query.End,
+		"clientIpAddress": query.ClientIPAddress,
+		"protocol":        query.Protocol,
+		"responseType":    query.ResponseType,
+		"rcode":           query.RCode,
+		"qname":           query.QName,
+		"qtype":           query.QType,
+	} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+
+	var apiResponse apiQueryLogsResponse
+	err := c.makeRequest(ctx, LOGS_URL+"/query", http.MethodGet, params, nil, &apiResponse)
+	if err != nil {
+		return model.DNSQueryLogPage{}, err
+	}
+
+	return apiResponse.Response, nil
+}
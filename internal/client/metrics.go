@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RequestMetrics describes one HTTP exchange with the API
+type RequestMetrics struct {
+	Endpoint string // server URL the request was sent to
+	Method   string
+	Path     string // API path, e.g. /api/zones/records/get
+	URL      string // full request URL, sensitive parameters redacted
+	Duration time.Duration
+	Status   int   // HTTP status, 0 when no response was received
+	Retries  int   // endpoints tried before this one for the same call
+	Err      error // transport error, sensitive parameters redacted
+}
+
+// MetricsHook is called after every HTTP exchange with the API, e.g. to
+// report API latency. It must not block, and may be called concurrently
+type MetricsHook func(ctx context.Context, metrics RequestMetrics)
+
+// configured hook, and logging of exchanges with DebugHTTP
+func metricsHooks(conf Config) []MetricsHook {
+	var hooks []MetricsHook
+	if conf.DebugHTTP {
+		hooks = append(hooks, logMetrics)
+	}
+	if conf.Metrics != nil {
+		hooks = append(hooks, conf.Metrics)
+	}
+	return hooks
+}
+
+func (c Client) reportMetrics(ctx context.Context, metrics RequestMetrics) {
+	for _, hook := range c.metrics {
+		hook(ctx, metrics)
+	}
+}
+
+// debug_http: log every exchange with status and timing
+func logMetrics(ctx context.Context, metrics RequestMetrics) {
+	fields := map[string]interface{}{
+		"method":      metrics.Method,
+		"url":         metrics.URL,
+		"duration_ms": metrics.Duration.Milliseconds(),
+	}
+	if metrics.Retries > 0 {
+		fields["retries"] = metrics.Retries
+	}
+	if metrics.Err != nil {
+		fields["error"] = metrics.Err.Error()
+	} else {
+		fields["status"] = metrics.Status
+	}
+	tflog.Info(ctx, "technitium API HTTP exchange", fields)
+}
+
+func httpStatus(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+	"github.com/pkg/errors"
+)
+
+// API responses decoding themselves while the body is read, instead of at
+// once
+type apiStreamedResponse interface {
+	apiStatusResponse
+	decode(dec *json.Decoder) error
+}
+
+// records/get response decoded one record at a time: the records array of a
+// large zone is never held in memory, only the records kept are.
+// The server sends the zone before the records, records are mapped with it
+type apiRecordsStream struct {
+	apiStatus
+	zone    string // when the response has none
+	keep    func(model.DNSRecord) bool
+	records []model.DNSRecord
+}
+
+var _ apiStreamedResponse = &apiRecordsStream{}
+
+func (s *apiRecordsStream) decode(dec *json.Decoder) error {
+	// decoded again when the call is retried with a new session
+	s.records = []model.DNSRecord{}
+
+	status := map[string]json.RawMessage{}
+	err := decodeObject(dec, func(key string) error {
+		if key != "response" {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return err
+			}
+			status[key] = value
+			return nil
+		}
+		return s.decodeResponse(dec)
+	})
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, &s.apiStatus)
+}
+
+func (s *apiRecordsStream) decodeResponse(dec *json.Decoder) error {
+	return decodeObject(dec, func(key string) error {
+		switch key {
+		case "zone":
+			var zone apiResponseZone
+			if err := dec.Decode(&zone); err != nil {
+				return err
+			}
+			if zone.Name != "" {
+				s.zone = zone.Name
+			}
+			return nil
+		case "records":
+			return decodeArray(dec, func() error {
+				var item apiDNSRecordResponseItem
+				if err := dec.Decode(&item); err != nil {
+					return err
+				}
+				if record := mapAPIDNSRecordToDNSRecord(item, s.zone); s.keep == nil || s.keep(record) {
+					s.records = append(s.records, record)
+				}
+				return nil
+			})
+		default:
+			var skipped json.RawMessage
+			return dec.Decode(&skipped)
+		}
+	})
+}
+
+// decode a JSON object key by key, decodeValue reading the value of each.
+// null is an empty object
+func decodeObject(dec *json.Decoder, decodeValue func(key string) error) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if token != json.Delim('{') {
+		return errors.Errorf("expected JSON object, got %v", token)
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return errors.Errorf("expected JSON object key, got %v", token)
+		}
+		if err := decodeValue(key); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token() // closing }
+	return err
+}
+
+// decode a JSON array item by item, null is an empty array
+func decodeArray(dec *json.Decoder, decodeItem func() error) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if token != json.Delim('[') {
+		return errors.Errorf("expected JSON array, got %v", token)
+	}
+	for dec.More() {
+		if err := decodeItem(); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token() // closing ]
+	return err
+}
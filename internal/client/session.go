@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+)
+
+const USER_URL = "/api/user"
+
+// API token of a client: the configured one, or a session token obtained by
+// logging in with credentials, renewed when it expires
+type session struct {
+	mu       sync.Mutex
+	token    string
+	username string
+	password string
+}
+
+type apiLoginResponse struct {
+	apiStatus
+	Token string `json:"token"`
+}
+
+func (s *session) current() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token
+}
+
+// log in again, unless a concurrent request already replaced the stale token:
+// requests failing together wait for a single login and reuse its token
+func (s *session) refresh(ctx context.Context, c Client, stale string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != stale {
+		return s.token, nil
+	}
+
+	formData := url.Values{
+		"user":        {s.username},
+		"pass":        {s.password},
+		"includeInfo": {"false"},
+	}
+	var apiResponse apiLoginResponse
+	resp, err := c.doRequest(ctx, "", USER_URL+"/login", http.MethodPost, nil, formData)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot log in")
+	}
+	defer closeBody(resp)
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return "", errors.Wrap(err, "cannot decode JSON response into the provided structure")
+	}
+	if err := apiResponse.apiError(); err != nil {
+		return "", errors.Wrap(err, "cannot log in")
+	}
+
+	tflog.Debug(ctx, "technitium API session token renewed")
+	s.token = apiResponse.Token
+	return s.token, nil
+}
+
+// run an API call with the session token, logging in first when there is
+// none yet, and once again when it is rejected as expired. Calls made without
+// a deadline, e.g. at provider configuration, get HTTP_REQUEST_TIMEOUT
+func (c Client) withSession(ctx context.Context, call func(ctx context.Context, token string) error) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, HTTP_REQUEST_TIMEOUT*time.Second)
+		defer cancel()
+	}
+
+	token := c.session.current()
+	if c.session.username == "" {
+		return call(ctx, token)
+	}
+
+	var err error
+	if token == "" {
+		if token, err = c.session.refresh(ctx, c, ""); err != nil {
+			return err
+		}
+	}
+	err = call(ctx, token)
+	if !isInvalidTokenError(err) {
+		return err
+	}
+	if token, err = c.session.refresh(ctx, c, token); err != nil {
+		return err
+	}
+	return call(ctx, token)
+}
+
+type apiSessionInfoResponse struct {
+	apiStatus
+	Info struct {
+		Version string `json:"version"`
+	} `json:"info"`
+}
+
+// server version, read once for all the requests of a run
+type serverInfo struct {
+	mu      sync.Mutex
+	version string
+}
+
+// GetServerVersion returns the version of the DNS server, e.g. 13.6.0.
+func (c Client) GetServerVersion(ctx context.Context) (string, error) {
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+	if c.server.version != "" {
+		return c.server.version, nil
+	}
+
+	var apiResponse apiSessionInfoResponse
+	if err := c.makeRequest(ctx, USER_URL+"/session/get", http.MethodGet, nil, nil, &apiResponse); err != nil {
+		return "", err
+	}
+	c.server.version = apiResponse.Info.Version
+	return c.server.version, nil
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// ForceUpdateBlockLists makes the server download its block lists again now.
+func (c Client) ForceUpdateBlockLists(ctx context.Context) error {
+	var apiResponse apiEmptyResponse
+	return c.makeRequest(ctx, SETTINGS_URL+"/forceUpdateBlockLists", http.MethodGet, nil, nil, &apiResponse)
+}
+
+type apiSettingsResponse struct {
+	apiStatus
+	Response model.DNSSettings `json:"response"`
+}
+
+// GetSettings retrieves the DNS server settings.
+func (c Client) GetSettings(ctx context.Context) (model.DNSSettings, error) {
+	var apiResponse apiSettingsResponse
+	err := c.makeRequest(ctx, SETTINGS_URL+"/get", http.MethodGet, nil, nil, &apiResponse)
+	if err != nil {
+		return model.DNSSettings{}, err
+	}
+
+	return apiResponse.Response, nil
+}
+
+// SetSettings changes DNS server settings, see model.DNSSettingsUpdate.
+func (c Client) SetSettings(ctx context.Context, settings model.DNSSettingsUpdate) error {
+	formData := url.Values{}
+
+	if settings.BlockListUrls != nil {
+		formData.Set("blockListUrls", formList(settings.BlockListUrls))
+	}
+	if settings.BlockListUpdateIntervalHours != nil {
+		formData.Set("blockListUrlUpdateIntervalHours", strconv.FormatInt(*settings.BlockListUpdateIntervalHours, 10))
+	}
+	if settings.EnableInMemoryStats != nil {
+		formData.Set("enableInMemoryStats", strconv.FormatBool(*settings.EnableInMemoryStats))
+	}
+	if settings.MaxStatFileDays != nil {
+		formData.Set("maxStatFileDays", strconv.FormatInt(*settings.MaxStatFileDays, 10))
+	}
+
+	if len(formData) == 0 {
+		// nothing to change
+		return nil
+	}
+
+	var apiResponse apiEmptyResponse
+	return c.makeRequest(ctx, SETTINGS_URL+"/set", http.MethodPost, nil, formData, &apiResponse)
+}
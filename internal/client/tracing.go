@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const TRACER_NAME = "github.com/kevynb/terraform-provider-technitium/internal/client"
+
+// tracer of the configured provider, the global one (no-op unless set) by default
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return provider.Tracer(TRACER_NAME)
+}
+
+// start a span for an API call, child of the span of ctx if any, with the
+// zone, domain and record type it is about
+func (c Client) startSpan(ctx context.Context, apiPath string, method string, queryParams url.Values, formData url.Values) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		attribute.String("technitium.operation", apiPath),
+		attribute.String("http.request.method", method),
+	}
+	for param, key := range map[string]string{"zone": "technitium.zone", "domain": "technitium.domain", "type": "technitium.record_type"} {
+		value := queryParams.Get(param)
+		if value == "" {
+			value = formData.Get(param)
+		}
+		if value != "" {
+			attributes = append(attributes, attribute.String(key, value))
+		}
+	}
+	return c.tracer.Start(ctx, "technitium "+apiPath, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,394 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var _ DNSApiClient = &FakeDNSApiClient{}
+
+// FakeDNSApiClient is an in-memory DNSApiClient, for unit tests of resources:
+// zones, their records and options, and app configs are kept in maps and
+// behave like the API does for them. Calls about anything else succeed and
+// return zero values
+type FakeDNSApiClient struct {
+	ServerVersion string // reported by GetServerVersion
+	Comment       string // returned by ManagedComment
+
+	mu          sync.Mutex
+	zones       map[string]DNSZone
+	zoneOptions map[string]DNSZoneOptions
+	records     map[string][]DNSRecord // by zone name
+	appConfigs  map[string]string      // by app name
+	settings    DNSSettings
+}
+
+func NewFakeDNSApiClient() *FakeDNSApiClient {
+	return &FakeDNSApiClient{
+		ServerVersion: "13.6",
+		Comment:       "Managed by terraform",
+		zones:         map[string]DNSZone{},
+		zoneOptions:   map[string]DNSZoneOptions{},
+		records:       map[string][]DNSRecord{},
+		appConfigs:    map[string]string{},
+		settings:      DNSSettings{BlockListUpdateIntervalHours: 24},
+	}
+}
+
+// closest zone holding a domain name
+func (f *FakeDNSApiClient) zoneOf(domain DNSRecordName) (string, error) {
+	zoneName := ""
+	for name := range f.zones {
+		if (string(domain) == name || strings.HasSuffix(string(domain), "."+name)) && len(name) > len(zoneName) {
+			zoneName = name
+		}
+	}
+	if zoneName == "" {
+		return "", fmt.Errorf("no such zone was found: %s", domain)
+	}
+	return zoneName, nil
+}
+
+func (f *FakeDNSApiClient) GetRecords(ctx context.Context, domain DNSRecordName) ([]DNSRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	zoneName, err := f.zoneOf(domain)
+	if err != nil {
+		return nil, err
+	}
+	records := []DNSRecord{}
+	for _, record := range f.records[zoneName] {
+		if record.Domain == domain {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (f *FakeDNSApiClient) GetZoneRecords(ctx context.Context, zoneName string) ([]DNSRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.zones[zoneName]; !ok {
+		return nil, fmt.Errorf("no such zone was found: %s", zoneName)
+	}
+	return append([]DNSRecord{}, f.records[zoneName]...), nil
+}
+
+// adds the record, replacing those of the same type and name on overwrite
+func (f *FakeDNSApiClient) AddRecord(ctx context.Context, record DNSRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	zoneName, err := f.zoneOf(record.Domain)
+	if err != nil {
+		return err
+	}
+	records := []DNSRecord{}
+	for _, existing := range f.records[zoneName] {
+		if record.Overwrite && existing.Type == record.Type && existing.Domain == record.Domain {
+			continue
+		}
+		if existing.SameKey(record) {
+			return fmt.Errorf("cannot add record: record already exists")
+		}
+		records = append(records, existing)
+	}
+	record.Overwrite = false
+	f.records[zoneName] = append(records, record)
+	return nil
+}
+
+func (f *FakeDNSApiClient) UpdateRecord(ctx context.Context, oldRecord DNSRecord, newRecord DNSRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	zoneName, err := f.zoneOf(oldRecord.Domain)
+	if err != nil {
+		return err
+	}
+	for i, existing := range f.records[zoneName] {
+		if existing.SameKey(oldRecord) {
+			f.records[zoneName][i] = newRecord
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot update record: record does not exist")
+}
+
+// deleting a record that does not exist is not an error, as with the API
+func (f *FakeDNSApiClient) DeleteRecord(ctx context.Context, record DNSRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	zoneName, err := f.zoneOf(record.Domain)
+	if err != nil {
+		return err
+	}
+	records := []DNSRecord{}
+	for _, existing := range f.records[zoneName] {
+		if !existing.SameKey(record) {
+			records = append(records, existing)
+		}
+	}
+	f.records[zoneName] = records
+	return nil
+}
+
+// zones sorted by name
+func (f *FakeDNSApiClient) ListZones(ctx context.Context) ([]DNSZone, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	zones := make([]DNSZone, 0, len(f.zones))
+	for _, zone := range f.zones {
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Name < zones[j].Name })
+	return zones, nil
+}
+
+func (f *FakeDNSApiClient) GetZoneOptions(ctx context.Context, zoneName string) (DNSZoneOptions, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.zones[zoneName]; !ok {
+		return DNSZoneOptions{}, fmt.Errorf("no such zone was found: %s", zoneName)
+	}
+	return f.zoneOptions[zoneName], nil
+}
+
+func (f *FakeDNSApiClient) CreateZone(ctx context.Context, zone DNSZone) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.zones[zone.Name]; ok {
+		return fmt.Errorf("zone already exists: %s", zone.Name)
+	}
+	if zone.DNSSecStatus == "" {
+		zone.DNSSecStatus = DNSSEC_STATUS_UNSIGNED
+	}
+	// forwarder settings end up in the FWD record the zone is initialized
+	// with, not in the zone list
+	if zone.Type == ZONE_FORWARDER && zone.Forwarder != "" && (zone.InitializeForwarder == nil || *zone.InitializeForwarder) {
+		fwd := DNSRecord{
+			Type:          REC_FWD,
+			Domain:        DNSRecordName(zone.Name),
+			Forwarder:     zone.Forwarder,
+			Protocol:      zone.Protocol,
+			ProxyType:     zone.ProxyType,
+			ProxyAddress:  zone.ProxyAddress,
+			ProxyUsername: zone.ProxyUsername,
+			ProxyPassword: zone.ProxyPassword,
+		}
+		if fwd.Protocol == "" {
+			fwd.Protocol = "Udp"
+		}
+		if zone.DnssecValidation != nil {
+			fwd.DnssecValidation = *zone.DnssecValidation
+		}
+		if zone.ProxyPort != nil {
+			fwd.ProxyPort = uint16(*zone.ProxyPort)
+		}
+		f.records[zone.Name] = append(f.records[zone.Name], fwd)
+	}
+	zone.InitializeForwarder, zone.Forwarder, zone.Protocol, zone.DnssecValidation = nil, "", "", nil
+	zone.ProxyType, zone.ProxyAddress, zone.ProxyPort, zone.ProxyUsername, zone.ProxyPassword = "", "", nil, "", ""
+	f.zones[zone.Name] = zone
+	f.zoneOptions[zone.Name] = DNSZoneOptions{
+		Name:         zone.Name,
+		Type:         zone.Type,
+		DNSSecStatus: zone.DNSSecStatus,
+		Catalog:      zone.Catalog,
+		ValidateZone: zone.ValidateZone,
+	}
+	if zone.Type == ZONE_PRIMARY || zone.Type == ZONE_FORWARDER || zone.Type == ZONE_CATALOG {
+		useSoaSerialDateScheme := zone.UseSoaSerialDateScheme != nil && *zone.UseSoaSerialDateScheme
+		options := f.zoneOptions[zone.Name]
+		options.UseSoaSerialDateScheme = &useSoaSerialDateScheme
+		f.zoneOptions[zone.Name] = options
+	}
+	return nil
+}
+
+func (f *FakeDNSApiClient) DeleteZone(ctx context.Context, zoneName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.zones[zoneName]; !ok {
+		return fmt.Errorf("no such zone was found: %s", zoneName)
+	}
+	delete(f.zones, zoneName)
+	delete(f.zoneOptions, zoneName)
+	delete(f.records, zoneName)
+	return nil
+}
+
+func (f *FakeDNSApiClient) ResyncZone(ctx context.Context, zoneName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.zones[zoneName]; !ok {
+		return fmt.Errorf("no such zone was found: %s", zoneName)
+	}
+	return nil
+}
+
+func (f *FakeDNSApiClient) SetZoneOptions(ctx context.Context, zoneName string, options DNSZoneOptionsUpdate) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	current, ok := f.zoneOptions[zoneName]
+	if !ok {
+		return fmt.Errorf("no such zone was found: %s", zoneName)
+	}
+	current.QueryAccess = options.QueryAccess
+	current.QueryAccessNetworkACL = options.QueryAccessNetworkACL
+	current.Update = options.Update
+	current.UpdateNetworkACL = options.UpdateNetworkACL
+	current.UpdateSecurityPolicies = options.UpdateSecurityPolicies
+	current.Notify = options.Notify
+	current.NotifyNameServers = options.NotifyNameServers
+	current.ZoneTransfer = options.ZoneTransfer
+	current.ZoneTransferNetworkACL = options.ZoneTransferNetworkACL
+	if options.ValidateZone != nil {
+		current.ValidateZone = options.ValidateZone
+	}
+	f.zoneOptions[zoneName] = current
+	return nil
+}
+
+// DNSSEC calls only track the zone dnssecStatus
+func (f *FakeDNSApiClient) setDnssecStatus(zoneName string, nxProof string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	zone, ok := f.zones[zoneName]
+	if !ok {
+		return fmt.Errorf("no such zone was found: %s", zoneName)
+	}
+	switch nxProof {
+	case "":
+		zone.DNSSecStatus = DNSSEC_STATUS_UNSIGNED
+	case DNSSEC_NSEC3:
+		zone.DNSSecStatus = DNSSEC_STATUS_SIGNED_WITH_NSEC3
+	default:
+		zone.DNSSecStatus = DNSSEC_STATUS_SIGNED_WITH_NSEC
+	}
+	f.zones[zoneName] = zone
+	options := f.zoneOptions[zoneName]
+	options.DNSSecStatus = zone.DNSSecStatus
+	f.zoneOptions[zoneName] = options
+	return nil
+}
+
+func (f *FakeDNSApiClient) SignZone(ctx context.Context, zoneName string, signing DNSZoneSigning) error {
+	nxProof := signing.NxProof
+	if nxProof == "" {
+		nxProof = DNSSEC_NSEC
+	}
+	return f.setDnssecStatus(zoneName, nxProof)
+}
+
+func (f *FakeDNSApiClient) UnsignZone(ctx context.Context, zoneName string) error {
+	return f.setDnssecStatus(zoneName, "")
+}
+
+func (f *FakeDNSApiClient) GetDnssecProperties(ctx context.Context, zoneName string) (DNSZoneDnssecProperties, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	zone, ok := f.zones[zoneName]
+	if !ok {
+		return DNSZoneDnssecProperties{}, fmt.Errorf("no such zone was found: %s", zoneName)
+	}
+	return DNSZoneDnssecProperties{Name: zoneName, DNSSecStatus: zone.DNSSecStatus}, nil
+}
+
+func (f *FakeDNSApiClient) GetDSRecords(ctx context.Context, zoneName string) ([]DNSZoneDSRecord, error) {
+	return nil, nil
+}
+
+func (f *FakeDNSApiClient) UpdateDnsKeyTtl(ctx context.Context, zoneName string, ttl int64) error {
+	return nil
+}
+
+func (f *FakeDNSApiClient) UpdateDnssecKeyRollover(ctx context.Context, zoneName string, keyType string, rolloverDays int64) error {
+	return nil
+}
+
+func (f *FakeDNSApiClient) RolloverDnssecAlgorithm(ctx context.Context, zoneName string, signing DNSZoneSigning) error {
+	return nil
+}
+
+func (f *FakeDNSApiClient) UpdateDnssecNxProof(ctx context.Context, zoneName string, nxProof string, iterations int64, saltLength int64) error {
+	return f.setDnssecStatus(zoneName, nxProof)
+}
+
+func (f *FakeDNSApiClient) GetTopDomains(ctx context.Context, statsType string, period DNSStatsPeriod, limit int64) ([]DNSTopDomain, error) {
+	return nil, nil
+}
+
+func (f *FakeDNSApiClient) ListLogFiles(ctx context.Context) ([]DNSLogFile, error) {
+	return nil, nil
+}
+
+func (f *FakeDNSApiClient) QueryLogs(ctx context.Context, query DNSQueryLogQuery) (DNSQueryLogPage, error) {
+	return DNSQueryLogPage{PageNumber: query.PageNumber, Entries: []DNSQueryLogEntry{}}, nil
+}
+
+func (f *FakeDNSApiClient) DownloadLogFile(ctx context.Context, fileName string, limitMB int64) ([]byte, error) {
+	return nil, nil
+}
+
+func (f *FakeDNSApiClient) Resolve(ctx context.Context, server string, domain string, recordType DNSRecordType) ([]DNSResolvedRecord, error) {
+	return nil, nil
+}
+
+func (f *FakeDNSApiClient) ListApps(ctx context.Context) ([]DNSApp, error) {
+	return nil, nil
+}
+
+func (f *FakeDNSApiClient) RemoveDhcpLease(ctx context.Context, scopeName string, clientIdentifier string) error {
+	return nil
+}
+
+func (f *FakeDNSApiClient) ForceUpdateBlockLists(ctx context.Context) error {
+	return nil
+}
+
+func (f *FakeDNSApiClient) GetSettings(ctx context.Context) (DNSSettings, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.settings, nil
+}
+
+func (f *FakeDNSApiClient) SetSettings(ctx context.Context, settings DNSSettingsUpdate) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if settings.BlockListUrls != nil {
+		f.settings.BlockListUrls = append([]string{}, settings.BlockListUrls...)
+	}
+	if settings.BlockListUpdateIntervalHours != nil {
+		f.settings.BlockListUpdateIntervalHours = *settings.BlockListUpdateIntervalHours
+	}
+	if settings.EnableInMemoryStats != nil {
+		f.settings.EnableInMemoryStats = *settings.EnableInMemoryStats
+	}
+	if settings.MaxStatFileDays != nil {
+		f.settings.MaxStatFileDays = *settings.MaxStatFileDays
+	}
+	return nil
+}
+
+func (f *FakeDNSApiClient) GetAppConfig(ctx context.Context, appName string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.appConfigs[appName], nil
+}
+
+func (f *FakeDNSApiClient) SetAppConfig(ctx context.Context, appName string, config string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.appConfigs[appName] = config
+	return nil
+}
+
+func (f *FakeDNSApiClient) GetServerVersion(ctx context.Context) (string, error) {
+	return f.ServerVersion, nil
+}
+
+func (f *FakeDNSApiClient) ManagedComment() string {
+	return f.Comment
+}
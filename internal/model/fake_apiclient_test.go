@@ -0,0 +1,118 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFakeDNSApiClient_Records(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	f := NewFakeDNSApiClient()
+
+	if err := f.AddRecord(ctx, DNSRecord{Type: REC_A, Domain: "www.example.com", IPAddress: "10.0.0.1"}); err == nil {
+		t.Fatal("got no error adding a record without zone")
+	}
+	for _, zone := range []string{"example.com", "sub.example.com"} {
+		if err := f.CreateZone(ctx, DNSZone{Name: zone, Type: ZONE_PRIMARY}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	www := DNSRecord{Type: REC_A, Domain: "www.example.com", TTL: 300, IPAddress: "10.0.0.1"}
+	sub := DNSRecord{Type: REC_A, Domain: "www.sub.example.com", TTL: 300, IPAddress: "10.0.0.2"}
+	for _, record := range []DNSRecord{www, sub} {
+		if err := f.AddRecord(ctx, record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.AddRecord(ctx, www); err == nil {
+		t.Error("got no error adding an existing record")
+	}
+
+	got, err := f.GetZoneRecords(ctx, "sub.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []DNSRecord{sub}; !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+
+	updated := www
+	updated.IPAddress = "10.0.0.3"
+	if err := f.UpdateRecord(ctx, www, updated); err != nil {
+		t.Fatal(err)
+	}
+	overwrite := DNSRecord{Type: REC_A, Domain: "www.example.com", TTL: 60, IPAddress: "10.0.0.4", Overwrite: true}
+	if err := f.AddRecord(ctx, overwrite); err != nil {
+		t.Fatal(err)
+	}
+	got, err = f.GetRecords(ctx, "www.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	overwrite.Overwrite = false
+	if want := []DNSRecord{overwrite}; !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+
+	if err := f.DeleteRecord(ctx, overwrite); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.DeleteZone(ctx, "sub.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.GetZoneRecords(ctx, "sub.example.com"); err == nil {
+		t.Error("got no error reading a deleted zone")
+	}
+	got, err = f.GetZoneRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("got records left after delete: %v", got)
+	}
+}
+
+func TestFakeDNSApiClient_Zones(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	f := NewFakeDNSApiClient()
+
+	for _, zone := range []string{"b.example", "a.example"} {
+		if err := f.CreateZone(ctx, DNSZone{Name: zone, Type: ZONE_PRIMARY}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.CreateZone(ctx, DNSZone{Name: "a.example", Type: ZONE_PRIMARY}); err == nil {
+		t.Error("got no error creating an existing zone")
+	}
+	if err := f.SignZone(ctx, "a.example", DNSZoneSigning{NxProof: DNSSEC_NSEC3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetZoneOptions(ctx, "b.example", DNSZoneOptionsUpdate{Notify: "None"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := f.ListZones(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []DNSZone{
+		{Name: "a.example", Type: ZONE_PRIMARY, DNSSecStatus: DNSSEC_STATUS_SIGNED_WITH_NSEC3},
+		{Name: "b.example", Type: ZONE_PRIMARY, DNSSecStatus: DNSSEC_STATUS_UNSIGNED},
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+
+	options, err := f.GetZoneOptions(ctx, "b.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options.Notify != "None" {
+		t.Errorf("notify: got %q, want %q", options.Notify, "None")
+	}
+}
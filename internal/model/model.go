@@ -2,7 +2,10 @@
 
 package model
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type DNSDomain string
 
@@ -80,96 +83,182 @@ type DNSZone struct {
 	ProxyPort                  *int64 `json:"proxyPort,omitempty"`
 	ProxyUsername              string `json:"proxyUsername,omitempty"`
 	ProxyPassword              string `json:"proxyPassword,omitempty"`
+
+	// Zone options, nil lists were not read
+	QueryAccess            string                `json:"queryAccess,omitempty"`
+	QueryAccessNetworkACL  []string              `json:"queryAccessNetworkACL,omitempty"`
+	Update                 string                `json:"update,omitempty"`
+	UpdateNetworkACL       []string              `json:"updateNetworkACL,omitempty"`
+	UpdateSecurityPolicies []DNSZoneUpdatePolicy `json:"updateSecurityPolicies,omitempty"`
+	Notify                 string                `json:"notify,omitempty"`
+	NotifyNameServers      []string              `json:"notifyNameServers,omitempty"`
+	ZoneTransfer           string                `json:"zoneTransfer,omitempty"`
+	ZoneTransferNetworkACL []string              `json:"zoneTransferNetworkACL,omitempty"`
+}
+
+// zone settings, as returned by zone options API
+type DNSZoneOptions struct {
+	Name                           string                `json:"name"`
+	Type                           DNSZoneType           `json:"type"`
+	Internal                       bool                  `json:"internal"`
+	DNSSecStatus                   string                `json:"dnssecStatus"`
+	Disabled                       bool                  `json:"disabled"`
+	Catalog                        string                `json:"catalog,omitempty"`
+	PrimaryNameServerAddresses     []string              `json:"primaryNameServerAddresses,omitempty"`
+	PrimaryZoneTransferProtocol    string                `json:"primaryZoneTransferProtocol,omitempty"`
+	PrimaryZoneTransferTsigKeyName string                `json:"primaryZoneTransferTsigKeyName,omitempty"`
+	UseSoaSerialDateScheme         *bool                 `json:"useSoaSerialDateScheme,omitempty"` // Primary, Forwarder and Catalog zones
+	ValidateZone                   *bool                 `json:"validateZone,omitempty"`
+	QueryAccess                    string                `json:"queryAccess,omitempty"`
+	QueryAccessNetworkACL          []string              `json:"queryAccessNetworkACL,omitempty"`
+	Update                         string                `json:"update,omitempty"`
+	UpdateNetworkACL               []string              `json:"updateNetworkACL,omitempty"`
+	UpdateSecurityPolicies         []DNSZoneUpdatePolicy `json:"updateSecurityPolicies,omitempty"`
+	Notify                         string                `json:"notify,omitempty"`
+	NotifyNameServers              []string              `json:"notifyNameServers,omitempty"`
+	ZoneTransfer                   string                `json:"zoneTransfer,omitempty"`
+	ZoneTransferNetworkACL         []string              `json:"zoneTransferNetworkACL,omitempty"`
+}
+
+// zone NOTIFY targets
+const (
+	ZONE_NOTIFY_NONE                           = "None"
+	ZONE_NOTIFY_ZONE_NAME_SERVERS              = "ZoneNameServers"
+	ZONE_NOTIFY_SPECIFIED_NAME_SERVERS         = "SpecifiedNameServers"
+	ZONE_NOTIFY_BOTH_ZONE_AND_SPECIFIED        = "BothZoneAndSpecifiedNameServers"
+	ZONE_NOTIFY_SEPARATE_FOR_CATALOG_AND_ZONES = "SeparateNameServersForCatalogAndMemberZones"
+)
+
+// dynamic updates signed with the TSIG key may change records of the given
+// types ("ANY" for all) at domain, "*.domain" for its subdomains
+type DNSZoneUpdatePolicy struct {
+	TsigKeyName  string   `json:"tsigKeyName"`
+	Domain       string   `json:"domain"`
+	AllowedTypes []string `json:"allowedTypes"`
+}
+
+// zone query access, dynamic updates and zone transfer policies
+const (
+	ZONE_ACCESS_DENY                                = "Deny"
+	ZONE_ACCESS_ALLOW                               = "Allow"
+	ZONE_ACCESS_ALLOW_ONLY_PRIVATE_NETWORKS         = "AllowOnlyPrivateNetworks"
+	ZONE_ACCESS_ALLOW_ONLY_ZONE_NAME_SERVERS        = "AllowOnlyZoneNameServers"
+	ZONE_ACCESS_USE_SPECIFIED_NETWORK_ACL           = "UseSpecifiedNetworkACL"
+	ZONE_ACCESS_ALLOW_ZONE_NAME_SERVERS_AND_USE_ACL = "AllowZoneNameServersAndUseSpecifiedNetworkACL"
+)
+
+// zone settings changed in place with the zone options API: empty values are
+// left as they are, empty non-nil lists are cleared
+type DNSZoneOptionsUpdate struct {
+	QueryAccess            string
+	QueryAccessNetworkACL  []string
+	Update                 string
+	UpdateNetworkACL       []string
+	UpdateSecurityPolicies []DNSZoneUpdatePolicy
+	Notify                 string
+	NotifyNameServers      []string
+	ZoneTransfer           string
+	ZoneTransferNetworkACL []string
+	ValidateZone           *bool
 }
 
+// form tags give the API parameter name, with options for the client encoder:
+//   - key: identifies the record, update sends both old (name) and new (newName) values
+//   - new: update only sends the new value
+//   - old: update only sends the old value
+//   - nodelete: not sent on delete
 type DNSRecord struct {
 	Type   DNSRecordType // from the enum above
 	Domain DNSRecordName // @ for top-level TXT/MX/A/NS...
+	Zone   string        `form:"zone,old"` // authoritative zone, inferred by the server from the domain when empty
 
 	TTL DNSRecordTTL // min 600, def 3600
 
 	Comments  string       // comment for the added resource
-	ExpiryTTL DNSRecordTTL // automatically delete the record when the value in seconds elapses
+	Overwrite bool         // on add, replace the existing records of the type at the domain
+	ExpiryTTL DNSRecordTTL `form:"expiryTtl,new,nodelete"` // automatically delete the record when the value in seconds elapses
 
-	IPAddress       string // ip address, required for A or AAAA record
-	Ptr             bool   // This option is used only for A and AAAA records.
-	CreatePtrZone   bool   // This option is used for A and AAAA records.
-	UpdateSvcbHints bool   // This option is used for A and AAAA records.
+	IPAddress       string `form:"ipAddress,key"`                // ip address, required for A or AAAA record
+	Ptr             bool   `form:"ptr,new"`                      // This option is used only for A and AAAA records.
+	CreatePtrZone   bool   `form:"createPtrZone,new,nodelete"`   // This option is used for A and AAAA records.
+	UpdateSvcbHints bool   `form:"updateSvcbHints,new,nodelete"` // This option is used for A and AAAA records.
 
-	NameServer string // This option is required for adding NS record.
-	Glue       string // This optional parameter is used for adding NS record.
+	NameServer string `form:"nameServer,key"` // This option is required for adding NS record.
+	Glue       string `form:"glue,new"`       // This optional parameter is used for adding NS record.
 
-	CName string // This option is required for adding CNAME record.
+	CName string `form:"cname,new"` // This option is required for adding CNAME record.
 
-	PtrName string // This option is required for adding PTR record.
+	PtrName string `form:"ptrName,key"` // This option is required for adding PTR record.
 
-	Exchange   string        // This option is required for adding MX record.
-	Preference DNSRecordPrio // This option is required for adding MX record.
+	Exchange   string        `form:"exchange,key"`   // This option is required for adding MX record.
+	Preference DNSRecordPrio `form:"preference,key"` // This option is required for adding MX record.
 
-	Text      string //  This option is required for adding TXT record.
-	SplitText bool   // Set to true for using new line char to split text into multiple character-strings for adding TXT record.
+	Text      string `form:"text,key"`      //  This option is required for adding TXT record.
+	SplitText bool   `form:"splitText,key"` // Set to true for using new line char to split text into multiple character-strings for adding TXT record.
 
-	Mailbox   string // for adding RP record.
-	TxtDomain string // Set a TXT record's domain name for adding RP record.
+	Mailbox   string `form:"mailbox,key"`   // for adding RP record.
+	TxtDomain string `form:"txtDomain,key"` // Set a TXT record's domain name for adding RP record.
 
-	Priority DNSRecordPrio       // This parameter is required for adding the SRV record.
-	Weight   DNSRecordSRVWeight  // This parameter is required for adding the SRV record.
-	Port     DNSRecordSRVPort    // This parameter is required for adding the SRV record.
-	Target   DNSRecordSRVService // This parameter is required for adding the SRV record.
+	Priority DNSRecordPrio       `form:"priority,key"` // This parameter is required for adding the SRV record.
+	Weight   DNSRecordSRVWeight  `form:"weight,key"`   // This parameter is required for adding the SRV record.
+	Port     DNSRecordSRVPort    `form:"port,key"`     // This parameter is required for adding the SRV record.
+	Target   DNSRecordSRVService `form:"target,key"`   // This parameter is required for adding the SRV record.
 
-	NaptrOrder       uint16 // This parameter is required for adding the NAPTR record.
-	NaptrPreference  uint16 // This parameter is required for adding the NAPTR record.
-	NaptrFlags       string // This parameter is required for adding the NAPTR record.
-	NaptrServices    string // This parameter is required for adding the NAPTR record.
-	NaptrRegexp      string // This parameter is required for adding the NAPTR record.
-	NaptrReplacement string // This parameter is required for adding the NAPTR record.
+	NaptrOrder       uint16 `form:"naptrOrder,key"`       // This parameter is required for adding the NAPTR record.
+	NaptrPreference  uint16 `form:"naptrPreference,key"`  // This parameter is required for adding the NAPTR record.
+	NaptrFlags       string `form:"naptrFlags,key"`       // This parameter is required for adding the NAPTR record.
+	NaptrServices    string `form:"naptrServices,key"`    // This parameter is required for adding the NAPTR record.
+	NaptrRegexp      string `form:"naptrRegexp,key"`      // This parameter is required for adding the NAPTR record.
+	NaptrReplacement string `form:"naptrReplacement,key"` // This parameter is required for adding the NAPTR record.
 
-	DName string // This parameter is required for adding DNAME record.
+	DName string `form:"dname,new"` // This parameter is required for adding DNAME record.
 
-	KeyTag     uint16 // This parameter is required for adding DS record.
-	Algorithm  string // This parameter is required for adding DS record.
-	DigestType string // This parameter is required for adding DS record.
-	Digest     string // This parameter is required for adding DS record.
+	KeyTag     uint16 `form:"keyTag,key"`     // This parameter is required for adding DS record.
+	Algorithm  string `form:"algorithm,key"`  // This parameter is required for adding DS record.
+	DigestType string `form:"digestType,key"` // This parameter is required for adding DS record.
+	Digest     string `form:"digest,key"`     // This parameter is required for adding DS record.
 
-	SshfpAlgorithm       string // This parameter is required for adding SSHFP record.
-	SshfpFingerprintType string // This parameter is required for adding SSHFP record.
-	SshfpFingerprint     string // This parameter is required for adding SSHFP record.
+	SshfpAlgorithm       string `form:"sshfpAlgorithm,key"`       // This parameter is required for adding SSHFP record.
+	SshfpFingerprintType string `form:"sshfpFingerprintType,key"` // This parameter is required for adding SSHFP record.
+	SshfpFingerprint     string `form:"sshfpFingerprint,key"`     // This parameter is required for adding SSHFP record.
 
-	TlsaCertificateUsage           string // This parameter is required for adding TLSA record.
-	TlsaSelector                   string // This parameter is required for adding TLSA record.
-	TlsaMatchingType               string // This parameter is required for adding TLSA record.
-	TlsaCertificateAssociationData string // This parameter is required for adding TLSA record.
+	TlsaCertificateUsage           string `form:"tlsaCertificateUsage,key"`           // This parameter is required for adding TLSA record.
+	TlsaSelector                   string `form:"tlsaSelector,key"`                   // This parameter is required for adding TLSA record.
+	TlsaMatchingType               string `form:"tlsaMatchingType,key"`               // This parameter is required for adding TLSA record.
+	TlsaCertificateAssociationData string `form:"tlsaCertificateAssociationData,key"` // This parameter is required for adding TLSA record.
 
-	SvcPriority   uint16 // This parameter is required for adding SCVB or HTTPS record.
-	SvcTargetName string // This parameter is required for adding SCVB or HTTPS record.
-	SvcParams     string // This parameter is required for adding SCVB or HTTPS record.
+	SvcPriority   uint16 `form:"svcPriority,key"`   // This parameter is required for adding SCVB or HTTPS record.
+	SvcTargetName string `form:"svcTargetName,key"` // This parameter is required for adding SCVB or HTTPS record.
+	SvcParams     string `form:"svcParams,key"`     // This parameter is required for adding SCVB or HTTPS record.
 
-	AutoIpv4Hint bool // This parameter is optional for adding SCVB or HTTPS record.
-	AutoIpv6Hint bool // This parameter is optional for adding SCVB or HTTPS record.
+	AutoIpv4Hint bool `form:"autoIpv4Hint,new,nodelete"` // This parameter is optional for adding SCVB or HTTPS record.
+	AutoIpv6Hint bool `form:"autoIpv6Hint,new,nodelete"` // This parameter is optional for adding SCVB or HTTPS record.
 
-	UriPriority uint16 // This parameter is required for adding URI record.
-	UriWeight   uint16 // This parameter is required for adding URI record.
-	Uri         string // This parameter is required for adding URI record.
+	UriPriority uint16 `form:"uriPriority,key"` // This parameter is required for adding URI record.
+	UriWeight   uint16 `form:"uriWeight,key"`   // This parameter is required for adding URI record.
+	Uri         string `form:"uri,key"`         // This parameter is required for adding URI record.
 
-	Flags string // This parameter is required for adding the CAA record.
-	Tag   string // This parameter is required for adding the CAA record.
-	Value string // This parameter is required for adding the CAA record.
+	Flags string `form:"flags,key"` // This parameter is required for adding the CAA record.
+	Tag   string `form:"tag,key"`   // This parameter is required for adding the CAA record.
+	Value string `form:"value,key"` // This parameter is required for adding the CAA record.
 
-	AName string // This parameter is required for adding the ANAME record.
+	AName string `form:"aname,key"` // This parameter is required for adding the ANAME record.
 
-	Protocol          string // This parameter is optional for adding the FWD record (Udp, Tcp, Tls, Https, Quic).
-	Forwarder         string // This parameter is required for adding the FWD record.
-	ForwarderPriority uint16 // This parameter is required for adding the FWD record.
-	DnssecValidation  bool   // This parameter is optional for adding the FWD record.
-	ProxyType         string // This parameter is optional for adding the FWD record.
-	ProxyAddress      string // This parameter is optional for adding the FWD record.
-	ProxyPort         uint16 // This parameter is optional for adding the FWD record.
-	ProxyUsername     string // This parameter is optional for adding the FWD record.
-	ProxyPassword     string // This parameter is optional for adding the FWD record.
+	Protocol          string `form:"protocol,key"`                  // This parameter is optional for adding the FWD record (Udp, Tcp, Tls, Https, Quic).
+	Forwarder         string `form:"forwarder,key"`                 // This parameter is required for adding the FWD record.
+	ForwarderPriority uint16 `form:"forwarderPriority,key"`         // This parameter is required for adding the FWD record.
+	DnssecValidation  bool   `form:"dnssecValidation,new,nodelete"` // This parameter is optional for adding the FWD record.
+	ProxyType         string `form:"proxyType,new"`                 // This parameter is optional for adding the FWD record.
+	ProxyAddress      string `form:"proxyAddress,new"`              // This parameter is optional for adding the FWD record.
+	ProxyPort         uint16 `form:"proxyPort,new"`                 // This parameter is optional for adding the FWD record.
+	ProxyUsername     string `form:"proxyUsername,new"`             // This parameter is optional for adding the FWD record.
+	ProxyPassword     string `form:"proxyPassword,new"`             // This parameter is optional for adding the FWD record.
 
-	AppName    string //  This parameter is required for adding the APP record.
-	ClassPath  string //  This parameter is required for adding the APP record.
-	RecordData string //  This parameter is required for adding the APP record.
+	AppName    string `form:"appName,old"`    //  This parameter is required for adding the APP record.
+	ClassPath  string `form:"classPath,old"`  //  This parameter is required for adding the APP record.
+	RecordData string `form:"recordData,new"` //  This parameter is required for adding the APP record.
+
+	ExtraRData map[string]string // raw parameters appended as is to add and update requests
 }
 
 // compare key field to determine if two records refer to the same object
@@ -183,8 +272,6 @@ type DNSRecord struct {
 // ...
 func (r DNSRecord) SameKey(r1 DNSRecord) bool {
 	if r.Type != r1.Type || r.Domain != r1.Domain {
-
-		println("RType", r.Type, "R1Type", r1.Type, "Domain", r.Domain, "R1Domain", r1.Domain)
 		return false
 	}
 
@@ -234,14 +321,400 @@ func (r DNSRecord) SameKey(r1 DNSRecord) bool {
 	}
 }
 
-// client API interface
-type DNSApiClient interface {
-	GetRecords(ctx context.Context, domain DNSRecordName) ([]DNSRecord, error)
-	GetZoneRecords(ctx context.Context, zoneName string) ([]DNSRecord, error)
+// DNSSEC signing algorithms, with their RSA hash algorithms and curves
+const (
+	DNSSEC_RSA   = "RSA"
+	DNSSEC_ECDSA = "ECDSA"
+	DNSSEC_EDDSA = "EDDSA"
+
+	DNSSEC_CURVE_P256    = "P256"
+	DNSSEC_CURVE_P384    = "P384"
+	DNSSEC_CURVE_ED25519 = "ED25519"
+	DNSSEC_CURVE_ED448   = "ED448"
+)
+
+// DNSSEC denial of existence, and zone dnssecStatus values
+const (
+	DNSSEC_NSEC  = "NSEC"
+	DNSSEC_NSEC3 = "NSEC3"
+
+	DNSSEC_STATUS_UNSIGNED          = "Unsigned"
+	DNSSEC_STATUS_SIGNED_WITH_NSEC  = "SignedWithNSEC"
+	DNSSEC_STATUS_SIGNED_WITH_NSEC3 = "SignedWithNSEC3"
+)
+
+// DNSSEC key types
+const (
+	DNSSEC_KEY_SIGNING_KEY  = "KeySigningKey"
+	DNSSEC_ZONE_SIGNING_KEY = "ZoneSigningKey"
+)
+
+// DNSSEC private key states
+const (
+	DNSSEC_KEY_STATE_GENERATED = "Generated"
+	DNSSEC_KEY_STATE_PUBLISHED = "Published"
+	DNSSEC_KEY_STATE_READY     = "Ready"
+	DNSSEC_KEY_STATE_ACTIVE    = "Active"
+	DNSSEC_KEY_STATE_RETIRED   = "Retired"
+	DNSSEC_KEY_STATE_REVOKED   = "Revoked"
+)
+
+// DnssecAlgorithmName returns the DNSSEC algorithm name of keys, as reported
+// by the server (e.g. RSASHA256, ECDSAP256SHA256, ED25519), of signing
+// parameters
+func DnssecAlgorithmName(algorithm string, hashAlgorithm string, curve string) string {
+	switch algorithm {
+	case DNSSEC_RSA:
+		return "RSA" + hashAlgorithm
+	case DNSSEC_ECDSA:
+		switch curve {
+		case DNSSEC_CURVE_P256:
+			return "ECDSAP256SHA256"
+		case DNSSEC_CURVE_P384:
+			return "ECDSAP384SHA384"
+		}
+	case DNSSEC_EDDSA:
+		return curve
+	}
+	return algorithm
+}
+
+// zone signing parameters, empty values use the server defaults; PEM private
+// keys are signed with instead of generated ones
+type DNSZoneSigning struct {
+	Algorithm        string
+	HashAlgorithm    string
+	KskKeySize       int64
+	ZskKeySize       int64
+	Curve            string
+	DnsKeyTtl        *int64
+	ZskRolloverDays  *int64
+	NxProof          string
+	Iterations       *int64
+	SaltLength       *int64
+	PemKskPrivateKey string
+	PemZskPrivateKey string
+}
+
+// DNSSEC settings and private keys of a zone
+type DNSZoneDnssecProperties struct {
+	Name              string             `json:"name"`
+	DNSSecStatus      string             `json:"dnssecStatus"`
+	Nsec3Iterations   int64              `json:"nsec3Iterations"`
+	Nsec3SaltLength   int64              `json:"nsec3SaltLength"`
+	DnsKeyTtl         int64              `json:"dnsKeyTtl"`
+	DnssecPrivateKeys []DNSSecPrivateKey `json:"dnssecPrivateKeys"`
+}
+
+type DNSSecPrivateKey struct {
+	KeyTag         int64  `json:"keyTag"`
+	KeyType        string `json:"keyType"`
+	Algorithm      string `json:"algorithm"`
+	State          string `json:"state"`
+	StateChangedOn string `json:"stateChangedOn"`
+	StateReadyBy   string `json:"stateReadyBy,omitempty"`
+	IsRetiring     bool   `json:"isRetiring"`
+	RolloverDays   int64  `json:"rolloverDays"`
+}
+
+// DS record (and DNSKEY public key) to publish in the parent zone
+type DNSZoneDSRecord struct {
+	KeyTag      int64             `json:"keyTag"`
+	DnsKeyState string            `json:"dnsKeyState"`
+	Algorithm   string            `json:"algorithm"`
+	PublicKey   string            `json:"publicKey"`
+	Digests     []DNSZoneDSDigest `json:"digests"`
+}
+
+type DNSZoneDSDigest struct {
+	DigestType string `json:"digestType"`
+	Digest     string `json:"digest"`
+}
+
+// dashboard top stats types
+const (
+	STATS_TOP_DOMAINS         = "TopDomains"
+	STATS_TOP_BLOCKED_DOMAINS = "TopBlockedDomains"
+)
+
+// dashboard stats periods, start and end are required for custom one
+const (
+	STATS_LAST_HOUR  = "LastHour"
+	STATS_LAST_DAY   = "LastDay"
+	STATS_LAST_WEEK  = "LastWeek"
+	STATS_LAST_MONTH = "LastMonth"
+	STATS_LAST_YEAR  = "LastYear"
+	STATS_CUSTOM     = "Custom"
+)
+
+// dashboard stats time range
+type DNSStatsPeriod struct {
+	Type  string
+	Start string // ISO 8601, for custom period
+	End   string
+}
+
+// domain with its hit count, from dashboard top stats
+type DNSTopDomain struct {
+	Name string `json:"name"`
+	Hits int64  `json:"hits"`
+}
+
+// server log file, size as formatted by the server (e.g. "8.14 KB")
+type DNSLogFile struct {
+	FileName string `json:"fileName"`
+	Size     string `json:"size"`
+}
+
+// installed DNS app names
+const (
+	APP_LOG_EXPORTER        = "Log Exporter"
+	APP_ADVANCED_FORWARDING = "Advanced Forwarding"
+	APP_QUERY_LOGS_SQLITE   = "Query Logs (Sqlite)"
+	APP_DNS64               = "DNS64"
+)
+
+// class of the Query Logs (Sqlite) app serving query log searches
+const APP_QUERY_LOGS_SQLITE_CLASS = "QueryLogsSqlite.App"
+
+// query log response types
+const (
+	QUERY_LOG_AUTHORITATIVE           = "Authoritative"
+	QUERY_LOG_RECURSIVE               = "Recursive"
+	QUERY_LOG_CACHED                  = "Cached"
+	QUERY_LOG_BLOCKED                 = "Blocked"
+	QUERY_LOG_UPSTREAM_BLOCKED        = "UpstreamBlocked"
+	QUERY_LOG_UPSTREAM_BLOCKED_CACHED = "UpstreamBlockedCached"
+	QUERY_LOG_CACHE_BLOCKED           = "CacheBlocked"
+)
+
+// search of the query logs kept by a query logger app, one page at a time.
+// Empty filters match every entry
+type DNSQueryLogQuery struct {
+	AppName         string
+	ClassPath       string
+	PageNumber      int64
+	EntriesPerPage  int64
+	DescendingOrder bool
+	Start           string // ISO 8601
+	End             string
+	ClientIPAddress string
+	Protocol        string
+	ResponseType    string
+	RCode           string
+	QName           string
+	QType           string
+}
+
+// page of query log entries, with the totals of the whole search
+type DNSQueryLogPage struct {
+	PageNumber   int64              `json:"pageNumber"`
+	TotalPages   int64              `json:"totalPages"`
+	TotalEntries int64              `json:"totalEntries"`
+	Entries      []DNSQueryLogEntry `json:"entries"`
+}
+
+// query log entry, ResponseRtt is nil for answers not from upstream
+type DNSQueryLogEntry struct {
+	RowNumber       int64    `json:"rowNumber"`
+	Timestamp       string   `json:"timestamp"`
+	ClientIPAddress string   `json:"clientIpAddress"`
+	Protocol        string   `json:"protocol"`
+	ResponseType    string   `json:"responseType"`
+	ResponseRtt     *float64 `json:"responseRtt"`
+	RCode           string   `json:"rcode"`
+	QName           string   `json:"qname"`
+	QType           string   `json:"qtype"`
+	QClass          string   `json:"qclass"`
+	Answer          string   `json:"answer"`
+}
+
+// server of the DNS client to resolve through the server itself
+const DNS_CLIENT_THIS_SERVER = "this-server"
+
+// answer of a query made through the server DNS client, RDATA fields depend
+// on the type (e.g. IPAddress for A/AAAA, Domain for CNAME)
+type DNSResolvedRecord struct {
+	Name  string          `json:"Name"`
+	Type  string          `json:"Type"`
+	RData json.RawMessage `json:"RDATA"`
+}
+
+// installed DNS app, with the classes it provides
+type DNSApp struct {
+	Name    string        `json:"name"`
+	Version string        `json:"version"`
+	DnsApps []DNSAppClass `json:"dnsApps"`
+}
+
+type DNSAppClass struct {
+	ClassPath                 string `json:"classPath"`
+	Description               string `json:"description"`
+	IsAppRecordRequestHandler bool   `json:"isAppRecordRequestHandler"`
+}
+
+// Log Exporter syslog transports
+const (
+	SYSLOG_UDP   = "UDP"
+	SYSLOG_TCP   = "TCP"
+	SYSLOG_TLS   = "TLS"
+	SYSLOG_LOCAL = "Local"
+)
+
+// Log Exporter app config, optional parts are nil
+type LogExporterConfig struct {
+	MaxQueueSize *int64             `json:"maxQueueSize,omitempty"`
+	File         *LogExporterFile   `json:"file,omitempty"`
+	HTTP         *LogExporterHTTP   `json:"http,omitempty"`
+	Syslog       *LogExporterSyslog `json:"syslog,omitempty"`
+}
+
+type LogExporterFile struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path"`
+}
+
+type LogExporterHTTP struct {
+	Enabled  bool              `json:"enabled"`
+	Endpoint string            `json:"endpoint"`
+	Headers  map[string]string `json:"headers"`
+}
+
+type LogExporterSyslog struct {
+	Enabled  bool   `json:"enabled"`
+	Address  string `json:"address"`
+	Port     int64  `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// Advanced Forwarding proxy types
+const (
+	FORWARDING_PROXY_HTTP   = "http"
+	FORWARDING_PROXY_SOCKS5 = "socks5"
+)
+
+// Advanced Forwarding app config
+type AdvancedForwardingConfig struct {
+	EnableForwarding bool                          `json:"enableForwarding"`
+	ProxyServers     []AdvancedForwardingProxy     `json:"proxyServers"`
+	Forwarders       []AdvancedForwardingForwarder `json:"forwarders"`
+	NetworkGroupMap  map[string]string             `json:"networkGroupMap"`
+	Groups           []AdvancedForwardingGroup     `json:"groups"`
+}
+
+type AdvancedForwardingProxy struct {
+	Name          string  `json:"name"`
+	Type          string  `json:"type"`
+	ProxyAddress  string  `json:"proxyAddress"`
+	ProxyPort     int64   `json:"proxyPort"`
+	ProxyUsername *string `json:"proxyUsername"`
+	ProxyPassword *string `json:"proxyPassword"`
+}
+
+type AdvancedForwardingForwarder struct {
+	Name               string   `json:"name"`
+	Proxy              *string  `json:"proxy"`
+	DnssecValidation   bool     `json:"dnssecValidation"`
+	ForwarderProtocol  string   `json:"forwarderProtocol"`
+	ForwarderAddresses []string `json:"forwarderAddresses"`
+}
+
+type AdvancedForwardingGroup struct {
+	Name             string                      `json:"name"`
+	EnableForwarding bool                        `json:"enableForwarding"`
+	Forwardings      []AdvancedForwardingForward `json:"forwardings"`
+}
+
+// forwarders (by name) used for the listed domains, "*" for all
+type AdvancedForwardingForward struct {
+	Forwarders []string `json:"forwarders"`
+	Domains    []string `json:"domains"`
+}
+
+// Query Logs (Sqlite) app config, unset settings are nil
+type QueryLogsSqliteConfig struct {
+	EnableLogging    *bool   `json:"enableLogging,omitempty"`
+	MaxQueueSize     *int64  `json:"maxQueueSize,omitempty"`
+	MaxLogDays       *int64  `json:"maxLogDays,omitempty"`
+	MaxLogRecords    *int64  `json:"maxLogRecords,omitempty"`
+	UseInMemoryDb    *bool   `json:"useInMemoryDb,omitempty"`
+	SqliteDbPath     *string `json:"sqliteDbPath,omitempty"`
+	ConnectionString *string `json:"connectionString,omitempty"`
+}
+
+// DNS64 app config
+type DNS64Config struct {
+	EnableDns64     bool              `json:"enableDns64"`
+	NetworkGroupMap map[string]string `json:"networkGroupMap"`
+	Groups          []DNS64Group      `json:"groups"`
+}
+
+// IPv4 networks are synthesized with their mapped IPv6 prefix, a nil prefix excludes them
+type DNS64Group struct {
+	Name           string             `json:"name"`
+	EnableDns64    bool               `json:"enableDns64"`
+	Dns64PrefixMap map[string]*string `json:"dns64PrefixMap"`
+	ExcludedIpv6   []string           `json:"excludedIpv6"`
+}
+
+// DNS server settings managed by the provider, as read
+type DNSSettings struct {
+	BlockListUrls                []string `json:"blockListUrls"` // allow list URLs are prefixed with !
+	BlockListUpdateIntervalHours int64    `json:"blockListUrlUpdateIntervalHours"`
+	BlockListNextUpdatedOn       string   `json:"blockListNextUpdatedOn"` // empty without block lists
+	EnableInMemoryStats          bool     `json:"enableInMemoryStats"`
+	MaxStatFileDays              int64    `json:"maxStatFileDays"` // 0 keeps them forever
+}
+
+// DNS server settings changed with the settings API: nil values are left as
+// they are, empty non-nil lists are cleared
+type DNSSettingsUpdate struct {
+	BlockListUrls                []string
+	BlockListUpdateIntervalHours *int64
+	EnableInMemoryStats          *bool
+	MaxStatFileDays              *int64
+}
+
+// client API methods changing the server. The provider wraps the client for
+// its read only, replicated and audited modes: each wrapper overrides every
+// one of these, as its tests check
+type DNSApiWriter interface {
 	AddRecord(ctx context.Context, record DNSRecord) error
 	UpdateRecord(ctx context.Context, oldRecord DNSRecord, newRecord DNSRecord) error
 	DeleteRecord(ctx context.Context, record DNSRecord) error
-	ListZones(ctx context.Context) ([]DNSZone, error)
 	CreateZone(ctx context.Context, zone DNSZone) error
 	DeleteZone(ctx context.Context, zoneName string) error
+	ResyncZone(ctx context.Context, zoneName string) error
+	SetZoneOptions(ctx context.Context, zoneName string, options DNSZoneOptionsUpdate) error
+	SignZone(ctx context.Context, zoneName string, signing DNSZoneSigning) error
+	UnsignZone(ctx context.Context, zoneName string) error
+	UpdateDnsKeyTtl(ctx context.Context, zoneName string, ttl int64) error
+	UpdateDnssecKeyRollover(ctx context.Context, zoneName string, keyType string, rolloverDays int64) error
+	RolloverDnssecAlgorithm(ctx context.Context, zoneName string, signing DNSZoneSigning) error
+	UpdateDnssecNxProof(ctx context.Context, zoneName string, nxProof string, iterations int64, saltLength int64) error
+	RemoveDhcpLease(ctx context.Context, scopeName string, clientIdentifier string) error
+	ForceUpdateBlockLists(ctx context.Context) error
+	SetSettings(ctx context.Context, settings DNSSettingsUpdate) error
+	SetAppConfig(ctx context.Context, appName string, config string) error
+}
+
+// client API interface
+type DNSApiClient interface {
+	DNSApiWriter
+	GetRecords(ctx context.Context, domain DNSRecordName) ([]DNSRecord, error)
+	GetZoneRecords(ctx context.Context, zoneName string) ([]DNSRecord, error)
+	ListZones(ctx context.Context) ([]DNSZone, error)
+	GetZoneOptions(ctx context.Context, zoneName string) (DNSZoneOptions, error)
+	GetDnssecProperties(ctx context.Context, zoneName string) (DNSZoneDnssecProperties, error)
+	GetDSRecords(ctx context.Context, zoneName string) ([]DNSZoneDSRecord, error)
+	GetTopDomains(ctx context.Context, statsType string, period DNSStatsPeriod, limit int64) ([]DNSTopDomain, error)
+	ListLogFiles(ctx context.Context) ([]DNSLogFile, error)
+	DownloadLogFile(ctx context.Context, fileName string, limitMB int64) ([]byte, error)
+	QueryLogs(ctx context.Context, query DNSQueryLogQuery) (DNSQueryLogPage, error)
+	Resolve(ctx context.Context, server string, domain string, recordType DNSRecordType) ([]DNSResolvedRecord, error)
+	ListApps(ctx context.Context) ([]DNSApp, error)
+	GetSettings(ctx context.Context) (DNSSettings, error)
+	GetAppConfig(ctx context.Context, appName string) (string, error)
+	GetServerVersion(ctx context.Context) (string, error)
+	ManagedComment() string
 }
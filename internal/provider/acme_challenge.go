@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource              = &AcmeChallengeResource{}
+	_ resource.ResourceWithConfigure = &AcmeChallengeResource{}
+)
+
+// short, for resolvers not to cache a challenge past its validation
+const DEFAULT_ACME_CHALLENGE_TTL = 60
+
+// label of the DNS-01 challenge records, RFC 8555
+const ACME_CHALLENGE_LABEL = "_acme-challenge"
+
+type tfAcmeChallenge struct {
+	ID       types.String   `tfsdk:"id"`
+	Zone     types.String   `tfsdk:"zone"`
+	Domain   types.String   `tfsdk:"domain"`
+	Fqdn     types.String   `tfsdk:"fqdn"`
+	Tokens   []types.String `tfsdk:"tokens"`
+	TTL      types.Int64    `tfsdk:"ttl"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// AcmeChallengeResource manages the TXT records of ACME DNS-01 challenges.
+// Only the records of its own tokens are touched: several resources may hold
+// challenges of the same name, e.g. for a domain and its wildcard
+type AcmeChallengeResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func AcmeChallengeResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &AcmeChallengeResource{reqMutex: m}
+	}
+}
+
+func (r *AcmeChallengeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acme_challenge"
+}
+
+func (r *AcmeChallengeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Publishes ACME DNS-01 challenge tokens as TXT records at `" + ACME_CHALLENGE_LABEL + ".<domain>`, with a short TTL. " +
+			"Only the records of the configured tokens are managed, so that challenges of the same name, e.g. for a domain and its wildcard, " +
+			"can be held by several resources at once. Destroying the resource deletes all its tokens.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The name of the challenge records.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The zone holding the records. Defaults to the closest zone of the challenge name.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain of the certificate, e.g. `example.com`. A wildcard, e.g. `*.example.com`, is validated at the same name as its domain.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfOtherDomain(),
+				},
+			},
+			"fqdn": schema.StringAttribute{
+				MarkdownDescription: "The name of the challenge records, e.g. `" + ACME_CHALLENGE_LABEL + ".example.com`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tokens": schema.SetAttribute{
+				MarkdownDescription: "The key authorization digests to publish, one TXT record each.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, TXT_CHUNK_MAX)),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The time-to-live (TTL) of the records, in seconds. Defaults to %d.", DEFAULT_ACME_CHALLENGE_TTL),
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(DEFAULT_ACME_CHALLENGE_TTL),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 604800),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *AcmeChallengeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AcmeChallengeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfAcmeChallenge
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	ctx = tflog.SetField(ctx, "domain", planData.Domain.ValueString())
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	fqdn := acmeChallengeName(planData.Domain.ValueString())
+	zone, ok := checkRecordZone(ctx, r.client, tfDNSRecord{Zone: planData.Zone, Domain: types.StringValue(fqdn)}, true, &resp.Diagnostics)
+	if !ok || resp.Diagnostics.HasError() {
+		return
+	}
+	planData.Zone = types.StringValue(zone.Name)
+	planData.ID = types.StringValue(fqdn)
+	planData.Fqdn = types.StringValue(fqdn)
+
+	if err := r.apply(ctx, nil, planData); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Creating ACME challenge records of %s: %s", fqdn, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *AcmeChallengeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateData tfAcmeChallenge
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "domain", stateData.Domain.ValueString())
+	tflog.Info(ctx, "read: start")
+	defer tflog.Info(ctx, "read: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	current, err := readAcmeChallenges(ctx, r.client, stateData, stateData.Tokens)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading ACME challenge records of %s: %s", stateData.Fqdn.ValueString(), err))
+		return
+	}
+	if len(current) == 0 {
+		tflog.Warn(ctx, "read: challenge records not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	stateData.Tokens = []types.String{}
+	for _, record := range current {
+		stateData.Tokens = append(stateData.Tokens, types.StringValue(record.Text))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func (r *AcmeChallengeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData, stateData tfAcmeChallenge
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "update")
+	ctx = tflog.SetField(ctx, "domain", planData.Domain.ValueString())
+	tflog.Info(ctx, "update: start")
+	defer tflog.Info(ctx, "update: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	if err := r.apply(ctx, stateData.Tokens, planData); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Updating ACME challenge records of %s: %s", planData.Fqdn.ValueString(), err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *AcmeChallengeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateData tfAcmeChallenge
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "delete")
+	ctx = tflog.SetField(ctx, "domain", stateData.Domain.ValueString())
+	tflog.Info(ctx, "delete: start")
+	defer tflog.Info(ctx, "delete: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	deleteTimeout, diags := stateData.Timeouts.Delete(ctx, DEFAULT_DELETE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// every token is deleted, whether it was found or not: deleting a record
+	// that does not exist is not an error, and a challenge left over would
+	// be served to the next validation
+	for _, token := range stateData.Tokens {
+		record := acmeChallengeRecord(stateData, token.ValueString())
+		if err := r.client.DeleteRecord(ctx, record); err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Deleting ACME challenge record %q of %s: %s", record.Text, stateData.Fqdn.ValueString(), err))
+		}
+	}
+}
+
+// reconcile the records of the tokens managed before or now, leaving the
+// other records of the name alone
+func (r *AcmeChallengeResource) apply(ctx context.Context, prior []types.String, plan tfAcmeChallenge) error {
+	current, err := readAcmeChallenges(ctx, r.client, plan, append(slices.Clone(prior), plan.Tokens...))
+	if err != nil {
+		return err
+	}
+	desired := make([]model.DNSRecord, 0, len(plan.Tokens))
+	for _, token := range plan.Tokens {
+		desired = append(desired, acmeChallengeRecord(plan, token.ValueString()))
+	}
+	return reconcileRecordSet(ctx, r.client, current, desired, ttlChanged)
+}
+
+// the challenge records of the name holding one of the tokens
+func readAcmeChallenges(ctx context.Context, client model.DNSApiClient, data tfAcmeChallenge, tokens []types.String) ([]model.DNSRecord, error) {
+	records, err := readRecordSet(ctx, client, data.Zone.ValueString(), data.Fqdn.ValueString(), model.REC_TXT)
+	if err != nil {
+		return nil, err
+	}
+	found := []model.DNSRecord{}
+	for _, record := range records {
+		if slices.Contains(tokens, types.StringValue(record.Text)) {
+			found = append(found, record)
+		}
+	}
+	return found, nil
+}
+
+func acmeChallengeRecord(data tfAcmeChallenge, token string) model.DNSRecord {
+	return model.DNSRecord{
+		Type:   model.REC_TXT,
+		Domain: model.DNSRecordName(data.Fqdn.ValueString()),
+		Zone:   data.Zone.ValueString(),
+		TTL:    model.DNSRecordTTL(data.TTL.ValueInt64()),
+		Text:   token,
+	}
+}
+
+// challenge name of a domain, the same for its wildcard
+func acmeChallengeName(domain string) string {
+	domain = strings.TrimSuffix(domainToASCII(domain), ".")
+	return ACME_CHALLENGE_LABEL + "." + strings.TrimPrefix(domain, "*.")
+}
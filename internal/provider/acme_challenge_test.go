@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestAcmeChallengeName(t *testing.T) {
+	t.Parallel()
+	for domain, want := range map[string]string{
+		"example.com":       "_acme-challenge.example.com",
+		"*.example.com.":    "_acme-challenge.example.com",
+		"www.bücher.de":     "_acme-challenge.www.xn--bcher-kva.de",
+		"*.sub.example.com": "_acme-challenge.sub.example.com",
+	} {
+		if got := acmeChallengeName(domain); got != want {
+			t.Errorf("acmeChallengeName(%q) = %q, want %q", domain, got, want)
+		}
+	}
+}
+
+// a domain and its wildcard are validated at the same name, by two resources
+func TestAcmeChallengeApply(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := model.NewFakeDNSApiClient()
+	if err := client.CreateZone(ctx, model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY}); err != nil {
+		t.Fatal(err)
+	}
+	r := &AcmeChallengeResource{client: client}
+
+	challenge := func(tokens ...string) tfAcmeChallenge {
+		data := tfAcmeChallenge{
+			Zone: types.StringValue("example.com"),
+			Fqdn: types.StringValue("_acme-challenge.example.com"),
+			TTL:  types.Int64Value(DEFAULT_ACME_CHALLENGE_TTL),
+		}
+		for _, token := range tokens {
+			data.Tokens = append(data.Tokens, types.StringValue(token))
+		}
+		return data
+	}
+	apex, wildcard := challenge("apex-1"), challenge("wildcard-1")
+	for _, data := range []tfAcmeChallenge{apex, wildcard} {
+		if err := r.apply(ctx, nil, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a new apex token replaces the old one only
+	renewed := challenge("apex-2")
+	if err := r.apply(ctx, apex.Tokens, renewed); err != nil {
+		t.Fatal(err)
+	}
+	records, err := client.GetRecords(ctx, "_acme-challenge.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	texts := map[string]bool{}
+	for _, record := range records {
+		texts[record.Text] = true
+	}
+	if len(texts) != 2 || !texts["apex-2"] || !texts["wildcard-1"] {
+		t.Errorf("challenge records = %v, want apex-2 and wildcard-1", texts)
+	}
+
+	found, err := readAcmeChallenges(ctx, client, wildcard, wildcard.Tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].Text != "wildcard-1" || found[0].TTL != DEFAULT_ACME_CHALLENGE_TTL {
+		t.Errorf("wildcard challenge records = %+v, want wildcard-1 only", found)
+	}
+}
@@ -0,0 +1,569 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &AdvancedForwardingResource{}
+	_ resource.ResourceWithConfigure      = &AdvancedForwardingResource{}
+	_ resource.ResourceWithImportState    = &AdvancedForwardingResource{}
+	_ resource.ResourceWithValidateConfig = &AdvancedForwardingResource{}
+)
+
+type tfAdvancedForwarding struct {
+	ID               types.String            `tfsdk:"id"`
+	EnableForwarding types.Bool              `tfsdk:"enable_forwarding"`
+	ProxyServers     []tfForwardingProxy     `tfsdk:"proxy_servers"`
+	Forwarders       []tfForwardingForwarder `tfsdk:"forwarders"`
+	NetworkGroupMap  map[string]string       `tfsdk:"network_group_map"`
+	Groups           []tfForwardingGroup     `tfsdk:"groups"`
+	Timeouts         timeouts.Value          `tfsdk:"timeouts"`
+}
+
+type tfForwardingProxy struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Address  types.String `tfsdk:"address"`
+	Port     types.Int64  `tfsdk:"port"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+}
+
+type tfForwardingForwarder struct {
+	Name             types.String   `tfsdk:"name"`
+	Proxy            types.String   `tfsdk:"proxy"`
+	DnssecValidation types.Bool     `tfsdk:"dnssec_validation"`
+	Protocol         types.String   `tfsdk:"protocol"`
+	Addresses        []types.String `tfsdk:"addresses"`
+}
+
+type tfForwardingGroup struct {
+	Name             types.String          `tfsdk:"name"`
+	EnableForwarding types.Bool            `tfsdk:"enable_forwarding"`
+	Forwardings      []tfForwardingForward `tfsdk:"forwardings"`
+}
+
+type tfForwardingForward struct {
+	Forwarders []types.String `tfsdk:"forwarders"`
+	Domains    []types.String `tfsdk:"domains"`
+}
+
+// AdvancedForwardingResource manages the config of the Advanced Forwarding DNS app
+type AdvancedForwardingResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func AdvancedForwardingResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &AdvancedForwardingResource{reqMutex: m}
+	}
+}
+
+func (r *AdvancedForwardingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_advanced_forwarding"
+}
+
+func (r *AdvancedForwardingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		MarkdownDescription: "Manages the configuration of the Advanced Forwarding DNS app, which must already be installed. " +
+			"Proxy servers, forwarders, groups and the network to group map are managed as a whole and replace the ones of the app config. " +
+			"Destroying the resource disables forwarding.",
+		Attributes: map[string]rschema.Attribute{
+			"id": rschema.StringAttribute{
+				MarkdownDescription: "The name of the app.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enable_forwarding": rschema.BoolAttribute{
+				MarkdownDescription: "Whether the app forwards queries at all. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"proxy_servers": rschema.ListNestedAttribute{
+				MarkdownDescription: "Proxy servers forwarders can connect through.",
+				Optional:            true,
+				NestedObject: rschema.NestedAttributeObject{
+					Attributes: map[string]rschema.Attribute{
+						"name": rschema.StringAttribute{
+							MarkdownDescription: "Unique name of the proxy server, referenced by forwarders.",
+							Required:            true,
+						},
+						"type": rschema.StringAttribute{
+							MarkdownDescription: "The proxy type. Valid values are `http`, `socks5`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(model.FORWARDING_PROXY_HTTP, model.FORWARDING_PROXY_SOCKS5),
+							},
+						},
+						"address": rschema.StringAttribute{
+							MarkdownDescription: "IP address or host name of the proxy server.",
+							Required:            true,
+							Validators: []validator.String{
+								hostAddressValidator{},
+							},
+						},
+						"port": rschema.Int64Attribute{
+							MarkdownDescription: "Port of the proxy server.",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535),
+							},
+						},
+						"username": rschema.StringAttribute{
+							MarkdownDescription: "The proxy server username.",
+							Optional:            true,
+						},
+						"password": rschema.StringAttribute{
+							MarkdownDescription: "The proxy server password.",
+							Optional:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
+			"forwarders": rschema.ListNestedAttribute{
+				MarkdownDescription: "Named sets of upstream servers, referenced by group forwardings.",
+				Optional:            true,
+				NestedObject: rschema.NestedAttributeObject{
+					Attributes: map[string]rschema.Attribute{
+						"name": rschema.StringAttribute{
+							MarkdownDescription: "Unique name of the forwarder.",
+							Required:            true,
+						},
+						"proxy": rschema.StringAttribute{
+							MarkdownDescription: "Name of the proxy server to connect through.",
+							Optional:            true,
+						},
+						"dnssec_validation": rschema.BoolAttribute{
+							MarkdownDescription: "Whether to validate DNSSEC for answers of this forwarder. Defaults to `true`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+						},
+						"protocol": rschema.StringAttribute{
+							MarkdownDescription: "The DNS transport protocol. Valid values are `Udp`, `Tcp`, `Tls`, `Https`, `Quic`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("Udp", "Tcp", "Tls", "Https", "Quic"),
+							},
+						},
+						"addresses": rschema.ListAttribute{
+							MarkdownDescription: "Forwarder addresses, e.g. `9.9.9.9` or `https://dns.quad9.net/dns-query (9.9.9.9)`.",
+							ElementType:         types.StringType,
+							Required:            true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"network_group_map": rschema.MapAttribute{
+				MarkdownDescription: "Maps client networks (`0.0.0.0/0`, `[::]/0`, `192.168.1.0/24` etc.) to group names.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"groups": rschema.ListNestedAttribute{
+				MarkdownDescription: "Groups of forwarding rules, applied to the clients of the networks mapped to them.",
+				Optional:            true,
+				NestedObject: rschema.NestedAttributeObject{
+					Attributes: map[string]rschema.Attribute{
+						"name": rschema.StringAttribute{
+							MarkdownDescription: "Unique name of the group.",
+							Required:            true,
+						},
+						"enable_forwarding": rschema.BoolAttribute{
+							MarkdownDescription: "Whether queries of this group are forwarded. Defaults to `true`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+						},
+						"forwardings": rschema.ListNestedAttribute{
+							MarkdownDescription: "Forwarders to use for given domains.",
+							Optional:            true,
+							NestedObject: rschema.NestedAttributeObject{
+								Attributes: map[string]rschema.Attribute{
+									"forwarders": rschema.ListAttribute{
+										MarkdownDescription: "Names of the forwarders to use.",
+										ElementType:         types.StringType,
+										Required:            true,
+										Validators: []validator.List{
+											listvalidator.SizeAtLeast(1),
+										},
+									},
+									"domains": rschema.ListAttribute{
+										MarkdownDescription: "Domains forwarded, subdomains included; `*` for all.",
+										ElementType:         types.StringType,
+										Required:            true,
+										Validators: []validator.List{
+											listvalidator.SizeAtLeast(1),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]rschema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *AdvancedForwardingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// names must be unique and references between proxies, forwarders and groups
+// must resolve; settings not known yet are not checked
+func (r *AdvancedForwardingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var proxies []tfForwardingProxy
+	var forwarders []tfForwardingForwarder
+	var groups []tfForwardingGroup
+	proxiesKnown := configList(ctx, req.Config, "proxy_servers", &proxies)
+	forwardersKnown := configList(ctx, req.Config, "forwarders", &forwarders)
+	groupsKnown := configList(ctx, req.Config, "groups", &groups)
+
+	proxyNames := uniqueNames(path.Root("proxy_servers"), len(proxies), func(i int) types.String { return proxies[i].Name }, &resp.Diagnostics)
+	forwarderNames := uniqueNames(path.Root("forwarders"), len(forwarders), func(i int) types.String { return forwarders[i].Name }, &resp.Diagnostics)
+	groupNames := uniqueNames(path.Root("groups"), len(groups), func(i int) types.String { return groups[i].Name }, &resp.Diagnostics)
+
+	if proxiesKnown {
+		for i, forwarder := range forwarders {
+			if forwarder.Proxy.IsNull() || forwarder.Proxy.IsUnknown() || proxyNames[forwarder.Proxy.ValueString()] {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(path.Root("forwarders").AtListIndex(i).AtName("proxy"), "Unknown proxy server",
+				fmt.Sprintf("No proxy server is named '%s'", forwarder.Proxy.ValueString()))
+		}
+	}
+
+	if forwardersKnown {
+		for i, group := range groups {
+			for j, forwarding := range group.Forwardings {
+				for k, name := range forwarding.Forwarders {
+					if name.IsUnknown() || forwarderNames[name.ValueString()] {
+						continue
+					}
+					resp.Diagnostics.AddAttributeError(
+						path.Root("groups").AtListIndex(i).AtName("forwardings").AtListIndex(j).AtName("forwarders").AtListIndex(k),
+						"Unknown forwarder", fmt.Sprintf("No forwarder is named '%s'", name.ValueString()))
+				}
+			}
+		}
+	}
+
+	var networks types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("network_group_map"), &networks)...)
+	validateNetworkGroupMap(networks, groupsKnown, groupNames, &resp.Diagnostics)
+}
+
+func (r *AdvancedForwardingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfAdvancedForwarding
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	ctx = tflog.SetField(ctx, "app", model.APP_ADVANCED_FORWARDING)
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.setConfig(ctx, tfAdvancedForwarding2model(planData))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planData.ID = types.StringValue(model.APP_ADVANCED_FORWARDING)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *AdvancedForwardingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateData tfAdvancedForwarding
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "app", model.APP_ADVANCED_FORWARDING)
+	tflog.Info(ctx, "read: start")
+	defer tflog.Info(ctx, "read: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var config model.AdvancedForwardingConfig
+	if err := readAppConfig(ctx, r.client, model.APP_ADVANCED_FORWARDING, &config); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading Advanced Forwarding app config: %s", err))
+		return
+	}
+
+	stateData = mergeAdvancedForwardingState(stateData, config)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func (r *AdvancedForwardingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData tfAdvancedForwarding
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "update")
+	ctx = tflog.SetField(ctx, "app", model.APP_ADVANCED_FORWARDING)
+	tflog.Info(ctx, "update: start")
+	defer tflog.Info(ctx, "update: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.setConfig(ctx, tfAdvancedForwarding2model(planData))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planData.ID = types.StringValue(model.APP_ADVANCED_FORWARDING)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *AdvancedForwardingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateData tfAdvancedForwarding
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "delete")
+	ctx = tflog.SetField(ctx, "app", model.APP_ADVANCED_FORWARDING)
+	tflog.Info(ctx, "delete: start")
+	defer tflog.Info(ctx, "delete: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	deleteTimeout, diags := stateData.Timeouts.Delete(ctx, DEFAULT_DELETE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.setConfig(ctx, map[string]any{"enableForwarding": false})...)
+}
+
+// terraform import technitium_app_advanced_forwarding.example "Advanced Forwarding"
+func (r *AdvancedForwardingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = tflog.SetField(ctx, "operation", "import")
+	ctx = tflog.SetField(ctx, "app", model.APP_ADVANCED_FORWARDING)
+	tflog.Info(ctx, "import: start")
+	defer tflog.Info(ctx, "import: end")
+
+	if req.ID != model.APP_ADVANCED_FORWARDING {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected the app name '%s', got '%s'", model.APP_ADVANCED_FORWARDING, req.ID))
+		return
+	}
+
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
+
+	var config model.AdvancedForwardingConfig
+	if err := readAppConfig(ctx, r.client, model.APP_ADVANCED_FORWARDING, &config); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading Advanced Forwarding app config: %s", err))
+		return
+	}
+
+	stateData := mergeAdvancedForwardingState(tfAdvancedForwarding{
+		ID:       types.StringValue(model.APP_ADVANCED_FORWARDING),
+		Timeouts: nullTimeouts(),
+	}, config)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+// the network map is free-form and replaced as a whole, lists always are
+func (r *AdvancedForwardingResource) setConfig(ctx context.Context, config any) diag.Diagnostics {
+	return writeAppConfig(ctx, r.client, model.APP_ADVANCED_FORWARDING, []string{"networkGroupMap"}, config)
+}
+
+func tfAdvancedForwarding2model(data tfAdvancedForwarding) model.AdvancedForwardingConfig {
+	config := model.AdvancedForwardingConfig{
+		EnableForwarding: data.EnableForwarding.ValueBool(),
+		ProxyServers:     []model.AdvancedForwardingProxy{},
+		Forwarders:       []model.AdvancedForwardingForwarder{},
+		NetworkGroupMap:  map[string]string{},
+		Groups:           []model.AdvancedForwardingGroup{},
+	}
+
+	for _, proxy := range data.ProxyServers {
+		config.ProxyServers = append(config.ProxyServers, model.AdvancedForwardingProxy{
+			Name:          proxy.Name.ValueString(),
+			Type:          proxy.Type.ValueString(),
+			ProxyAddress:  proxy.Address.ValueString(),
+			ProxyPort:     proxy.Port.ValueInt64(),
+			ProxyUsername: proxy.Username.ValueStringPointer(),
+			ProxyPassword: proxy.Password.ValueStringPointer(),
+		})
+	}
+	for _, forwarder := range data.Forwarders {
+		config.Forwarders = append(config.Forwarders, model.AdvancedForwardingForwarder{
+			Name:               forwarder.Name.ValueString(),
+			Proxy:              forwarder.Proxy.ValueStringPointer(),
+			DnssecValidation:   forwarder.DnssecValidation.ValueBool(),
+			ForwarderProtocol:  forwarder.Protocol.ValueString(),
+			ForwarderAddresses: tfStrings2model(forwarder.Addresses),
+		})
+	}
+	for network, group := range data.NetworkGroupMap {
+		config.NetworkGroupMap[network] = group
+	}
+	for _, group := range data.Groups {
+		apiGroup := model.AdvancedForwardingGroup{
+			Name:             group.Name.ValueString(),
+			EnableForwarding: group.EnableForwarding.ValueBool(),
+			Forwardings:      []model.AdvancedForwardingForward{},
+		}
+		for _, forwarding := range group.Forwardings {
+			apiGroup.Forwardings = append(apiGroup.Forwardings, model.AdvancedForwardingForward{
+				Forwarders: tfStrings2model(forwarding.Forwarders),
+				Domains:    tfStrings2model(forwarding.Domains),
+			})
+		}
+		config.Groups = append(config.Groups, apiGroup)
+	}
+
+	return config
+}
+
+// refresh state from the app config, lists left unset stay null while empty
+func mergeAdvancedForwardingState(state tfAdvancedForwarding, config model.AdvancedForwardingConfig) tfAdvancedForwarding {
+	state.EnableForwarding = types.BoolValue(config.EnableForwarding)
+
+	if state.ProxyServers != nil || len(config.ProxyServers) > 0 {
+		state.ProxyServers = []tfForwardingProxy{}
+	}
+	for _, proxy := range config.ProxyServers {
+		state.ProxyServers = append(state.ProxyServers, tfForwardingProxy{
+			Name:     types.StringValue(proxy.Name),
+			Type:     types.StringValue(proxy.Type),
+			Address:  types.StringValue(proxy.ProxyAddress),
+			Port:     types.Int64Value(proxy.ProxyPort),
+			Username: types.StringPointerValue(proxy.ProxyUsername),
+			Password: types.StringPointerValue(proxy.ProxyPassword),
+		})
+	}
+
+	if state.Forwarders != nil || len(config.Forwarders) > 0 {
+		state.Forwarders = []tfForwardingForwarder{}
+	}
+	for _, forwarder := range config.Forwarders {
+		state.Forwarders = append(state.Forwarders, tfForwardingForwarder{
+			Name:             types.StringValue(forwarder.Name),
+			Proxy:            types.StringPointerValue(forwarder.Proxy),
+			DnssecValidation: types.BoolValue(forwarder.DnssecValidation),
+			Protocol:         types.StringValue(forwarder.ForwarderProtocol),
+			Addresses:        modelStrings2tf(forwarder.ForwarderAddresses),
+		})
+	}
+
+	if state.NetworkGroupMap != nil || len(config.NetworkGroupMap) > 0 {
+		state.NetworkGroupMap = map[string]string{}
+	}
+	for network, group := range config.NetworkGroupMap {
+		state.NetworkGroupMap[network] = group
+	}
+
+	priorGroups := state.Groups
+	if state.Groups != nil || len(config.Groups) > 0 {
+		state.Groups = []tfForwardingGroup{}
+	}
+	for i, group := range config.Groups {
+		tfGroup := tfForwardingGroup{
+			Name:             types.StringValue(group.Name),
+			EnableForwarding: types.BoolValue(group.EnableForwarding),
+		}
+		// keep an unset forwardings list null, whatever group is at its index
+		if len(group.Forwardings) > 0 || (i < len(priorGroups) && priorGroups[i].Forwardings != nil) {
+			tfGroup.Forwardings = []tfForwardingForward{}
+		}
+		for _, forwarding := range group.Forwardings {
+			tfGroup.Forwardings = append(tfGroup.Forwardings, tfForwardingForward{
+				Forwarders: modelStrings2tf(forwarding.Forwarders),
+				Domains:    modelStrings2tf(forwarding.Domains),
+			})
+		}
+		state.Groups = append(state.Groups, tfGroup)
+	}
+
+	return state
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// app configs are free-form JSON documents owned by each DNS app: typed app
+// resources only manage the keys they know about, everything else is kept
+
+// read the config of an installed app into its typed model
+func readAppConfig(ctx context.Context, client model.DNSApiClient, appName string, v any) error {
+	raw, err := client.GetAppConfig(ctx, appName)
+	if err != nil {
+		return err
+	}
+	return decodeAppConfig(raw, v)
+}
+
+// merge configs in order over the current config of an installed app and save it
+func writeAppConfig(ctx context.Context, client model.DNSApiClient, appName string, replaced []string, configs ...any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	merged, err := client.GetAppConfig(ctx, appName)
+	if err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Reading %s app config: %s", appName, err))
+		return diags
+	}
+	for _, config := range configs {
+		merged, err = mergeAppConfig(merged, config, replaced...)
+		if err != nil {
+			diags.AddError("Invalid app config", err.Error())
+			return diags
+		}
+	}
+	if err := client.SetAppConfig(ctx, appName, merged); err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Saving %s app config: %s", appName, err))
+	}
+	return diags
+}
+
+// decode an app config into its typed model, an empty config leaves v untouched
+func decodeAppConfig(config string, v any) error {
+	if config == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(config), v); err != nil {
+		return fmt.Errorf("cannot parse app config: %w", err)
+	}
+	return nil
+}
+
+// merge a typed (or partial) app config over the current one and return the
+// resulting JSON document; objects at the replaced dotted paths (free-form
+// maps like HTTP headers) are replaced instead of merged
+func mergeAppConfig(current string, typed any, replaced ...string) (string, error) {
+	base := map[string]any{}
+	if current != "" {
+		if err := json.Unmarshal([]byte(current), &base); err != nil {
+			return "", fmt.Errorf("cannot parse app config: %w", err)
+		}
+	}
+
+	raw, err := json.Marshal(typed)
+	if err != nil {
+		return "", err
+	}
+	var overlay map[string]any
+	if err := json.Unmarshal(raw, &overlay); err != nil {
+		return "", err
+	}
+
+	for _, replacedPath := range replaced {
+		deleteJSONPath(base, overlay, strings.Split(replacedPath, "."))
+	}
+
+	merged, err := json.MarshalIndent(mergeJSONObjects(base, overlay), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(merged), nil
+}
+
+// delete keys from base when the overlay sets them
+func deleteJSONPath(base, overlay map[string]any, keys []string) {
+	if _, ok := overlay[keys[0]]; !ok {
+		return
+	}
+	if len(keys) == 1 {
+		delete(base, keys[0])
+		return
+	}
+	baseObject, baseOk := base[keys[0]].(map[string]any)
+	overlayObject, ok := overlay[keys[0]].(map[string]any)
+	if ok && baseOk {
+		deleteJSONPath(baseObject, overlayObject, keys[1:])
+	}
+}
+
+// recursively merge overlay into base: nested objects are merged, any other
+// value replaces the base one
+func mergeJSONObjects(base, overlay map[string]any) map[string]any {
+	for key, value := range overlay {
+		overlayObject, ok := value.(map[string]any)
+		baseObject, baseOk := base[key].(map[string]any)
+		if ok && baseOk {
+			base[key] = mergeJSONObjects(baseObject, overlayObject)
+			continue
+		}
+		base[key] = value
+	}
+	return base
+}
+
+// get a list attribute of the config, false if it (or one of its elements) is not known yet
+func configList(ctx context.Context, config tfsdk.Config, name string, target any) bool {
+	var list types.List
+	if config.GetAttribute(ctx, path.Root(name), &list).HasError() || list.IsUnknown() {
+		return false
+	}
+	return !list.ElementsAs(ctx, target, false).HasError()
+}
+
+// get a set attribute of the config, false if it is not known yet
+func configSet(ctx context.Context, config tfsdk.Config, name string, target any) bool {
+	var set types.Set
+	if config.GetAttribute(ctx, path.Root(name), &set).HasError() || set.IsUnknown() {
+		return false
+	}
+	return !set.ElementsAs(ctx, target, false).HasError()
+}
+
+// report duplicated names of a nested list, return the set of known names
+func uniqueNames(listPath path.Path, count int, name func(int) types.String, diags *diag.Diagnostics) map[string]bool {
+	names := map[string]bool{}
+	for i := range count {
+		value := name(i)
+		if value.IsUnknown() || value.IsNull() {
+			continue
+		}
+		if names[value.ValueString()] {
+			diags.AddAttributeError(listPath.AtListIndex(i).AtName("name"), "Duplicate name",
+				fmt.Sprintf("Name '%s' is used more than once", value.ValueString()))
+		}
+		names[value.ValueString()] = true
+	}
+	return names
+}
+
+// IP address or network in CIDR notation, IPv6 ones may be bracketed
+func isNetworkAddress(network string) bool {
+	return parseNetworkAddress(network) != nil
+}
+
+// address of an IP address or network as accepted by isNetworkAddress, nil if invalid
+func parseNetworkAddress(network string) net.IP {
+	address, prefix, hasPrefix := strings.Cut(network, "/")
+	address = strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+	if !hasPrefix {
+		return net.ParseIP(address)
+	}
+	ip, _, err := net.ParseCIDR(address + "/" + prefix)
+	if err != nil {
+		return nil
+	}
+	return ip
+}
+
+// network_group_map keys must be networks and values names of configured groups
+func validateNetworkGroupMap(networks types.Map, groupsKnown bool, groupNames map[string]bool, diags *diag.Diagnostics) {
+	if networks.IsNull() || networks.IsUnknown() {
+		return
+	}
+	for network, group := range networks.Elements() {
+		networkPath := path.Root("network_group_map").AtMapKey(network)
+		if !isNetworkAddress(network) {
+			diags.AddAttributeError(networkPath, "Invalid network",
+				fmt.Sprintf("'%s' is neither an IP address nor a network address", network))
+		}
+		groupName, ok := group.(types.String)
+		if !ok || !groupsKnown || groupName.IsUnknown() || groupNames[groupName.ValueString()] {
+			continue
+		}
+		diags.AddAttributeError(networkPath, "Unknown group",
+			fmt.Sprintf("No group is named '%s'", groupName.ValueString()))
+	}
+}
+
+func tfStrings2model(values []types.String) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, value.ValueString())
+	}
+	return result
+}
+
+func modelStrings2tf(values []string) []types.String {
+	result := make([]types.String, 0, len(values))
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
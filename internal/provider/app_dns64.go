@@ -0,0 +1,458 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &DNS64Resource{}
+	_ resource.ResourceWithConfigure      = &DNS64Resource{}
+	_ resource.ResourceWithImportState    = &DNS64Resource{}
+	_ resource.ResourceWithValidateConfig = &DNS64Resource{}
+)
+
+// DNS64 prefix lengths allowed by RFC 6052
+var dns64PrefixLengths = []int{32, 40, 48, 56, 64, 96}
+
+type tfDNS64 struct {
+	ID              types.String      `tfsdk:"id"`
+	EnableDns64     types.Bool        `tfsdk:"enable_dns64"`
+	NetworkGroupMap map[string]string `tfsdk:"network_group_map"`
+	Groups          []tfDNS64Group    `tfsdk:"groups"`
+	Timeouts        timeouts.Value    `tfsdk:"timeouts"`
+}
+
+type tfDNS64Group struct {
+	Name         types.String      `tfsdk:"name"`
+	EnableDns64  types.Bool        `tfsdk:"enable_dns64"`
+	PrefixMap    map[string]string `tfsdk:"prefix_map"`
+	ExcludedIpv4 []types.String    `tfsdk:"excluded_ipv4"`
+	ExcludedIpv6 []types.String    `tfsdk:"excluded_ipv6"`
+}
+
+// DNS64Resource manages the config of the DNS64 DNS app
+type DNS64Resource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func DNS64ResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &DNS64Resource{reqMutex: m}
+	}
+}
+
+func (r *DNS64Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_dns64"
+}
+
+func (r *DNS64Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		MarkdownDescription: "Manages the configuration of the DNS64 DNS app, which must already be installed. " +
+			"Groups and the network to group map are managed as a whole and replace the ones of the app config. " +
+			"Destroying the resource disables DNS64.",
+		Attributes: map[string]rschema.Attribute{
+			"id": rschema.StringAttribute{
+				MarkdownDescription: "The name of the app.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enable_dns64": rschema.BoolAttribute{
+				MarkdownDescription: "Whether the app synthesizes AAAA records at all. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"network_group_map": rschema.MapAttribute{
+				MarkdownDescription: "Maps client networks (`0.0.0.0/0`, `::/0`, `2001:db8::/32` etc.) to group names.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"groups": rschema.ListNestedAttribute{
+				MarkdownDescription: "DNS64 settings applied to the clients of the networks mapped to them.",
+				Optional:            true,
+				NestedObject: rschema.NestedAttributeObject{
+					Attributes: map[string]rschema.Attribute{
+						"name": rschema.StringAttribute{
+							MarkdownDescription: "Unique name of the group.",
+							Required:            true,
+						},
+						"enable_dns64": rschema.BoolAttribute{
+							MarkdownDescription: "Whether AAAA records are synthesized for this group. Defaults to `true`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+						},
+						"prefix_map": rschema.MapAttribute{
+							MarkdownDescription: "Maps IPv4 networks of A answers to the IPv6 prefix used to synthesize them, " +
+								"e.g. `{ \"0.0.0.0/0\" = \"64:ff9b::/96\" }`. Prefix lengths must be 32, 40, 48, 56, 64 or 96.",
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+						"excluded_ipv4": rschema.ListAttribute{
+							MarkdownDescription: "IPv4 networks of A answers never synthesized, e.g. private ranges.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"excluded_ipv6": rschema.ListAttribute{
+							MarkdownDescription: "IPv6 networks of AAAA answers ignored, so that AAAA records are synthesized instead, e.g. `::ffff:0:0/96`.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]rschema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *DNS64Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// group names must be unique and referenced groups exist, networks and
+// prefixes must be of the right address family; unknown settings are not checked
+func (r *DNS64Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var groups []tfDNS64Group
+	groupsKnown := configList(ctx, req.Config, "groups", &groups)
+	groupNames := uniqueNames(path.Root("groups"), len(groups), func(i int) types.String { return groups[i].Name }, &resp.Diagnostics)
+
+	for i, group := range groups {
+		groupPath := path.Root("groups").AtListIndex(i)
+		for network, prefix := range group.PrefixMap {
+			networkPath := groupPath.AtName("prefix_map").AtMapKey(network)
+			if ip := parseNetworkAddress(network); ip == nil || ip.To4() == nil {
+				resp.Diagnostics.AddAttributeError(networkPath, "Invalid network",
+					fmt.Sprintf("'%s' is not an IPv4 network", network))
+			}
+			if !isDNS64Prefix(prefix) {
+				resp.Diagnostics.AddAttributeError(networkPath, "Invalid prefix",
+					fmt.Sprintf("'%s' is not an IPv6 prefix of length %v", prefix, dns64PrefixLengths))
+			}
+		}
+		for j, network := range group.ExcludedIpv4 {
+			ip := parseNetworkAddress(network.ValueString())
+			if network.IsUnknown() || (ip != nil && ip.To4() != nil) {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(groupPath.AtName("excluded_ipv4").AtListIndex(j), "Invalid network",
+				fmt.Sprintf("'%s' is not an IPv4 network", network.ValueString()))
+		}
+		for j, network := range group.ExcludedIpv6 {
+			ip := parseNetworkAddress(network.ValueString())
+			if network.IsUnknown() || (ip != nil && ip.To4() == nil) {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(groupPath.AtName("excluded_ipv6").AtListIndex(j), "Invalid network",
+				fmt.Sprintf("'%s' is not an IPv6 network", network.ValueString()))
+		}
+	}
+
+	var networks types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("network_group_map"), &networks)...)
+	validateNetworkGroupMap(networks, groupsKnown, groupNames, &resp.Diagnostics)
+}
+
+func isDNS64Prefix(prefix string) bool {
+	ip, network, err := net.ParseCIDR(prefix)
+	if err != nil || ip.To4() != nil {
+		return false
+	}
+	length, _ := network.Mask.Size()
+	return slices.Contains(dns64PrefixLengths, length)
+}
+
+func (r *DNS64Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfDNS64
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	ctx = tflog.SetField(ctx, "app", model.APP_DNS64)
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.setConfig(ctx, tfDNS642model(planData))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planData.ID = types.StringValue(model.APP_DNS64)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *DNS64Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateData tfDNS64
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "app", model.APP_DNS64)
+	tflog.Info(ctx, "read: start")
+	defer tflog.Info(ctx, "read: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var config model.DNS64Config
+	if err := readAppConfig(ctx, r.client, model.APP_DNS64, &config); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading DNS64 app config: %s", err))
+		return
+	}
+
+	stateData = mergeDNS64State(stateData, config)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func (r *DNS64Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData tfDNS64
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "update")
+	ctx = tflog.SetField(ctx, "app", model.APP_DNS64)
+	tflog.Info(ctx, "update: start")
+	defer tflog.Info(ctx, "update: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.setConfig(ctx, tfDNS642model(planData))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planData.ID = types.StringValue(model.APP_DNS64)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *DNS64Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateData tfDNS64
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "delete")
+	ctx = tflog.SetField(ctx, "app", model.APP_DNS64)
+	tflog.Info(ctx, "delete: start")
+	defer tflog.Info(ctx, "delete: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	deleteTimeout, diags := stateData.Timeouts.Delete(ctx, DEFAULT_DELETE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.setConfig(ctx, map[string]any{"enableDns64": false})...)
+}
+
+// terraform import technitium_app_dns64.example DNS64
+func (r *DNS64Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = tflog.SetField(ctx, "operation", "import")
+	ctx = tflog.SetField(ctx, "app", model.APP_DNS64)
+	tflog.Info(ctx, "import: start")
+	defer tflog.Info(ctx, "import: end")
+
+	if req.ID != model.APP_DNS64 {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected the app name '%s', got '%s'", model.APP_DNS64, req.ID))
+		return
+	}
+
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
+
+	var config model.DNS64Config
+	if err := readAppConfig(ctx, r.client, model.APP_DNS64, &config); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading DNS64 app config: %s", err))
+		return
+	}
+
+	stateData := mergeDNS64State(tfDNS64{
+		ID:       types.StringValue(model.APP_DNS64),
+		Timeouts: nullTimeouts(),
+	}, config)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+// the network and prefix maps are free-form and replaced as a whole, lists always are
+func (r *DNS64Resource) setConfig(ctx context.Context, config any) diag.Diagnostics {
+	return writeAppConfig(ctx, r.client, model.APP_DNS64, []string{"networkGroupMap"}, config)
+}
+
+func tfDNS642model(data tfDNS64) model.DNS64Config {
+	config := model.DNS64Config{
+		EnableDns64:     data.EnableDns64.ValueBool(),
+		NetworkGroupMap: map[string]string{},
+		Groups:          []model.DNS64Group{},
+	}
+
+	for network, group := range data.NetworkGroupMap {
+		config.NetworkGroupMap[network] = group
+	}
+	for _, group := range data.Groups {
+		apiGroup := model.DNS64Group{
+			Name:           group.Name.ValueString(),
+			EnableDns64:    group.EnableDns64.ValueBool(),
+			Dns64PrefixMap: map[string]*string{},
+			ExcludedIpv6:   tfStrings2model(group.ExcludedIpv6),
+		}
+		for network, prefix := range group.PrefixMap {
+			apiGroup.Dns64PrefixMap[network] = &prefix
+		}
+		for _, network := range group.ExcludedIpv4 {
+			apiGroup.Dns64PrefixMap[network.ValueString()] = nil
+		}
+		config.Groups = append(config.Groups, apiGroup)
+	}
+
+	return config
+}
+
+// refresh state from the app config, collections left unset stay null while empty
+func mergeDNS64State(state tfDNS64, config model.DNS64Config) tfDNS64 {
+	state.EnableDns64 = types.BoolValue(config.EnableDns64)
+
+	if state.NetworkGroupMap != nil || len(config.NetworkGroupMap) > 0 {
+		state.NetworkGroupMap = map[string]string{}
+	}
+	for network, group := range config.NetworkGroupMap {
+		state.NetworkGroupMap[network] = group
+	}
+
+	priorGroups := state.Groups
+	if state.Groups != nil || len(config.Groups) > 0 {
+		state.Groups = []tfDNS64Group{}
+	}
+	for i, group := range config.Groups {
+		var prior tfDNS64Group
+		if i < len(priorGroups) {
+			prior = priorGroups[i]
+		}
+
+		tfGroup := tfDNS64Group{
+			Name:        types.StringValue(group.Name),
+			EnableDns64: types.BoolValue(group.EnableDns64),
+		}
+		var excludedIpv4 []string
+		for network, prefix := range group.Dns64PrefixMap {
+			if prefix == nil {
+				excludedIpv4 = append(excludedIpv4, network)
+				continue
+			}
+			if tfGroup.PrefixMap == nil {
+				tfGroup.PrefixMap = map[string]string{}
+			}
+			tfGroup.PrefixMap[network] = *prefix
+		}
+		if tfGroup.PrefixMap == nil && prior.PrefixMap != nil {
+			tfGroup.PrefixMap = map[string]string{}
+		}
+		// the order of excluded networks is lost in the prefix map, keep the configured one
+		tfGroup.ExcludedIpv4 = orderedLike(prior.ExcludedIpv4, excludedIpv4)
+		if len(group.ExcludedIpv6) > 0 || prior.ExcludedIpv6 != nil {
+			tfGroup.ExcludedIpv6 = modelStrings2tf(group.ExcludedIpv6)
+		}
+		state.Groups = append(state.Groups, tfGroup)
+	}
+
+	return state
+}
+
+// values as a list ordered like prior, new values last in sorted order;
+// nil if there are none and prior was not set
+func orderedLike(prior []types.String, values []string) []types.String {
+	if len(values) == 0 && prior == nil {
+		return nil
+	}
+
+	result := []types.String{}
+	for _, value := range prior {
+		if slices.Contains(values, value.ValueString()) {
+			result = append(result, value)
+		}
+	}
+	slices.Sort(values)
+	for _, value := range values {
+		if !slices.ContainsFunc(result, func(v types.String) bool { return v.ValueString() == value }) {
+			result = append(result, types.StringValue(value))
+		}
+	}
+	return result
+}
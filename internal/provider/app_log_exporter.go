@@ -0,0 +1,488 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &LogExporterResource{}
+	_ resource.ResourceWithConfigure      = &LogExporterResource{}
+	_ resource.ResourceWithImportState    = &LogExporterResource{}
+	_ resource.ResourceWithValidateConfig = &LogExporterResource{}
+)
+
+const DEFAULT_SYSLOG_PORT = 514
+
+type tfLogExporter struct {
+	ID           types.String         `tfsdk:"id"`
+	MaxQueueSize types.Int64          `tfsdk:"max_queue_size"`
+	FileOutput   *tfLogExporterFile   `tfsdk:"file_output"`
+	HTTPOutput   *tfLogExporterHTTP   `tfsdk:"http_output"`
+	SyslogOutput *tfLogExporterSyslog `tfsdk:"syslog_output"`
+	Timeouts     timeouts.Value       `tfsdk:"timeouts"`
+}
+
+type tfLogExporterFile struct {
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Path    types.String `tfsdk:"path"`
+}
+
+type tfLogExporterHTTP struct {
+	Enabled  types.Bool   `tfsdk:"enabled"`
+	Endpoint types.String `tfsdk:"endpoint"`
+	Headers  types.Map    `tfsdk:"headers"`
+}
+
+type tfLogExporterSyslog struct {
+	Enabled  types.Bool   `tfsdk:"enabled"`
+	Address  types.String `tfsdk:"address"`
+	Port     types.Int64  `tfsdk:"port"`
+	Protocol types.String `tfsdk:"protocol"`
+}
+
+// LogExporterResource manages the config of the Log Exporter DNS app
+type LogExporterResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func LogExporterResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &LogExporterResource{reqMutex: m}
+	}
+}
+
+func (r *LogExporterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_log_exporter"
+}
+
+func (r *LogExporterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		MarkdownDescription: "Manages the configuration of the Log Exporter DNS app, which must already be installed. " +
+			"Only the outputs set here are managed, other settings of the app config are left as they are. " +
+			"Destroying the resource disables the managed outputs.",
+		Attributes: map[string]rschema.Attribute{
+			"id": rschema.StringAttribute{
+				MarkdownDescription: "The name of the app.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_queue_size": rschema.Int64Attribute{
+				MarkdownDescription: "Maximum number of log entries queued before new entries are dropped.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"file_output": rschema.SingleNestedAttribute{
+				MarkdownDescription: "Export query logs to a file on the DNS server.",
+				Optional:            true,
+				Attributes: map[string]rschema.Attribute{
+					"enabled": rschema.BoolAttribute{
+						MarkdownDescription: "Whether the output is enabled. Defaults to `true`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(true),
+					},
+					"path": rschema.StringAttribute{
+						MarkdownDescription: "Path of the log file, relative paths are resolved in the app folder.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+				},
+			},
+			"http_output": rschema.SingleNestedAttribute{
+				MarkdownDescription: "Post query logs as JSON to an HTTP endpoint.",
+				Optional:            true,
+				Attributes: map[string]rschema.Attribute{
+					"enabled": rschema.BoolAttribute{
+						MarkdownDescription: "Whether the output is enabled. Defaults to `true`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(true),
+					},
+					"endpoint": rschema.StringAttribute{
+						MarkdownDescription: "The http or https URL logs are posted to.",
+						Required:            true,
+						Validators: []validator.String{
+							httpURLValidator{},
+						},
+					},
+					"headers": rschema.MapAttribute{
+						MarkdownDescription: "Extra HTTP headers sent with each request, e.g. `Authorization`.",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			"syslog_output": rschema.SingleNestedAttribute{
+				MarkdownDescription: "Send query logs to a syslog server.",
+				Optional:            true,
+				Attributes: map[string]rschema.Attribute{
+					"enabled": rschema.BoolAttribute{
+						MarkdownDescription: "Whether the output is enabled. Defaults to `true`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(true),
+					},
+					"address": rschema.StringAttribute{
+						MarkdownDescription: "IP address or host name of the syslog server, or the socket path for the `Local` protocol.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"port": rschema.Int64Attribute{
+						MarkdownDescription: fmt.Sprintf("Port of the syslog server. Defaults to `%d`.", DEFAULT_SYSLOG_PORT),
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(DEFAULT_SYSLOG_PORT),
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+					},
+					"protocol": rschema.StringAttribute{
+						MarkdownDescription: "Syslog transport. Valid values are `UDP`, `TCP`, `TLS`, `Local`. Defaults to `UDP`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString(model.SYSLOG_UDP),
+						Validators: []validator.String{
+							stringvalidator.OneOf(model.SYSLOG_UDP, model.SYSLOG_TCP, model.SYSLOG_TLS, model.SYSLOG_LOCAL),
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]rschema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *LogExporterResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// the syslog address is only a network address for non-local transports
+func (r *LogExporterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var protocol, address types.String
+	addressPath := path.Root("syslog_output").AtName("address")
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("syslog_output").AtName("protocol"), &protocol)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, addressPath, &address)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if protocol.ValueString() == model.SYSLOG_LOCAL || protocol.IsUnknown() {
+		return
+	}
+	var addressResp validator.StringResponse
+	hostAddressValidator{}.ValidateString(ctx, validator.StringRequest{
+		Path:        addressPath,
+		ConfigValue: address,
+	}, &addressResp)
+	resp.Diagnostics.Append(addressResp.Diagnostics...)
+}
+
+func (r *LogExporterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfLogExporter
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	ctx = tflog.SetField(ctx, "app", model.APP_LOG_EXPORTER)
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	config := tfLogExporter2model(ctx, planData, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.setConfig(ctx, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planData.ID = types.StringValue(model.APP_LOG_EXPORTER)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *LogExporterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateData tfLogExporter
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "app", model.APP_LOG_EXPORTER)
+	tflog.Info(ctx, "read: start")
+	defer tflog.Info(ctx, "read: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	config, err := r.readConfig(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading Log Exporter app config: %s", err))
+		return
+	}
+
+	// only the outputs present in the state are tracked
+	stateData = mergeLogExporterState(ctx, stateData, config, false)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func (r *LogExporterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData, stateData tfLogExporter
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "update")
+	ctx = tflog.SetField(ctx, "app", model.APP_LOG_EXPORTER)
+	tflog.Info(ctx, "update: start")
+	defer tflog.Info(ctx, "update: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	config := tfLogExporter2model(ctx, planData, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// outputs removed from the configuration are no longer managed: disable them
+	resp.Diagnostics.Append(r.setConfig(ctx, disabledLogExporterOutputs(stateData), config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planData.ID = types.StringValue(model.APP_LOG_EXPORTER)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *LogExporterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateData tfLogExporter
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "delete")
+	ctx = tflog.SetField(ctx, "app", model.APP_LOG_EXPORTER)
+	tflog.Info(ctx, "delete: start")
+	defer tflog.Info(ctx, "delete: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	deleteTimeout, diags := stateData.Timeouts.Delete(ctx, DEFAULT_DELETE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.setConfig(ctx, disabledLogExporterOutputs(stateData))...)
+}
+
+// terraform import technitium_app_log_exporter.example "Log Exporter"
+func (r *LogExporterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = tflog.SetField(ctx, "operation", "import")
+	ctx = tflog.SetField(ctx, "app", model.APP_LOG_EXPORTER)
+	tflog.Info(ctx, "import: start")
+	defer tflog.Info(ctx, "import: end")
+
+	if req.ID != model.APP_LOG_EXPORTER {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected the app name '%s', got '%s'", model.APP_LOG_EXPORTER, req.ID))
+		return
+	}
+
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
+
+	config, err := r.readConfig(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading Log Exporter app config: %s", err))
+		return
+	}
+
+	stateData := mergeLogExporterState(ctx, tfLogExporter{
+		ID:       types.StringValue(model.APP_LOG_EXPORTER),
+		Timeouts: nullTimeouts(),
+	}, config, true)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+// the typed config of the app, free-form HTTP headers are replaced as a whole
+func (r *LogExporterResource) readConfig(ctx context.Context) (model.LogExporterConfig, error) {
+	var config model.LogExporterConfig
+	return config, readAppConfig(ctx, r.client, model.APP_LOG_EXPORTER, &config)
+}
+
+func (r *LogExporterResource) setConfig(ctx context.Context, configs ...any) diag.Diagnostics {
+	return writeAppConfig(ctx, r.client, model.APP_LOG_EXPORTER, []string{"http.headers"}, configs...)
+}
+
+func tfLogExporter2model(ctx context.Context, data tfLogExporter, diags *diag.Diagnostics) model.LogExporterConfig {
+	var config model.LogExporterConfig
+
+	if !data.MaxQueueSize.IsNull() {
+		v := data.MaxQueueSize.ValueInt64()
+		config.MaxQueueSize = &v
+	}
+	if data.FileOutput != nil {
+		config.File = &model.LogExporterFile{
+			Enabled: data.FileOutput.Enabled.ValueBool(),
+			Path:    data.FileOutput.Path.ValueString(),
+		}
+	}
+	if data.HTTPOutput != nil {
+		config.HTTP = &model.LogExporterHTTP{
+			Enabled:  data.HTTPOutput.Enabled.ValueBool(),
+			Endpoint: data.HTTPOutput.Endpoint.ValueString(),
+			Headers:  map[string]string{},
+		}
+		if !data.HTTPOutput.Headers.IsNull() {
+			diags.Append(data.HTTPOutput.Headers.ElementsAs(ctx, &config.HTTP.Headers, false)...)
+		}
+	}
+	if data.SyslogOutput != nil {
+		config.Syslog = &model.LogExporterSyslog{
+			Enabled:  data.SyslogOutput.Enabled.ValueBool(),
+			Address:  data.SyslogOutput.Address.ValueString(),
+			Port:     data.SyslogOutput.Port.ValueInt64(),
+			Protocol: data.SyslogOutput.Protocol.ValueString(),
+		}
+	}
+
+	return config
+}
+
+// partial config disabling the outputs managed in data, other settings untouched
+func disabledLogExporterOutputs(data tfLogExporter) map[string]any {
+	disabled := map[string]any{"enabled": false}
+	config := map[string]any{}
+
+	if data.FileOutput != nil {
+		config["file"] = disabled
+	}
+	if data.HTTPOutput != nil {
+		config["http"] = disabled
+	}
+	if data.SyslogOutput != nil {
+		config["syslog"] = disabled
+	}
+	return config
+}
+
+// refresh state from the app config: settings absent from state are only
+// filled in when all is set (import)
+func mergeLogExporterState(ctx context.Context, state tfLogExporter, config model.LogExporterConfig, all bool) tfLogExporter {
+	if config.MaxQueueSize != nil && (all || !state.MaxQueueSize.IsNull()) {
+		state.MaxQueueSize = types.Int64Value(*config.MaxQueueSize)
+	}
+
+	if config.File != nil && (all || state.FileOutput != nil) {
+		state.FileOutput = &tfLogExporterFile{
+			Enabled: types.BoolValue(config.File.Enabled),
+			Path:    types.StringValue(config.File.Path),
+		}
+	}
+
+	if config.HTTP != nil && (all || state.HTTPOutput != nil) {
+		headers := types.MapNull(types.StringType)
+		if len(config.HTTP.Headers) > 0 || (state.HTTPOutput != nil && !state.HTTPOutput.Headers.IsNull()) {
+			headers, _ = types.MapValueFrom(ctx, types.StringType, config.HTTP.Headers)
+		}
+		state.HTTPOutput = &tfLogExporterHTTP{
+			Enabled:  types.BoolValue(config.HTTP.Enabled),
+			Endpoint: types.StringValue(config.HTTP.Endpoint),
+			Headers:  headers,
+		}
+	}
+
+	if config.Syslog != nil && (all || state.SyslogOutput != nil) {
+		state.SyslogOutput = &tfLogExporterSyslog{
+			Enabled:  types.BoolValue(config.Syslog.Enabled),
+			Address:  types.StringValue(config.Syslog.Address),
+			Port:     types.Int64Value(config.Syslog.Port),
+			Protocol: types.StringValue(config.Syslog.Protocol),
+		}
+	}
+
+	return state
+}
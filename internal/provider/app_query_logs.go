@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &QueryLogsSqliteResource{}
+	_ resource.ResourceWithConfigure   = &QueryLogsSqliteResource{}
+	_ resource.ResourceWithImportState = &QueryLogsSqliteResource{}
+)
+
+type tfQueryLogsSqlite struct {
+	ID               types.String   `tfsdk:"id"`
+	EnableLogging    types.Bool     `tfsdk:"enable_logging"`
+	MaxQueueSize     types.Int64    `tfsdk:"max_queue_size"`
+	MaxLogDays       types.Int64    `tfsdk:"max_log_days"`
+	MaxLogRecords    types.Int64    `tfsdk:"max_log_records"`
+	UseInMemoryDb    types.Bool     `tfsdk:"use_in_memory_db"`
+	SqliteDbPath     types.String   `tfsdk:"sqlite_db_path"`
+	ConnectionString types.String   `tfsdk:"connection_string"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+// QueryLogsSqliteResource manages the settings of the Query Logs (Sqlite) DNS app
+type QueryLogsSqliteResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func QueryLogsSqliteResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &QueryLogsSqliteResource{reqMutex: m}
+	}
+}
+
+func (r *QueryLogsSqliteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_query_logs_sqlite"
+}
+
+func (r *QueryLogsSqliteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		MarkdownDescription: "Manages the settings of the Query Logs (Sqlite) DNS app, which must already be installed. " +
+			"Only the settings set here are managed. Destroying the resource leaves the app settings as they are.",
+		Attributes: map[string]rschema.Attribute{
+			"id": rschema.StringAttribute{
+				MarkdownDescription: "The name of the app.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enable_logging": rschema.BoolAttribute{
+				MarkdownDescription: "Whether queries are logged to the database.",
+				Optional:            true,
+			},
+			"max_queue_size": rschema.Int64Attribute{
+				MarkdownDescription: "Maximum number of log entries queued before new entries are dropped.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_log_days": rschema.Int64Attribute{
+				MarkdownDescription: "Number of days logs are kept for, `0` to keep them forever.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_log_records": rschema.Int64Attribute{
+				MarkdownDescription: "Maximum number of log entries kept, `0` for no limit.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"use_in_memory_db": rschema.BoolAttribute{
+				MarkdownDescription: "Whether logs are kept in an in-memory database, lost on restart.",
+				Optional:            true,
+			},
+			"sqlite_db_path": rschema.StringAttribute{
+				MarkdownDescription: "Path of the database file, relative paths are resolved in the app folder.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"connection_string": rschema.StringAttribute{
+				MarkdownDescription: "The Sqlite connection string, `{sqliteDbPath}` is replaced with `sqlite_db_path`.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]rschema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *QueryLogsSqliteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *QueryLogsSqliteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfQueryLogsSqlite
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	ctx = tflog.SetField(ctx, "app", model.APP_QUERY_LOGS_SQLITE)
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(writeAppConfig(ctx, r.client, model.APP_QUERY_LOGS_SQLITE, nil, tfQueryLogsSqlite2model(planData))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planData.ID = types.StringValue(model.APP_QUERY_LOGS_SQLITE)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *QueryLogsSqliteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateData tfQueryLogsSqlite
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "app", model.APP_QUERY_LOGS_SQLITE)
+	tflog.Info(ctx, "read: start")
+	defer tflog.Info(ctx, "read: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var config model.QueryLogsSqliteConfig
+	if err := readAppConfig(ctx, r.client, model.APP_QUERY_LOGS_SQLITE, &config); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading Query Logs (Sqlite) app config: %s", err))
+		return
+	}
+
+	// only the settings present in the state are tracked
+	stateData = mergeQueryLogsSqliteState(stateData, config, false)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func (r *QueryLogsSqliteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData tfQueryLogsSqlite
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "update")
+	ctx = tflog.SetField(ctx, "app", model.APP_QUERY_LOGS_SQLITE)
+	tflog.Info(ctx, "update: start")
+	defer tflog.Info(ctx, "update: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(writeAppConfig(ctx, r.client, model.APP_QUERY_LOGS_SQLITE, nil, tfQueryLogsSqlite2model(planData))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planData.ID = types.StringValue(model.APP_QUERY_LOGS_SQLITE)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+// settings are left on the server, there is nothing to delete
+func (r *QueryLogsSqliteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "delete: Query Logs (Sqlite) app settings are left as they are")
+}
+
+// terraform import technitium_app_query_logs_sqlite.example "Query Logs (Sqlite)"
+func (r *QueryLogsSqliteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = tflog.SetField(ctx, "operation", "import")
+	ctx = tflog.SetField(ctx, "app", model.APP_QUERY_LOGS_SQLITE)
+	tflog.Info(ctx, "import: start")
+	defer tflog.Info(ctx, "import: end")
+
+	if req.ID != model.APP_QUERY_LOGS_SQLITE {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected the app name '%s', got '%s'", model.APP_QUERY_LOGS_SQLITE, req.ID))
+		return
+	}
+
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
+
+	var config model.QueryLogsSqliteConfig
+	if err := readAppConfig(ctx, r.client, model.APP_QUERY_LOGS_SQLITE, &config); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading Query Logs (Sqlite) app config: %s", err))
+		return
+	}
+
+	stateData := mergeQueryLogsSqliteState(tfQueryLogsSqlite{
+		ID:       types.StringValue(model.APP_QUERY_LOGS_SQLITE),
+		Timeouts: nullTimeouts(),
+	}, config, true)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func tfQueryLogsSqlite2model(data tfQueryLogsSqlite) model.QueryLogsSqliteConfig {
+	return model.QueryLogsSqliteConfig{
+		EnableLogging:    data.EnableLogging.ValueBoolPointer(),
+		MaxQueueSize:     data.MaxQueueSize.ValueInt64Pointer(),
+		MaxLogDays:       data.MaxLogDays.ValueInt64Pointer(),
+		MaxLogRecords:    data.MaxLogRecords.ValueInt64Pointer(),
+		UseInMemoryDb:    data.UseInMemoryDb.ValueBoolPointer(),
+		SqliteDbPath:     data.SqliteDbPath.ValueStringPointer(),
+		ConnectionString: data.ConnectionString.ValueStringPointer(),
+	}
+}
+
+// refresh state from the app config: settings absent from state are only
+// filled in when all is set (import)
+func mergeQueryLogsSqliteState(state tfQueryLogsSqlite, config model.QueryLogsSqliteConfig, all bool) tfQueryLogsSqlite {
+	if config.EnableLogging != nil && (all || !state.EnableLogging.IsNull()) {
+		state.EnableLogging = types.BoolPointerValue(config.EnableLogging)
+	}
+	if config.MaxQueueSize != nil && (all || !state.MaxQueueSize.IsNull()) {
+		state.MaxQueueSize = types.Int64PointerValue(config.MaxQueueSize)
+	}
+	if config.MaxLogDays != nil && (all || !state.MaxLogDays.IsNull()) {
+		state.MaxLogDays = types.Int64PointerValue(config.MaxLogDays)
+	}
+	if config.MaxLogRecords != nil && (all || !state.MaxLogRecords.IsNull()) {
+		state.MaxLogRecords = types.Int64PointerValue(config.MaxLogRecords)
+	}
+	if config.UseInMemoryDb != nil && (all || !state.UseInMemoryDb.IsNull()) {
+		state.UseInMemoryDb = types.BoolPointerValue(config.UseInMemoryDb)
+	}
+	if config.SqliteDbPath != nil && (all || !state.SqliteDbPath.IsNull()) {
+		state.SqliteDbPath = types.StringPointerValue(config.SqliteDbPath)
+	}
+	if config.ConnectionString != nil && (all || !state.ConnectionString.IsNull()) {
+		state.ConnectionString = types.StringPointerValue(config.ConnectionString)
+	}
+	return state
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// APP record class paths with typed answers instead of raw record_data
+const (
+	CLASS_GEO_CONTINENT_ADDRESS = "GeoContinent.Address"
+	CLASS_GEO_CONTINENT_CNAME   = "GeoContinent.CNAME"
+	CLASS_GEO_COUNTRY_ADDRESS   = "GeoCountry.Address"
+	CLASS_GEO_COUNTRY_CNAME     = "GeoCountry.CNAME"
+	CLASS_SPLIT_HORIZON_ADDRESS = "SplitHorizon.SimpleAddress"
+	CLASS_SPLIT_HORIZON_CNAME   = "SplitHorizon.SimpleCNAME"
+)
+
+// answer used when no other key matches the client
+const APP_ANSWERS_DEFAULT = "default"
+
+var (
+	addressClassPaths = []string{CLASS_GEO_CONTINENT_ADDRESS, CLASS_GEO_COUNTRY_ADDRESS, CLASS_SPLIT_HORIZON_ADDRESS}
+	cnameClassPaths   = []string{CLASS_GEO_CONTINENT_CNAME, CLASS_GEO_COUNTRY_CNAME, CLASS_SPLIT_HORIZON_CNAME}
+	continentCodes    = []string{"AF", "AN", "AS", "EU", "NA", "OC", "SA"}
+	countryCode       = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// appRecordDataModifier plans record_data from app_answers or app_cname_answers
+type appRecordDataModifier struct{}
+
+func (m appRecordDataModifier) Description(ctx context.Context) string {
+	return "record_data is built from app_answers or app_cname_answers when not set"
+}
+
+func (m appRecordDataModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m appRecordDataModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var answers, cnameAnswers types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("app_answers"), &answers)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("app_cname_answers"), &cnameAnswers)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case answers.IsUnknown() || cnameAnswers.IsUnknown():
+		resp.PlanValue = types.StringUnknown()
+	case !answers.IsNull():
+		var networks map[string][]string
+		resp.Diagnostics.Append(answers.ElementsAs(ctx, &networks, false)...)
+		resp.PlanValue = appAnswersRecordData(networks, &resp.Diagnostics)
+	case !cnameAnswers.IsNull():
+		var networks map[string]string
+		resp.Diagnostics.Append(cnameAnswers.ElementsAs(ctx, &networks, false)...)
+		resp.PlanValue = appAnswersRecordData(networks, &resp.Diagnostics)
+	default:
+		resp.PlanValue = types.StringNull()
+	}
+}
+
+// JSON record data of typed answers, map keys are sorted so it is stable
+func appAnswersRecordData[T any](answers map[string]T, diags *diag.Diagnostics) types.String {
+	data, err := json.Marshal(answers)
+	if err != nil {
+		diags.AddError("Cannot encode record data", err.Error())
+		return types.StringUnknown()
+	}
+	return types.StringValue(string(data))
+}
+
+// the app must be installed and provide an APP record handler at the class path
+func checkAppRecordHandler(ctx context.Context, client model.DNSApiClient, appName string, classPath string, diags *diag.Diagnostics) {
+	apps, err := client.ListApps(ctx)
+	if err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Listing installed apps: query failed: %s", err))
+		return
+	}
+
+	var names []string
+	for _, app := range apps {
+		names = append(names, app.Name)
+		if app.Name != appName {
+			continue
+		}
+		var classPaths []string
+		for _, class := range app.DnsApps {
+			if class.IsAppRecordRequestHandler {
+				classPaths = append(classPaths, class.ClassPath)
+			}
+		}
+		if !slices.Contains(classPaths, classPath) {
+			diags.AddAttributeError(path.Root("class_path"), "Unknown class path",
+				fmt.Sprintf("App '%s' has no APP record handler '%s', available class paths: %s",
+					appName, classPath, strings.Join(classPaths, ", ")))
+		}
+		return
+	}
+	diags.AddAttributeError(path.Root("app_name"), "App not installed",
+		fmt.Sprintf("App '%s' is not installed on the server, installed apps: %s", appName, strings.Join(names, ", ")))
+}
+
+// typed answers must match the class path, and geo ones be keyed by valid codes
+func validateAppAnswers(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	var classPath types.String
+	var answers, cnameAnswers types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root("class_path"), &classPath)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("app_answers"), &answers)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("app_cname_answers"), &cnameAnswers)...)
+	if diags.HasError() || classPath.IsUnknown() {
+		return
+	}
+
+	check := func(attr string, answers types.Map, classPaths []string) {
+		if answers.IsNull() {
+			return
+		}
+		if !slices.Contains(classPaths, classPath.ValueString()) {
+			diags.AddAttributeError(path.Root(attr), "Unsupported class path",
+				fmt.Sprintf("%s requires class_path to be one of %s", attr, strings.Join(classPaths, ", ")))
+			return
+		}
+		if answers.IsUnknown() {
+			return
+		}
+		for key := range answers.Elements() {
+			if err := checkAppAnswersKey(classPath.ValueString(), key); err != nil {
+				diags.AddAttributeError(path.Root(attr).AtMapKey(key), "Invalid answers key", err.Error())
+			}
+		}
+	}
+	check("app_answers", answers, addressClassPaths)
+	check("app_cname_answers", cnameAnswers, cnameClassPaths)
+}
+
+// geo apps are keyed by continent or ISO country code, split horizon by
+// network names or addresses the app resolves itself
+func checkAppAnswersKey(classPath string, key string) error {
+	if key == APP_ANSWERS_DEFAULT {
+		return nil
+	}
+	switch {
+	case strings.HasPrefix(classPath, "GeoContinent."):
+		if !slices.Contains(continentCodes, key) {
+			return fmt.Errorf("'%s' is not one of the continent codes %s or '%s'",
+				key, strings.Join(continentCodes, ", "), APP_ANSWERS_DEFAULT)
+		}
+	case strings.HasPrefix(classPath, "GeoCountry."):
+		if !countryCode.MatchString(key) {
+			return fmt.Errorf("'%s' is not an uppercase ISO 3166 country code or '%s'", key, APP_ANSWERS_DEFAULT)
+		}
+	}
+	return nil
+}
+
+// refresh typed answers tracked in state from the server record data
+func refreshAppAnswers(ctx context.Context, tfRec *tfDNSRecord) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data := []byte(tfRec.RecordData.ValueString())
+
+	if !tfRec.AppAnswers.IsNull() {
+		var answers map[string][]string
+		if json.Unmarshal(data, &answers) == nil {
+			tfRec.AppAnswers, diags = types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, answers)
+		}
+	}
+	if !tfRec.AppCnameAnswers.IsNull() {
+		var answers map[string]string
+		if json.Unmarshal(data, &answers) == nil {
+			tfRec.AppCnameAnswers, diags = types.MapValueFrom(ctx, types.StringType, answers)
+		}
+	}
+	return diags
+}
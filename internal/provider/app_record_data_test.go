@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckAppRecordHandler(t *testing.T) {
+	t.Parallel()
+	apps := []model.DNSApp{{
+		Name: "Geo Country",
+		DnsApps: []model.DNSAppClass{
+			{ClassPath: "GeoCountry.App", IsAppRecordRequestHandler: true},
+			{ClassPath: "GeoCountry.Logger"},
+		},
+	}}
+	tests := []struct {
+		name      string
+		appName   string
+		classPath string
+		listErr   error
+		wantError string
+	}{
+		{name: "record handler", appName: "Geo Country", classPath: "GeoCountry.App"},
+		{name: "not a record handler", appName: "Geo Country", classPath: "GeoCountry.Logger", wantError: "Unknown class path"},
+		{name: "app not installed", appName: "Split Horizon", classPath: "SplitHorizon.SimpleAddress", wantError: "App not installed"},
+		{name: "list failure", appName: "Geo Country", classPath: "GeoCountry.App", listErr: errors.New("timeout"), wantError: "Client Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := model.NewMockDNSApiClient(t)
+			client.EXPECT().ListApps(mock.Anything).Return(apps, tt.listErr).Once()
+
+			var diags diag.Diagnostics
+			checkAppRecordHandler(context.Background(), client, tt.appName, tt.classPath, &diags)
+
+			switch {
+			case tt.wantError == "" && diags.HasError():
+				t.Errorf("got unexpected errors: %v", diags.Errors())
+			case tt.wantError != "" && (len(diags.Errors()) != 1 || diags.Errors()[0].Summary() != tt.wantError):
+				t.Errorf("got errors %v, want one %q", diags.Errors(), tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// audit_log_file mode: successful changes are appended to a local JSON lines
+// file, reads pass through.
+type auditedClient struct {
+	model.DNSApiClient
+	log *auditLog
+}
+
+var _ model.DNSApiClient = auditedClient{}
+
+// replaces sensitive values in the audit file
+const SENSITIVE_PLACEHOLDER = "(sensitive)"
+
+// one line of the audit file
+type auditEntry struct {
+	Time      string      `json:"time"`
+	Operator  string      `json:"operator"`
+	Workspace string      `json:"workspace"`
+	Operation string      `json:"operation"`
+	Zone      string      `json:"zone,omitempty"`
+	Record    string      `json:"record,omitempty"` // domain and type
+	Before    interface{} `json:"before,omitempty"`
+	After     interface{} `json:"after,omitempty"`
+}
+
+type auditLog struct {
+	mu        sync.Mutex
+	path      string
+	operator  string
+	workspace string
+}
+
+func newAuditLog(path string) *auditLog {
+	operator := os.Getenv("USER")
+	if current, err := user.Current(); err == nil {
+		operator = current.Username
+	}
+	return &auditLog{path: path, operator: operator, workspace: workspaceName()}
+}
+
+// the change is made at this point: failing to record it is only logged,
+// reporting an error would have terraform consider it was not
+func (l *auditLog) write(ctx context.Context, entry auditEntry) {
+	entry.Time = time.Now().UTC().Format(time.RFC3339)
+	entry.Operator = l.operator
+	entry.Workspace = l.workspace
+	line, err := json.Marshal(entry)
+	if err != nil {
+		tflog.Warn(ctx, "cannot encode audit log entry: "+err.Error())
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err == nil {
+		_, err = file.Write(append(line, '\n'))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		tflog.Warn(ctx, "cannot write audit log "+l.path+": "+err.Error())
+	}
+}
+
+// audited copies of records and zones, without their secrets
+func auditedRecord(record model.DNSRecord) model.DNSRecord {
+	if record.ProxyPassword != "" {
+		record.ProxyPassword = SENSITIVE_PLACEHOLDER
+	}
+	return record
+}
+
+func auditedZone(zone model.DNSZone) model.DNSZone {
+	if zone.ProxyPassword != "" {
+		zone.ProxyPassword = SENSITIVE_PLACEHOLDER
+	}
+	return zone
+}
+
+func recordName(record model.DNSRecord) string {
+	return string(record.Domain) + " " + string(record.Type)
+}
+
+func (c auditedClient) AddRecord(ctx context.Context, record model.DNSRecord) error {
+	err := c.DNSApiClient.AddRecord(ctx, record)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "add record", Zone: record.Zone, Record: recordName(record), After: auditedRecord(record)})
+	}
+	return err
+}
+
+func (c auditedClient) UpdateRecord(ctx context.Context, oldRecord model.DNSRecord, newRecord model.DNSRecord) error {
+	err := c.DNSApiClient.UpdateRecord(ctx, oldRecord, newRecord)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "update record", Zone: oldRecord.Zone, Record: recordName(oldRecord),
+			Before: auditedRecord(oldRecord), After: auditedRecord(newRecord)})
+	}
+	return err
+}
+
+func (c auditedClient) DeleteRecord(ctx context.Context, record model.DNSRecord) error {
+	err := c.DNSApiClient.DeleteRecord(ctx, record)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "delete record", Zone: record.Zone, Record: recordName(record), Before: auditedRecord(record)})
+	}
+	return err
+}
+
+func (c auditedClient) CreateZone(ctx context.Context, zone model.DNSZone) error {
+	err := c.DNSApiClient.CreateZone(ctx, zone)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "create zone", Zone: zone.Name, After: auditedZone(zone)})
+	}
+	return err
+}
+
+func (c auditedClient) DeleteZone(ctx context.Context, zoneName string) error {
+	err := c.DNSApiClient.DeleteZone(ctx, zoneName)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "delete zone", Zone: zoneName})
+	}
+	return err
+}
+
+func (c auditedClient) ResyncZone(ctx context.Context, zoneName string) error {
+	err := c.DNSApiClient.ResyncZone(ctx, zoneName)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "resync zone", Zone: zoneName})
+	}
+	return err
+}
+
+func (c auditedClient) SetZoneOptions(ctx context.Context, zoneName string, options model.DNSZoneOptionsUpdate) error {
+	err := c.DNSApiClient.SetZoneOptions(ctx, zoneName, options)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "set zone options", Zone: zoneName, After: options})
+	}
+	return err
+}
+
+func (c auditedClient) SignZone(ctx context.Context, zoneName string, signing model.DNSZoneSigning) error {
+	err := c.DNSApiClient.SignZone(ctx, zoneName, signing)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "sign zone", Zone: zoneName, After: signing})
+	}
+	return err
+}
+
+func (c auditedClient) UnsignZone(ctx context.Context, zoneName string) error {
+	err := c.DNSApiClient.UnsignZone(ctx, zoneName)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "unsign zone", Zone: zoneName})
+	}
+	return err
+}
+
+func (c auditedClient) UpdateDnsKeyTtl(ctx context.Context, zoneName string, ttl int64) error {
+	err := c.DNSApiClient.UpdateDnsKeyTtl(ctx, zoneName, ttl)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "update DNSKEY TTL", Zone: zoneName, After: ttl})
+	}
+	return err
+}
+
+func (c auditedClient) UpdateDnssecKeyRollover(ctx context.Context, zoneName string, keyType string, rolloverDays int64) error {
+	err := c.DNSApiClient.UpdateDnssecKeyRollover(ctx, zoneName, keyType, rolloverDays)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "update DNSSEC key rollover", Zone: zoneName,
+			After: map[string]interface{}{"keyType": keyType, "rolloverDays": rolloverDays}})
+	}
+	return err
+}
+
+func (c auditedClient) RolloverDnssecAlgorithm(ctx context.Context, zoneName string, signing model.DNSZoneSigning) error {
+	err := c.DNSApiClient.RolloverDnssecAlgorithm(ctx, zoneName, signing)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "roll over DNSSEC algorithm", Zone: zoneName, After: signing})
+	}
+	return err
+}
+
+func (c auditedClient) UpdateDnssecNxProof(ctx context.Context, zoneName string, nxProof string, iterations int64, saltLength int64) error {
+	err := c.DNSApiClient.UpdateDnssecNxProof(ctx, zoneName, nxProof, iterations, saltLength)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "update DNSSEC proof of non-existence", Zone: zoneName,
+			After: map[string]interface{}{"nxProof": nxProof, "iterations": iterations, "saltLength": saltLength}})
+	}
+	return err
+}
+
+func (c auditedClient) RemoveDhcpLease(ctx context.Context, scopeName string, clientIdentifier string) error {
+	err := c.DNSApiClient.RemoveDhcpLease(ctx, scopeName, clientIdentifier)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "remove DHCP lease",
+			Before: map[string]interface{}{"scope": scopeName, "clientIdentifier": clientIdentifier}})
+	}
+	return err
+}
+
+func (c auditedClient) ForceUpdateBlockLists(ctx context.Context) error {
+	err := c.DNSApiClient.ForceUpdateBlockLists(ctx)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "force update block lists"})
+	}
+	return err
+}
+
+func (c auditedClient) SetSettings(ctx context.Context, settings model.DNSSettingsUpdate) error {
+	err := c.DNSApiClient.SetSettings(ctx, settings)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "set settings", After: settings})
+	}
+	return err
+}
+
+// app configs may hold credentials, only the app is recorded
+func (c auditedClient) SetAppConfig(ctx context.Context, appName string, config string) error {
+	err := c.DNSApiClient.SetAppConfig(ctx, appName, config)
+	if err == nil {
+		c.log.write(ctx, auditEntry{Operation: "set app config", After: map[string]interface{}{"app": appName}})
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestAuditedClient(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	c := auditedClient{DNSApiClient: model.NewFakeDNSApiClient(), log: newAuditLog(path)}
+
+	if err := c.CreateZone(ctx, model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY}); err != nil {
+		t.Fatal(err)
+	}
+	record := model.DNSRecord{Type: model.REC_FWD, Domain: "fwd.example.com", Forwarder: "192.0.2.53", ProxyPassword: "secret"}
+	if err := c.AddRecord(ctx, record); err != nil {
+		t.Fatal(err)
+	}
+	// failed changes are not recorded
+	if err := c.DeleteZone(ctx, "example.org"); err == nil {
+		t.Fatal("deleting a missing zone succeeded")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid audit line %q: %s", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("got %d audit entries, want 2: %v", len(entries), entries)
+	}
+	if entries[0]["operation"] != "create zone" || entries[0]["zone"] != "example.com" {
+		t.Errorf("first entry = %v, want zone creation", entries[0])
+	}
+	added, _ := entries[1]["after"].(map[string]interface{})
+	if entries[1]["record"] != "fwd.example.com FWD" || added["ProxyPassword"] != SENSITIVE_PLACEHOLDER {
+		t.Errorf("second entry = %v, want record addition without the proxy password", entries[1])
+	}
+}
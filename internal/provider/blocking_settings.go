@@ -0,0 +1,355 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &BlockingSettingsResource{}
+	_ resource.ResourceWithConfigure   = &BlockingSettingsResource{}
+	_ resource.ResourceWithImportState = &BlockingSettingsResource{}
+)
+
+// ID of the blocking settings, there is one set per server
+const BLOCKING_SETTINGS_ID = "blocking"
+
+// prefix of the allow list URLs among the block list URLs of the server
+const ALLOW_LIST_URL_PREFIX = "!"
+
+type tfBlockingSettings struct {
+	ID                           types.String   `tfsdk:"id"`
+	BlockListUrls                types.Set      `tfsdk:"block_list_urls"`
+	AllowListUrls                types.Set      `tfsdk:"allow_list_urls"`
+	BlockListUpdateIntervalHours types.Int64    `tfsdk:"block_list_update_interval_hours"`
+	BlockListNextUpdate          types.String   `tfsdk:"block_list_next_update"`
+	Timeouts                     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// BlockingSettingsResource manages the blocking settings of the DNS server
+type BlockingSettingsResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func BlockingSettingsResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &BlockingSettingsResource{reqMutex: m}
+	}
+}
+
+func (r *BlockingSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blocking_settings"
+}
+
+func (r *BlockingSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		MarkdownDescription: "Manages the blocking settings of the DNS server. There is a single set of settings per server: " +
+			"settings not configured are left as they are, and destroying the resource leaves the server settings unchanged.",
+		Attributes: map[string]rschema.Attribute{
+			"id": rschema.StringAttribute{
+				MarkdownDescription: "Always `" + BLOCKING_SETTINGS_ID + "`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"block_list_urls": rschema.SetAttribute{
+				MarkdownDescription: "URLs of the block lists the server downloads, of domains to block. " +
+					"The server downloads the lists when they change, use `technitium_block_lists_update` to download them again later.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(httpURLValidator{}),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"allow_list_urls": rschema.SetAttribute{
+				MarkdownDescription: "URLs of the allow lists the server downloads along with the block lists, of domains never to block. " +
+					"They are updated with the block lists.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(httpURLValidator{}),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"block_list_update_interval_hours": rschema.Int64Attribute{
+				MarkdownDescription: "Hours between automatic updates of the block lists, from 1 to 168.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 168),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"block_list_next_update": rschema.StringAttribute{
+				MarkdownDescription: "When the block lists are next updated automatically, null without block lists.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]rschema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *BlockingSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BlockingSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfBlockingSettings
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	planData = r.apply(ctx, planData, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *BlockingSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateData tfBlockingSettings
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	tflog.Info(ctx, "read: start")
+	defer tflog.Info(ctx, "read: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	settings, err := r.client.GetSettings(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading server settings: %s", err))
+		return
+	}
+
+	stateData = blockingSettings2tf(stateData, settings)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func (r *BlockingSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData tfBlockingSettings
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "update")
+	tflog.Info(ctx, "update: start")
+	defer tflog.Info(ctx, "update: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	planData = r.apply(ctx, planData, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+// the settings stay on the server
+func (r *BlockingSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "delete: nothing to do, blocking settings are left as they are")
+}
+
+// terraform import technitium_blocking_settings.example blocking
+func (r *BlockingSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = tflog.SetField(ctx, "operation", "import")
+	tflog.Info(ctx, "import: start")
+	defer tflog.Info(ctx, "import: end")
+
+	if req.ID != BLOCKING_SETTINGS_ID {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected '%s', got '%s'", BLOCKING_SETTINGS_ID, req.ID))
+		return
+	}
+
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
+
+	settings, err := r.client.GetSettings(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading server settings: %s", err))
+		return
+	}
+
+	stateData := blockingSettings2tf(tfBlockingSettings{Timeouts: nullTimeouts()}, settings)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+// save the planned settings and read them back for the computed ones
+func (r *BlockingSettingsResource) apply(ctx context.Context, plan tfBlockingSettings, diags *diag.Diagnostics) tfBlockingSettings {
+	update := model.DNSSettingsUpdate{}
+	if !plan.BlockListUpdateIntervalHours.IsUnknown() {
+		update.BlockListUpdateIntervalHours = plan.BlockListUpdateIntervalHours.ValueInt64Pointer()
+	}
+	// both kinds of lists are saved together, the one not configured is kept
+	if !plan.BlockListUrls.IsUnknown() || !plan.AllowListUrls.IsUnknown() {
+		current, err := r.client.GetSettings(ctx)
+		if err != nil {
+			diags.AddError("Client Error",
+				fmt.Sprintf("Reading server settings: %s", err))
+			return plan
+		}
+		blockListUrls, allowListUrls := splitBlockListUrls(current.BlockListUrls)
+		if !plan.BlockListUrls.IsUnknown() {
+			blockListUrls = stringSetModel(plan.BlockListUrls)
+		}
+		if !plan.AllowListUrls.IsUnknown() {
+			allowListUrls = stringSetModel(plan.AllowListUrls)
+		}
+		update.BlockListUrls = joinBlockListUrls(blockListUrls, allowListUrls)
+	}
+	if err := r.client.SetSettings(ctx, update); err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Saving server settings: %s", err))
+		return plan
+	}
+
+	settings, err := r.client.GetSettings(ctx)
+	if err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Reading server settings: %s", err))
+		return plan
+	}
+	return blockingSettings2tf(plan, settings)
+}
+
+func blockingSettings2tf(prior tfBlockingSettings, settings model.DNSSettings) tfBlockingSettings {
+	result := prior
+	result.ID = types.StringValue(BLOCKING_SETTINGS_ID)
+	blockListUrls, allowListUrls := splitBlockListUrls(settings.BlockListUrls)
+	result.BlockListUrls = stringSetValue(blockListUrls)
+	result.AllowListUrls = stringSetValue(allowListUrls)
+	result.BlockListUpdateIntervalHours = types.Int64Value(settings.BlockListUpdateIntervalHours)
+	result.BlockListNextUpdate = types.StringNull()
+	if settings.BlockListNextUpdatedOn != "" {
+		result.BlockListNextUpdate = types.StringValue(settings.BlockListNextUpdatedOn)
+	}
+	return result
+}
+
+// block list and allow list URLs of the server list
+func splitBlockListUrls(urls []string) ([]string, []string) {
+	blockListUrls, allowListUrls := []string{}, []string{}
+	for _, url := range urls {
+		if allowListUrl, ok := strings.CutPrefix(url, ALLOW_LIST_URL_PREFIX); ok {
+			allowListUrls = append(allowListUrls, allowListUrl)
+		} else {
+			blockListUrls = append(blockListUrls, url)
+		}
+	}
+	return blockListUrls, allowListUrls
+}
+
+// server list of block list and allow list URLs, never nil
+func joinBlockListUrls(blockListUrls []string, allowListUrls []string) []string {
+	urls := append([]string{}, blockListUrls...)
+	for _, url := range allowListUrls {
+		urls = append(urls, ALLOW_LIST_URL_PREFIX+url)
+	}
+	return urls
+}
+
+func stringSetValue(values []string) types.Set {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// values of a configured set, empty if it is not set
+func stringSetModel(set types.Set) []string {
+	values := []string{}
+	for _, element := range set.Elements() {
+		if value, ok := element.(types.String); ok {
+			values = append(values, value.ValueString())
+		}
+	}
+	return values
+}
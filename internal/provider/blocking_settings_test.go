@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestBlockingSettingsApply(t *testing.T) {
+	t.Parallel()
+	client := model.NewFakeDNSApiClient()
+	r := &BlockingSettingsResource{client: client}
+	unknownUrls := types.SetUnknown(types.StringType)
+
+	var diags diag.Diagnostics
+	got := r.apply(context.Background(), tfBlockingSettings{
+		BlockListUrls:                stringSetValue([]string{"https://example.com/block.txt"}),
+		AllowListUrls:                stringSetValue([]string{"https://example.com/allow.txt"}),
+		BlockListUpdateIntervalHours: types.Int64Value(12),
+	}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got.ID.ValueString() != BLOCKING_SETTINGS_ID || got.BlockListUpdateIntervalHours.ValueInt64() != 12 {
+		t.Errorf("settings = %+v", got)
+	}
+	settings, _ := client.GetSettings(context.Background())
+	if want := []string{"https://example.com/block.txt", "!https://example.com/allow.txt"}; !slices.Equal(settings.BlockListUrls, want) {
+		t.Errorf("server block list URLs = %v, want %v", settings.BlockListUrls, want)
+	}
+
+	// not configured: left as is
+	got = r.apply(context.Background(), tfBlockingSettings{
+		BlockListUrls:                unknownUrls,
+		AllowListUrls:                stringSetValue(nil),
+		BlockListUpdateIntervalHours: types.Int64Unknown(),
+	}, &diags)
+	if got.BlockListUpdateIntervalHours.ValueInt64() != 12 {
+		t.Errorf("block_list_update_interval_hours = %s, want 12", got.BlockListUpdateIntervalHours)
+	}
+	if !got.BlockListUrls.Equal(stringSetValue([]string{"https://example.com/block.txt"})) {
+		t.Errorf("block_list_urls = %s, want the block list kept", got.BlockListUrls)
+	}
+	if len(got.AllowListUrls.Elements()) != 0 {
+		t.Errorf("allow_list_urls = %s, want it cleared", got.AllowListUrls)
+	}
+}
+
+func TestSplitBlockListUrls(t *testing.T) {
+	t.Parallel()
+	blockListUrls, allowListUrls := splitBlockListUrls([]string{"https://a.example/list", "!https://b.example/list"})
+	if !slices.Equal(blockListUrls, []string{"https://a.example/list"}) || !slices.Equal(allowListUrls, []string{"https://b.example/list"}) {
+		t.Errorf("splitBlockListUrls = %v, %v", blockListUrls, allowListUrls)
+	}
+	if got := joinBlockListUrls(nil, nil); got == nil || len(got) != 0 {
+		t.Errorf("joinBlockListUrls(nil, nil) = %#v, want an empty list", got)
+	}
+}
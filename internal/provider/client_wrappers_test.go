@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// methods of model.DNSApiWriter, to call on each client wrapper
+func writeMethods() []reflect.Method {
+	writer := reflect.TypeOf((*model.DNSApiWriter)(nil)).Elem()
+	methods := make([]reflect.Method, 0, writer.NumMethod())
+	for i := 0; i < writer.NumMethod(); i++ {
+		methods = append(methods, writer.Method(i))
+	}
+	return methods
+}
+
+// call a write method with zero arguments, reporting whether it reached a
+// mock without expectations, which panics
+func callWrite(client model.DNSApiClient, method reflect.Method) (err error, panicked bool) {
+	defer func() {
+		if recover() != nil {
+			panicked = true
+		}
+	}()
+	args := make([]reflect.Value, method.Type.NumIn())
+	for i := range args {
+		args[i] = reflect.Zero(method.Type.In(i))
+	}
+	args[0] = reflect.ValueOf(context.Background())
+	result := reflect.ValueOf(client).MethodByName(method.Name).Call(args)
+	err, _ = result[0].Interface().(error)
+	return err, false
+}
+
+// mock accepting every write
+func writableMock() *model.MockDNSApiClient {
+	client := &model.MockDNSApiClient{}
+	for _, method := range writeMethods() {
+		args := make([]interface{}, method.Type.NumIn())
+		for i := range args {
+			args[i] = mock.Anything
+		}
+		client.On(method.Name, args...).Return(nil)
+	}
+	return client
+}
+
+// the client wrappers embed model.DNSApiClient, so a write method they do not
+// override silently passes through to the wrapped client
+func TestClientWrappersOverrideWrites(t *testing.T) {
+	t.Parallel()
+
+	t.Run("read only", func(t *testing.T) {
+		t.Parallel()
+		c := readOnlyClient{DNSApiClient: &model.MockDNSApiClient{}}
+		for _, method := range writeMethods() {
+			if err, panicked := callWrite(c, method); panicked || err == nil {
+				t.Errorf("readOnlyClient.%s reaches the server", method.Name)
+			}
+		}
+	})
+
+	t.Run("replicated", func(t *testing.T) {
+		t.Parallel()
+		replicaClient := writableMock()
+		c := replicatedClient{DNSApiClient: writableMock(), replicas: []replica{{url: "https://replica.example.com", client: replicaClient}}}
+		for _, method := range writeMethods() {
+			if err, panicked := callWrite(c, method); panicked || err != nil {
+				t.Fatalf("replicatedClient.%s = %v", method.Name, err)
+			}
+			if !replicaClient.AssertNumberOfCalls(t, method.Name, 1) {
+				t.Errorf("replicatedClient.%s is not applied to the replicas", method.Name)
+			}
+		}
+	})
+
+	t.Run("audited", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		c := auditedClient{DNSApiClient: writableMock(), log: newAuditLog(path)}
+		for _, method := range writeMethods() {
+			if err, panicked := callWrite(c, method); panicked || err != nil {
+				t.Fatalf("auditedClient.%s = %v", method.Name, err)
+			}
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+		lines := 0
+		for scanner := bufio.NewScanner(file); scanner.Scan(); {
+			lines++
+		}
+		if want := len(writeMethods()); lines != want {
+			t.Errorf("got %d audit entries, want one per write method: %d", lines, want)
+		}
+	})
+}
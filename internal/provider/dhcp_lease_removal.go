@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource              = &DhcpLeaseRemovalResource{}
+	_ resource.ResourceWithConfigure = &DhcpLeaseRemovalResource{}
+)
+
+// DHCP client identifier as shown by the server, hardware type then address
+// bytes, e.g. 1-DD-C5-88-AE-60-08
+var dhcpClientIdentifierRegexp = regexp.MustCompile(`^[0-9A-Fa-f]{1,2}(-[0-9A-Fa-f]{2})+$`)
+
+type tfDhcpLeaseRemoval struct {
+	ID               types.String   `tfsdk:"id"`
+	Scope            types.String   `tfsdk:"scope"`
+	ClientIdentifier types.String   `tfsdk:"client_identifier"`
+	Triggers         types.Map      `tfsdk:"triggers"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+// DhcpLeaseRemovalResource removes a DHCP lease when created
+type DhcpLeaseRemovalResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func DhcpLeaseRemovalResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &DhcpLeaseRemovalResource{reqMutex: m}
+	}
+}
+
+func (r *DhcpLeaseRemovalResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dhcp_lease_removal"
+}
+
+func (r *DhcpLeaseRemovalResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		MarkdownDescription: "Removes the lease of a client from a DHCP scope, e.g. to purge a stale or conflicting dynamic lease. " +
+			"The lease is removed when the resource is created, again when `scope`, `client_identifier` or `triggers` change. " +
+			"Destroying the resource does nothing.",
+		Attributes: map[string]rschema.Attribute{
+			"id": rschema.StringAttribute{
+				MarkdownDescription: "The scope and client identifier, separated by `:`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope": rschema.StringAttribute{
+				MarkdownDescription: "The name of the DHCP scope.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"client_identifier": rschema.StringAttribute{
+				MarkdownDescription: "The client identifier of the lease, as listed by the server, e.g. `1-DD-C5-88-AE-60-08`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(dhcpClientIdentifierRegexp, "must be hex bytes separated by -, e.g. 1-DD-C5-88-AE-60-08"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": rschema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that remove the lease again when changed.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]rschema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *DhcpLeaseRemovalResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DhcpLeaseRemovalResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfDhcpLeaseRemoval
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	ctx = tflog.SetField(ctx, "scope", planData.Scope.ValueString())
+	ctx = tflog.SetField(ctx, "client_identifier", planData.ClientIdentifier.ValueString())
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.client.RemoveDhcpLease(ctx, planData.Scope.ValueString(), planData.ClientIdentifier.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to remove DHCP lease: %s", err))
+		return
+	}
+
+	planData.ID = types.StringValue(planData.Scope.ValueString() + IMPORT_SEP + planData.ClientIdentifier.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+// nothing to refresh, the lease is gone or is a new one
+func (r *DhcpLeaseRemovalResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// every attribute requires replacement
+func (r *DhcpLeaseRemovalResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData tfDhcpLeaseRemoval
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *DhcpLeaseRemovalResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "delete: nothing to do, DHCP lease removal is not reverted")
+}
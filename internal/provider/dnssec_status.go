@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &DnssecStatusDataSource{}
+)
+
+type tfDnssecStatus struct {
+	ZoneNames []types.String       `tfsdk:"zone_names"`
+	Zones     []tfDnssecStatusZone `tfsdk:"zones"`
+}
+
+type tfDnssecStatusZone struct {
+	Name         types.String        `tfsdk:"name"`
+	Type         types.String        `tfsdk:"type"`
+	DNSSecStatus types.String        `tfsdk:"dnssec_status"`
+	Keys         []tfDnssecStatusKey `tfsdk:"keys"`
+}
+
+type tfDnssecStatusKey struct {
+	KeyTag         types.Int64  `tfsdk:"key_tag"`
+	KeyType        types.String `tfsdk:"key_type"`
+	Algorithm      types.String `tfsdk:"algorithm"`
+	State          types.String `tfsdk:"state"`
+	StateChangedOn types.String `tfsdk:"state_changed_on"`
+	StateReadyBy   types.String `tfsdk:"state_ready_by"`
+	IsRetiring     types.Bool   `tfsdk:"is_retiring"`
+	RolloverDays   types.Int64  `tfsdk:"rollover_days"`
+	NextRollover   types.String `tfsdk:"next_rollover"`
+}
+
+// DnssecStatusDataSource reports the DNSSEC signing status and keys of zones
+type DnssecStatusDataSource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func DnssecStatusDataSourceFactory(m *sync.Mutex) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &DnssecStatusDataSource{reqMutex: m}
+	}
+}
+
+func (d *DnssecStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dnssec_status"
+}
+
+func (d *DnssecStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the DNSSEC signing status of zones of Technitium DNS Server, with the state and next rollover of their keys.",
+		Attributes: map[string]schema.Attribute{
+			"zone_names": schema.ListAttribute{
+				MarkdownDescription: "Zones to report on. All non-internal zones when not set.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"zones": schema.ListNestedAttribute{
+				MarkdownDescription: "DNSSEC status of the zones.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The zone name.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The zone type.",
+							Computed:            true,
+						},
+						"dnssec_status": schema.StringAttribute{
+							MarkdownDescription: "`Unsigned`, `SignedWithNSEC` or `SignedWithNSEC3`.",
+							Computed:            true,
+						},
+						"keys": schema.ListNestedAttribute{
+							MarkdownDescription: "The private keys of signed primary zones.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"key_tag": schema.Int64Attribute{
+										MarkdownDescription: "The key tag.",
+										Computed:            true,
+									},
+									"key_type": schema.StringAttribute{
+										MarkdownDescription: "`KeySigningKey` or `ZoneSigningKey`.",
+										Computed:            true,
+									},
+									"algorithm": schema.StringAttribute{
+										MarkdownDescription: "The DNSSEC algorithm, e.g. `ECDSAP256SHA256`.",
+										Computed:            true,
+									},
+									"state": schema.StringAttribute{
+										MarkdownDescription: "The key state, e.g. `Published`, `Ready`, `Active`, `Retired`.",
+										Computed:            true,
+									},
+									"state_changed_on": schema.StringAttribute{
+										MarkdownDescription: "When the key entered its state.",
+										Computed:            true,
+									},
+									"state_ready_by": schema.StringAttribute{
+										MarkdownDescription: "When a published key becomes ready, if known.",
+										Computed:            true,
+									},
+									"is_retiring": schema.BoolAttribute{
+										MarkdownDescription: "Whether the key is being retired.",
+										Computed:            true,
+									},
+									"rollover_days": schema.Int64Attribute{
+										MarkdownDescription: "Days after which the key is rolled over automatically, `0` if never.",
+										Computed:            true,
+									},
+									"next_rollover": schema.StringAttribute{
+										MarkdownDescription: "RFC 3339 date of the next automatic rollover of active keys, null when not scheduled.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DnssecStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DnssecStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config tfDnssecStatus
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	tflog.Info(ctx, "read DNSSEC status: start")
+	defer tflog.Info(ctx, "read DNSSEC status: end")
+	d.reqMutex.Lock()
+	defer d.reqMutex.Unlock()
+
+	zones, err := d.client.ListZones(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
+		return
+	}
+
+	var names []string
+	if config.ZoneNames != nil {
+		names = tfStrings2model(config.ZoneNames)
+		for _, name := range names {
+			if !slices.ContainsFunc(zones, func(zone model.DNSZone) bool { return zone.Name == name }) {
+				resp.Diagnostics.AddAttributeError(path.Root("zone_names"), "Zone not found",
+					fmt.Sprintf("Zone '%s' does not exist", name))
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	config.Zones = []tfDnssecStatusZone{}
+	for _, zone := range zones {
+		if names != nil && !slices.Contains(names, zone.Name) || names == nil && zone.Internal {
+			continue
+		}
+		status := tfDnssecStatusZone{
+			Name:         types.StringValue(zone.Name),
+			Type:         types.StringValue(string(zone.Type)),
+			DNSSecStatus: types.StringValue(zone.DNSSecStatus),
+			Keys:         []tfDnssecStatusKey{},
+		}
+		// only primary zones hold the private keys
+		if zone.Type == model.ZONE_PRIMARY && zone.DNSSecStatus != "" && zone.DNSSecStatus != model.DNSSEC_STATUS_UNSIGNED {
+			properties, err := d.client.GetDnssecProperties(ctx, zone.Name)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error",
+					fmt.Sprintf("Reading DNSSEC properties of zone %s: %s", zone.Name, err))
+				return
+			}
+			for _, key := range properties.DnssecPrivateKeys {
+				stateReadyBy := types.StringNull()
+				if key.StateReadyBy != "" {
+					stateReadyBy = types.StringValue(key.StateReadyBy)
+				}
+				status.Keys = append(status.Keys, tfDnssecStatusKey{
+					KeyTag:         types.Int64Value(key.KeyTag),
+					KeyType:        types.StringValue(key.KeyType),
+					Algorithm:      types.StringValue(key.Algorithm),
+					State:          types.StringValue(key.State),
+					StateChangedOn: types.StringValue(key.StateChangedOn),
+					StateReadyBy:   stateReadyBy,
+					IsRetiring:     types.BoolValue(key.IsRetiring),
+					RolloverDays:   types.Int64Value(key.RolloverDays),
+					NextRollover:   nextDnssecRollover(key),
+				})
+			}
+		}
+		config.Zones = append(config.Zones, status)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// date of the next automatic rollover of an active key, counted from its
+// activation
+func nextDnssecRollover(key model.DNSSecPrivateKey) types.String {
+	if key.State != model.DNSSEC_KEY_STATE_ACTIVE || key.IsRetiring || key.RolloverDays <= 0 {
+		return types.StringNull()
+	}
+	activatedOn, err := time.Parse(time.RFC3339Nano, key.StateChangedOn)
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(activatedOn.AddDate(0, 0, int(key.RolloverDays)).UTC().Format(time.RFC3339))
+}
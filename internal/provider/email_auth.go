@@ -0,0 +1,750 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &EmailAuthResource{}
+	_ resource.ResourceWithConfigure      = &EmailAuthResource{}
+	_ resource.ResourceWithValidateConfig = &EmailAuthResource{}
+	_ resource.ResourceWithModifyPlan     = &EmailAuthResource{}
+)
+
+// default TTL of the email authentication TXT records
+const DEFAULT_EMAIL_AUTH_TTL = 3600
+
+// SPF all mechanism, when not set
+const DEFAULT_SPF_ALL = "~all"
+
+type tfEmailAuth struct {
+	ID          types.String      `tfsdk:"id"`
+	Zone        types.String      `tfsdk:"zone"`
+	Domain      types.String      `tfsdk:"domain"`
+	SPF         *tfSPF            `tfsdk:"spf"`
+	DMARC       *tfDMARC          `tfsdk:"dmarc"`
+	DKIM        map[string]tfDKIM `tfsdk:"dkim"`
+	TTL         types.Int64       `tfsdk:"ttl"`
+	SPFRecord   types.String      `tfsdk:"spf_record"`
+	DMARCRecord types.String      `tfsdk:"dmarc_record"`
+	DKIMRecords types.Map         `tfsdk:"dkim_records"`
+	Timeouts    timeouts.Value    `tfsdk:"timeouts"`
+}
+
+type tfSPF struct {
+	A       types.Bool   `tfsdk:"a"`
+	MX      types.Bool   `tfsdk:"mx"`
+	IP4     types.List   `tfsdk:"ip4"`
+	IP6     types.List   `tfsdk:"ip6"`
+	Include types.List   `tfsdk:"include"`
+	All     types.String `tfsdk:"all"`
+}
+
+type tfDMARC struct {
+	Policy          types.String `tfsdk:"policy"`
+	SubdomainPolicy types.String `tfsdk:"subdomain_policy"`
+	Percentage      types.Int64  `tfsdk:"percentage"`
+	Rua             types.List   `tfsdk:"rua"`
+	Ruf             types.List   `tfsdk:"ruf"`
+	Adkim           types.String `tfsdk:"adkim"`
+	Aspf            types.String `tfsdk:"aspf"`
+}
+
+type tfDKIM struct {
+	PublicKey types.String `tfsdk:"public_key"`
+	KeyType   types.String `tfsdk:"key_type"`
+}
+
+// EmailAuthResource manages the SPF, DMARC and DKIM TXT records of a domain,
+// built from their settings
+type EmailAuthResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func EmailAuthResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &EmailAuthResource{reqMutex: m}
+	}
+}
+
+func (r *EmailAuthResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_auth"
+}
+
+func (r *EmailAuthResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	dmarcPolicies := []string{"none", "quarantine", "reject"}
+	alignments := []string{"r", "s"}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the email authentication TXT records of a domain, formatted from their settings: " +
+			"the SPF record at the domain, the DMARC record at `_dmarc.<domain>` and a DKIM key record at `<selector>._domainkey.<domain>` per selector. " +
+			"They replace the SPF record of the domain, and any TXT record at the DMARC and DKIM names. " +
+			"Long values, e.g. DKIM keys, are split into character-strings. Other TXT records of the domain are left as they are.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The domain of the records.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The zone holding the records. Defaults to the closest zone of `domain`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain sending mail, e.g. `example.com`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfOtherDomain(),
+				},
+			},
+			"spf": schema.SingleNestedAttribute{
+				MarkdownDescription: "The SPF policy of the domain: the hosts allowed to send its mail.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"a": schema.BoolAttribute{
+						MarkdownDescription: "Allow the addresses of the domain A and AAAA records.",
+						Optional:            true,
+					},
+					"mx": schema.BoolAttribute{
+						MarkdownDescription: "Allow the addresses of the domain mail exchanges.",
+						Optional:            true,
+					},
+					"ip4": schema.ListAttribute{
+						MarkdownDescription: "IPv4 addresses or networks allowed.",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(networkAddressValidator{}),
+						},
+					},
+					"ip6": schema.ListAttribute{
+						MarkdownDescription: "IPv6 addresses or networks allowed.",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(networkAddressValidator{}),
+						},
+					},
+					"include": schema.ListAttribute{
+						MarkdownDescription: "Domains whose SPF policy allows hosts as well, e.g. of a mail provider.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+					"all": schema.StringAttribute{
+						MarkdownDescription: "What to do with mail of other hosts: `-all` to fail, `~all` to soft fail, `?all` to stay neutral. Defaults to `" + DEFAULT_SPF_ALL + "`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("-all", "~all", "?all"),
+						},
+					},
+				},
+			},
+			"dmarc": schema.SingleNestedAttribute{
+				MarkdownDescription: "The DMARC policy of the domain: what receivers do with mail failing SPF and DKIM, and where they report it.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"policy": schema.StringAttribute{
+						MarkdownDescription: "Policy for the domain: `none`, `quarantine` or `reject`.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(dmarcPolicies...),
+						},
+					},
+					"subdomain_policy": schema.StringAttribute{
+						MarkdownDescription: "Policy for subdomains, the domain one when not set.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(dmarcPolicies...),
+						},
+					},
+					"percentage": schema.Int64Attribute{
+						MarkdownDescription: "Percentage of the failing mail the policy applies to, 100 when not set.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 100),
+						},
+					},
+					"rua": schema.ListAttribute{
+						MarkdownDescription: "Where to send aggregate reports: email addresses, or URIs such as `mailto:dmarc@example.com`.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+					"ruf": schema.ListAttribute{
+						MarkdownDescription: "Where to send failure reports: email addresses, or URIs such as `mailto:dmarc@example.com`.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+					"adkim": schema.StringAttribute{
+						MarkdownDescription: "DKIM alignment: `r` for relaxed, `s` for strict.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(alignments...),
+						},
+					},
+					"aspf": schema.StringAttribute{
+						MarkdownDescription: "SPF alignment: `r` for relaxed, `s` for strict.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(alignments...),
+						},
+					},
+				},
+			},
+			"dkim": schema.MapNestedAttribute{
+				MarkdownDescription: "The DKIM public keys of the domain, by selector.",
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(dkimSelectorRegexp, "must be a DKIM selector, e.g. `mail` or `2024.mail`")),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "The base64 public key. PEM armor lines and white space are removed.",
+							Required:            true,
+						},
+						"key_type": schema.StringAttribute{
+							MarkdownDescription: "The key type: `rsa` or `ed25519`. Defaults to `rsa`.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("rsa", "ed25519"),
+							},
+						},
+					},
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "The time-to-live (TTL) of the records, in seconds.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(DEFAULT_EMAIL_AUTH_TTL),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 604800),
+				},
+			},
+			"spf_record": schema.StringAttribute{
+				MarkdownDescription: "The text of the SPF record.",
+				Computed:            true,
+			},
+			"dmarc_record": schema.StringAttribute{
+				MarkdownDescription: "The text of the DMARC record.",
+				Computed:            true,
+			},
+			"dkim_records": schema.MapAttribute{
+				MarkdownDescription: "The text of the DKIM records, by selector.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *EmailAuthResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// there must be something to manage
+func (r *EmailAuthResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var spf, dmarc types.Object
+	var dkim types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("spf"), &spf)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dmarc"), &dmarc)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dkim"), &dkim)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if spf.IsNull() && dmarc.IsNull() && dkim.IsNull() {
+		resp.Diagnostics.AddError("Missing email authentication",
+			"At least one of spf, dmarc or dkim must be set")
+	}
+}
+
+// the records texts are planned from the settings, for their changes on the
+// server to show up as differences
+func (r *EmailAuthResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planData tfEmailAuth
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planData = planEmailAuthRecords(ctx, planData)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &planData)...)
+}
+
+func (r *EmailAuthResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfEmailAuth
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	ctx = tflog.SetField(ctx, "domain", planData.Domain.ValueString())
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	zone, ok := checkRecordZone(ctx, r.client, tfDNSRecord{Zone: planData.Zone, Domain: planData.Domain}, true, &resp.Diagnostics)
+	if !ok || resp.Diagnostics.HasError() {
+		return
+	}
+	planData.Zone = types.StringValue(zone.Name)
+	planData.ID = planData.Domain
+	planData = planEmailAuthRecords(ctx, planData)
+
+	if err := r.apply(ctx, nil, planData); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Creating email authentication records of %s: %s", planData.Domain.ValueString(), err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *EmailAuthResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateData tfEmailAuth
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "domain", stateData.Domain.ValueString())
+	tflog.Info(ctx, "read: start")
+	defer tflog.Info(ctx, "read: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	stateData, err := readEmailAuthRecords(ctx, r.client, stateData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading email authentication records of %s: %s", stateData.Domain.ValueString(), err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func (r *EmailAuthResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData, stateData tfEmailAuth
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "update")
+	ctx = tflog.SetField(ctx, "domain", planData.Domain.ValueString())
+	tflog.Info(ctx, "update: start")
+	defer tflog.Info(ctx, "update: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	planData = planEmailAuthRecords(ctx, planData)
+	if err := r.apply(ctx, &stateData, planData); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Updating email authentication records of %s: %s", planData.Domain.ValueString(), err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *EmailAuthResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateData tfEmailAuth
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "delete")
+	ctx = tflog.SetField(ctx, "domain", stateData.Domain.ValueString())
+	tflog.Info(ctx, "delete: start")
+	defer tflog.Info(ctx, "delete: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	deleteTimeout, diags := stateData.Timeouts.Delete(ctx, DEFAULT_DELETE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	none := stateData
+	none.SPF, none.DMARC, none.DKIM = nil, nil, nil
+	if err := r.apply(ctx, &stateData, none); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Deleting email authentication records of %s: %s", stateData.Domain.ValueString(), err))
+	}
+}
+
+// reconcile the TXT records of each name managed before or now: those of
+// names no longer managed are deleted
+func (r *EmailAuthResource) apply(ctx context.Context, prior *tfEmailAuth, plan tfEmailAuth) error {
+	splitText := serverSupports(ctx, r.client, featureSplitText)
+	desired := emailAuthTexts(plan)
+	names := emailAuthNames(plan)
+	if prior != nil {
+		for name, owns := range emailAuthNames(*prior) {
+			if _, ok := names[name]; !ok {
+				names[name] = owns
+			}
+		}
+	}
+
+	for name, owns := range names {
+		current, err := readEmailAuthSet(ctx, r.client, plan.Zone.ValueString(), name, owns)
+		if err != nil {
+			return err
+		}
+		records := []model.DNSRecord{}
+		if text, ok := desired[name]; ok {
+			records = append(records, emailAuthRecord(plan, name, text, splitText))
+		}
+		if err := reconcileRecordSet(ctx, r.client, current, records, ttlChanged); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ttlChanged(current, desired model.DNSRecord) bool {
+	return current.TTL != desired.TTL
+}
+
+// TXT record holding text, split in character-strings when too long
+func emailAuthRecord(data tfEmailAuth, name string, text string, splitText bool) model.DNSRecord {
+	record := model.DNSRecord{
+		Type:   model.REC_TXT,
+		Domain: model.DNSRecordName(name),
+		Zone:   data.Zone.ValueString(),
+		TTL:    model.DNSRecordTTL(data.TTL.ValueInt64()),
+		Text:   text,
+	}
+	if splitText && len(text) > TXT_CHUNK_MAX {
+		record.Text = strings.Join(splitTxt(text, TXT_CHUNK_MAX), "\n")
+		record.SplitText = true
+	}
+	return record
+}
+
+// TXT records of a name that are email authentication ones
+func readEmailAuthSet(ctx context.Context, client model.DNSApiClient, zoneName string, name string, owns func(text string) bool) ([]model.DNSRecord, error) {
+	records, err := readRecordSet(ctx, client, zoneName, name, model.REC_TXT)
+	if err != nil {
+		return nil, err
+	}
+	owned := []model.DNSRecord{}
+	for _, record := range records {
+		if owns(txtValue(record)) {
+			owned = append(owned, record)
+		}
+	}
+	return owned, nil
+}
+
+// text of a TXT record, its character-strings joined
+func txtValue(record model.DNSRecord) string {
+	if record.SplitText {
+		return strings.ReplaceAll(record.Text, "\n", "")
+	}
+	return record.Text
+}
+
+// the texts of the records as found on the server, null when a name has
+// none or several, for the planned ones to differ
+func readEmailAuthRecords(ctx context.Context, client model.DNSApiClient, data tfEmailAuth) (tfEmailAuth, error) {
+	found := map[string]string{}
+	for name, owns := range emailAuthNames(data) {
+		records, err := readEmailAuthSet(ctx, client, data.Zone.ValueString(), name, owns)
+		if err != nil {
+			return data, err
+		}
+		if len(records) == 1 {
+			found[name] = txtValue(records[0])
+		}
+	}
+	return emailAuthState(data, found, false), nil
+}
+
+// the planned texts of the records, unknown until the settings are
+func planEmailAuthRecords(ctx context.Context, data tfEmailAuth) tfEmailAuth {
+	if data.Domain.IsUnknown() {
+		data.SPFRecord, data.DMARCRecord, data.DKIMRecords = types.StringUnknown(), types.StringUnknown(), types.MapUnknown(types.StringType)
+		return data
+	}
+	return emailAuthState(data, emailAuthTexts(data), true)
+}
+
+// set the record texts of data from the texts by name
+func emailAuthState(data tfEmailAuth, texts map[string]string, planned bool) tfEmailAuth {
+	domain := emailAuthDomain(data)
+	text := func(name string, configured bool) types.String {
+		value, ok := texts[name]
+		switch {
+		case !configured:
+			return types.StringNull()
+		case !ok && planned:
+			return types.StringUnknown()
+		case !ok:
+			return types.StringNull()
+		}
+		return types.StringValue(value)
+	}
+
+	data.SPFRecord = text(domain, data.SPF != nil)
+	data.DMARCRecord = text("_dmarc."+domain, data.DMARC != nil)
+	data.DKIMRecords = types.MapNull(types.StringType)
+	if data.DKIM != nil {
+		records := map[string]attr.Value{}
+		for selector := range data.DKIM {
+			records[selector] = text(dkimName(selector, domain), true)
+		}
+		data.DKIMRecords = types.MapValueMust(types.StringType, records)
+	}
+	return data
+}
+
+func emailAuthDomain(data tfEmailAuth) string {
+	return strings.TrimSuffix(domainToASCII(data.Domain.ValueString()), ".")
+}
+
+func dkimName(selector string, domain string) string {
+	return strings.ToLower(selector) + "._domainkey." + domain
+}
+
+// the names of the records managed, and which of their TXT records they are
+func emailAuthNames(data tfEmailAuth) map[string]func(text string) bool {
+	domain := emailAuthDomain(data)
+	anyText := func(text string) bool { return true }
+	names := map[string]func(text string) bool{}
+	if data.SPF != nil {
+		names[domain] = isSPFRecord
+	}
+	if data.DMARC != nil {
+		names["_dmarc."+domain] = anyText
+	}
+	for selector := range data.DKIM {
+		names[dkimName(selector, domain)] = anyText
+	}
+	return names
+}
+
+// v=spf1 alone or followed by terms
+func isSPFRecord(text string) bool {
+	version, _, _ := strings.Cut(text, " ")
+	return strings.EqualFold(version, "v=spf1")
+}
+
+// the texts of the records, by name, for the known settings only
+func emailAuthTexts(data tfEmailAuth) map[string]string {
+	domain := emailAuthDomain(data)
+	texts := map[string]string{}
+	if text, ok := spfText(data.SPF); ok {
+		texts[domain] = text
+	}
+	if text, ok := dmarcText(data.DMARC); ok {
+		texts["_dmarc."+domain] = text
+	}
+	for selector, dkim := range data.DKIM {
+		if text, ok := dkimText(dkim); ok {
+			texts[dkimName(selector, domain)] = text
+		}
+	}
+	return texts
+}
+
+func spfText(spf *tfSPF) (string, bool) {
+	if spf == nil || spf.A.IsUnknown() || spf.MX.IsUnknown() || spf.All.IsUnknown() {
+		return "", false
+	}
+	terms := []string{"v=spf1"}
+	if spf.A.ValueBool() {
+		terms = append(terms, "a")
+	}
+	if spf.MX.ValueBool() {
+		terms = append(terms, "mx")
+	}
+	for _, mechanism := range []struct {
+		name   string
+		values types.List
+	}{{"ip4", spf.IP4}, {"ip6", spf.IP6}, {"include", spf.Include}} {
+		values, ok := listStrings(mechanism.values)
+		if !ok {
+			return "", false
+		}
+		for _, value := range values {
+			terms = append(terms, mechanism.name+":"+value)
+		}
+	}
+	all := spf.All.ValueString()
+	if all == "" {
+		all = DEFAULT_SPF_ALL
+	}
+	return strings.Join(append(terms, all), " "), true
+}
+
+func dmarcText(dmarc *tfDMARC) (string, bool) {
+	if dmarc == nil {
+		return "", false
+	}
+	tags := []string{"v=DMARC1"}
+	for _, tag := range []struct {
+		name  string
+		value attr.Value
+	}{
+		{"p", dmarc.Policy}, {"sp", dmarc.SubdomainPolicy}, {"pct", dmarc.Percentage},
+		{"rua", dmarc.Rua}, {"ruf", dmarc.Ruf}, {"adkim", dmarc.Adkim}, {"aspf", dmarc.Aspf},
+	} {
+		switch value := tag.value.(type) {
+		case types.String:
+			if value.IsUnknown() {
+				return "", false
+			}
+			if !value.IsNull() {
+				tags = append(tags, tag.name+"="+value.ValueString())
+			}
+		case types.Int64:
+			if value.IsUnknown() {
+				return "", false
+			}
+			if !value.IsNull() {
+				tags = append(tags, fmt.Sprintf("%s=%d", tag.name, value.ValueInt64()))
+			}
+		case types.List:
+			uris, ok := listStrings(value)
+			if !ok {
+				return "", false
+			}
+			for i, uri := range uris {
+				if !strings.Contains(uri, ":") {
+					uris[i] = "mailto:" + uri
+				}
+			}
+			if len(uris) > 0 {
+				tags = append(tags, tag.name+"="+strings.Join(uris, ","))
+			}
+		}
+	}
+	return strings.Join(tags, "; "), true
+}
+
+func dkimText(dkim tfDKIM) (string, bool) {
+	if dkim.PublicKey.IsUnknown() || dkim.KeyType.IsUnknown() {
+		return "", false
+	}
+	keyType := dkim.KeyType.ValueString()
+	if keyType == "" {
+		keyType = "rsa"
+	}
+	return "v=DKIM1; k=" + keyType + "; p=" + dkimPublicKey(dkim.PublicKey.ValueString()), true
+}
+
+// the base64 key, without PEM armor nor white space
+func dkimPublicKey(key string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(key, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "-----") {
+			continue
+		}
+		b.WriteString(strings.Join(strings.Fields(line), ""))
+	}
+	return b.String()
+}
+
+// the values of a list, false while unknown
+func listStrings(list types.List) ([]string, bool) {
+	if list.IsUnknown() {
+		return nil, false
+	}
+	values := []string{}
+	for _, element := range list.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() {
+			return nil, false
+		}
+		values = append(values, value.ValueString())
+	}
+	return values, true
+}
+
+// a DNS label per part, e.g. mail or 2024.mail
+var dkimSelectorRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_-]*[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9_-]*[A-Za-z0-9])?)*$`)
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func stringList(values ...string) types.List {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+func TestEmailAuthTexts(t *testing.T) {
+	t.Parallel()
+	data := tfEmailAuth{
+		Domain: types.StringValue("Example.com."),
+		SPF: &tfSPF{
+			MX:      types.BoolValue(true),
+			IP4:     stringList("192.0.2.0/24"),
+			IP6:     types.ListNull(types.StringType),
+			Include: stringList("_spf.mail.example.net"),
+			All:     types.StringValue("-all"),
+		},
+		DMARC: &tfDMARC{
+			Policy:          types.StringValue("quarantine"),
+			SubdomainPolicy: types.StringNull(),
+			Percentage:      types.Int64Value(50),
+			Rua:             stringList("dmarc@example.com", "https://reports.example.net"),
+			Ruf:             types.ListNull(types.StringType),
+			Adkim:           types.StringValue("s"),
+			Aspf:            types.StringNull(),
+		},
+		DKIM: map[string]tfDKIM{
+			"Mail": {
+				PublicKey: types.StringValue("-----BEGIN PUBLIC KEY-----\nMIIB\nIjAN\n-----END PUBLIC KEY-----\n"),
+				KeyType:   types.StringNull(),
+			},
+		},
+	}
+
+	want := map[string]string{
+		"Example.com":                 "v=spf1 mx ip4:192.0.2.0/24 include:_spf.mail.example.net -all",
+		"_dmarc.Example.com":          "v=DMARC1; p=quarantine; pct=50; rua=mailto:dmarc@example.com,https://reports.example.net; adkim=s",
+		"mail._domainkey.Example.com": "v=DKIM1; k=rsa; p=MIIBIjAN",
+	}
+	got := emailAuthTexts(data)
+	if len(got) != len(want) {
+		t.Errorf("texts = %v, want %v", got, want)
+	}
+	for name, text := range want {
+		if got[name] != text {
+			t.Errorf("text of %s = %q, want %q", name, got[name], text)
+		}
+	}
+
+	// unknown settings leave the text unknown
+	data.SPF.IP6 = types.ListUnknown(types.StringType)
+	if planned := planEmailAuthRecords(context.Background(), data); !planned.SPFRecord.IsUnknown() || planned.DMARCRecord.IsUnknown() {
+		t.Errorf("planned spf_record = %v, dmarc_record = %v, want only the SPF one unknown", planned.SPFRecord, planned.DMARCRecord)
+	}
+}
+
+func TestEmailAuthApply(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := model.NewFakeDNSApiClient()
+	if err := client.CreateZone(ctx, model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY}); err != nil {
+		t.Fatal(err)
+	}
+	for _, text := range []string{"v=spf1 -all", "site-verification=abc"} {
+		if err := client.AddRecord(ctx, model.DNSRecord{Type: model.REC_TXT, Domain: "example.com", Text: text}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := &EmailAuthResource{client: client}
+
+	key := strings.Repeat("A", 392)
+	data := tfEmailAuth{
+		Zone:   types.StringValue("example.com"),
+		Domain: types.StringValue("example.com"),
+		SPF: &tfSPF{
+			A:       types.BoolValue(true),
+			IP4:     types.ListNull(types.StringType),
+			IP6:     types.ListNull(types.StringType),
+			Include: types.ListNull(types.StringType),
+			All:     types.StringNull(),
+		},
+		DKIM: map[string]tfDKIM{"mail": {PublicKey: types.StringValue(key), KeyType: types.StringNull()}},
+		TTL:  types.Int64Value(3600),
+	}
+	if err := r.apply(ctx, nil, data); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := client.GetRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	texts := []string{}
+	for _, record := range records {
+		texts = append(texts, record.Text)
+	}
+	if len(texts) != 2 || !strings.Contains(strings.Join(texts, "|"), "site-verification=abc") || !strings.Contains(strings.Join(texts, "|"), "v=spf1 a ~all") {
+		t.Errorf("TXT records of example.com = %q, want the new SPF record and the verification one", texts)
+	}
+
+	dkim, err := client.GetRecords(ctx, "mail._domainkey.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dkim) != 1 || !dkim[0].SplitText || strings.Count(dkim[0].Text, "\n") != 1 {
+		t.Fatalf("DKIM records = %+v, want one split in 2 character-strings", dkim)
+	}
+
+	got, err := readEmailAuthRecords(ctx, client, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SPFRecord.ValueString() != "v=spf1 a ~all" || got.DKIMRecords.Elements()["mail"] != types.StringValue("v=DKIM1; k=rsa; p="+key) {
+		t.Errorf("read spf_record = %v, dkim_records = %v", got.SPFRecord, got.DKIMRecords)
+	}
+
+	// removing the DKIM selector deletes its record, the SPF one stays
+	prior := data
+	data.DKIM = nil
+	if err := r.apply(ctx, &prior, data); err != nil {
+		t.Fatal(err)
+	}
+	if dkim, _ := client.GetRecords(ctx, "mail._domainkey.example.com"); len(dkim) != 0 {
+		t.Errorf("DKIM records = %+v, want none", dkim)
+	}
+}
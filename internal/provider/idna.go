@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"golang.org/x/net/idna"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ function.Function = &IdnaEncodeFunction{}
+	_ function.Function = &IdnaDecodeFunction{}
+)
+
+// IdnaEncodeFunction converts an internationalized domain name to punycode
+type IdnaEncodeFunction struct{}
+
+func NewIdnaEncodeFunction() function.Function {
+	return &IdnaEncodeFunction{}
+}
+
+func (f *IdnaEncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "idna_encode"
+}
+
+func (f *IdnaEncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert a domain name to punycode",
+		MarkdownDescription: "Converts an internationalized (Unicode) domain name to its ASCII punycode form, the way Technitium stores it. ASCII names are returned lowercased and otherwise unchanged.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "Domain name, e.g. `bücher.example`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *IdnaEncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	encoded, err := idnaProfile.ToASCII(name)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Cannot encode domain name: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, encoded))
+}
+
+// IdnaDecodeFunction converts a punycode domain name back to Unicode
+type IdnaDecodeFunction struct{}
+
+func NewIdnaDecodeFunction() function.Function {
+	return &IdnaDecodeFunction{}
+}
+
+func (f *IdnaDecodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "idna_decode"
+}
+
+func (f *IdnaDecodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert a punycode domain name to Unicode",
+		MarkdownDescription: "Converts a punycode (`xn--`) domain name back to its Unicode form. Names without punycode labels are returned unchanged.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "Domain name, e.g. `xn--bcher-kva.example`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *IdnaDecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	decoded, err := idnaProfile.ToUnicode(name)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Cannot decode domain name: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, decoded))
+}
+
+// names are mapped as for lookups (e.g. lowercased), without the host name
+// rules that would reject labels like _dmarc or *
+var idnaProfile = idna.New(idna.MapForLookup(), idna.StrictDomainName(false), idna.Transitional(false))
+
+// domainToASCII converts an internationalized zone or domain name to the
+// ASCII (punycode) form used by the API. ASCII names are returned as is,
+// and so are invalid ones, for the API to report them
+func domainToASCII(name string) string {
+	if isASCII(name) {
+		return name
+	}
+	ascii, err := idnaProfile.ToASCII(name)
+	if err != nil {
+		return name
+	}
+	return ascii
+}
+
+// whether two names are the same domain, in Unicode or punycode form
+func sameDomainName(name1 string, name2 string) bool {
+	return strings.EqualFold(domainToASCII(name1), domainToASCII(name2))
+}
+
+// whether two host names, e.g. of name servers or mail exchanges, are the
+// same, with or without the root dot
+func sameHostName(name1 string, name2 string) bool {
+	return sameDomainName(strings.TrimSuffix(name1, "."), strings.TrimSuffix(name2, "."))
+}
+
+// replace the resource when its name changes, but not when the same name is
+// only written in its other form, Unicode or punycode
+func requiresReplaceIfOtherDomain() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !sameDomainName(req.StateValue.ValueString(), req.PlanValue.ValueString())
+		},
+		"Changing the name requires replacement, writing it in Unicode or punycode form does not.",
+		"Changing the name requires replacement, writing it in Unicode or punycode form does not.",
+	)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
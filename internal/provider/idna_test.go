@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// the functions accept the names the resources do, e.g. with _ or * labels
+func TestIdnaFunctions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		function function.Function
+		name     string
+		want     string
+	}{
+		{NewIdnaEncodeFunction(), "_dmarc.Bücher.example", "_dmarc.xn--bcher-kva.example"},
+		{NewIdnaEncodeFunction(), "*.bücher.example", "*.xn--bcher-kva.example"},
+		{NewIdnaDecodeFunction(), "_dmarc.xn--bcher-kva.example", "_dmarc.bücher.example"},
+	}
+
+	for _, tt := range tests {
+		resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+		tt.function.Run(context.Background(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.name)}),
+		}, resp)
+		if resp.Error != nil {
+			t.Errorf("%q: %s", tt.name, resp.Error)
+			continue
+		}
+		got := resp.Result.Value().(types.String).ValueString()
+		if got != tt.want {
+			t.Errorf("%q = %q, want %q", tt.name, got, tt.want)
+		}
+		if _, encode := tt.function.(*IdnaEncodeFunction); encode && got != domainToASCII(tt.name) {
+			t.Errorf("idna_encode(%q) = %q, resources send %q", tt.name, got, domainToASCII(tt.name))
+		}
+	}
+}
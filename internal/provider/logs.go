@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &LogFilesDataSource{}
+	_ datasource.DataSourceWithConfigure = &LogFileDataSource{}
+)
+
+type tfLogFiles struct {
+	Files []tfLogFileEntry `tfsdk:"files"`
+}
+
+type tfLogFileEntry struct {
+	FileName types.String `tfsdk:"file_name"`
+	Size     types.String `tfsdk:"size"`
+}
+
+type tfLogFile struct {
+	FileName      types.String `tfsdk:"file_name"`
+	LimitMB       types.Int64  `tfsdk:"limit_mb"`
+	OutputPath    types.String `tfsdk:"output_path"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	Size          types.Int64  `tfsdk:"size"`
+}
+
+// LogFilesDataSource lists the server log files
+type LogFilesDataSource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func LogFilesDataSourceFactory(m *sync.Mutex) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &LogFilesDataSource{reqMutex: m}
+	}
+}
+
+func (d *LogFilesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_log_files"
+}
+
+func (d *LogFilesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the log files of Technitium DNS Server, to be downloaded with `technitium_log_file`.",
+		Attributes: map[string]schema.Attribute{
+			"files": schema.ListNestedAttribute{
+				MarkdownDescription: "Log files, most recent first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"file_name": schema.StringAttribute{
+							MarkdownDescription: "Log file name, the date of the log (e.g. `2024-05-01`).",
+							Computed:            true,
+						},
+						"size": schema.StringAttribute{
+							MarkdownDescription: "File size as displayed by the server, e.g. `8.14 KB`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *LogFilesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *LogFilesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = tflog.SetField(ctx, "operation", "read")
+	tflog.Info(ctx, "read log files: start")
+	defer tflog.Info(ctx, "read log files: end")
+	d.reqMutex.Lock()
+	defer d.reqMutex.Unlock()
+
+	logFiles, err := d.client.ListLogFiles(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Listing log files: query failed: %s", err))
+		return
+	}
+
+	result := tfLogFiles{Files: make([]tfLogFileEntry, len(logFiles))}
+	for i, logFile := range logFiles {
+		result.Files[i] = tfLogFileEntry{
+			FileName: types.StringValue(logFile.FileName),
+			Size:     types.StringValue(logFile.Size),
+		}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &result)...)
+}
+
+// LogFileDataSource downloads a server log file
+type LogFileDataSource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func LogFileDataSourceFactory(m *sync.Mutex) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &LogFileDataSource{reqMutex: m}
+	}
+}
+
+func (d *LogFileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_log_file"
+}
+
+func (d *LogFileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Downloads a log file of Technitium DNS Server, as a base64 attribute or to a local file.",
+		Attributes: map[string]schema.Attribute{
+			"file_name": schema.StringAttribute{
+				MarkdownDescription: "Log file name, as listed by `technitium_log_files`.",
+				Required:            true,
+			},
+			"limit_mb": schema.Int64Attribute{
+				MarkdownDescription: "Only download the last megabytes of the file.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"output_path": schema.StringAttribute{
+				MarkdownDescription: "Local path to write the log file to. `content_base64` is left empty when set, so large logs are not kept in state.",
+				Optional:            true,
+			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded log file content, when `output_path` is not set.",
+				Computed:            true,
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Size of the downloaded content in bytes.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *LogFileDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *LogFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config tfLogFile
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "file_name", config.FileName.ValueString())
+	tflog.Info(ctx, "download log file: start")
+	defer tflog.Info(ctx, "download log file: end")
+	d.reqMutex.Lock()
+	defer d.reqMutex.Unlock()
+
+	content, err := d.client.DownloadLogFile(ctx, config.FileName.ValueString(), config.LimitMB.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Downloading log file: query failed: %s", err))
+		return
+	}
+
+	config.Size = types.Int64Value(int64(len(content)))
+	if config.OutputPath.IsNull() {
+		config.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content))
+	} else {
+		if err := os.WriteFile(config.OutputPath.ValueString(), content, 0o600); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("output_path"), "Cannot write log file",
+				fmt.Sprintf("Writing downloaded log file failed: %s", err))
+			return
+		}
+		config.ContentBase64 = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
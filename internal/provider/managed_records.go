@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &ManagedRecordsDataSource{}
+)
+
+type tfManagedRecords struct {
+	Zone    types.String      `tfsdk:"zone"`
+	Comment types.String      `tfsdk:"comment"`
+	Records []tfManagedRecord `tfsdk:"records"`
+}
+
+type tfManagedRecord struct {
+	Domain   types.String `tfsdk:"domain"`
+	Type     types.String `tfsdk:"type"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Value    types.String `tfsdk:"value"`
+	Comments types.String `tfsdk:"comments"`
+	ImportID types.String `tfsdk:"import_id"`
+}
+
+// ManagedRecordsDataSource lists the records of a zone carrying the managed comment
+type ManagedRecordsDataSource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func ManagedRecordsDataSourceFactory(m *sync.Mutex) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &ManagedRecordsDataSource{reqMutex: m}
+	}
+}
+
+func (d *ManagedRecordsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_managed_records"
+}
+
+func (d *ManagedRecordsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the records of a zone whose comments contain the comment terraform sets on the records it manages, " +
+			"to compare what the server considers managed by terraform with the state.",
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The zone to list records of.",
+				Required:            true,
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Text the record comments must contain. The provider `managed_comment` when not set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"records": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching records, in server order.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The domain name of the record.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The record type.",
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "The record TTL.",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The record value, in the format of the `value` part of import IDs.",
+							Computed:            true,
+						},
+						"comments": schema.StringAttribute{
+							MarkdownDescription: "The record comments.",
+							Computed:            true,
+						},
+						"import_id": schema.StringAttribute{
+							MarkdownDescription: "ID to import the record with into a `technitium_record`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ManagedRecordsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ManagedRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config tfManagedRecords
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "zone", config.Zone.ValueString())
+	tflog.Info(ctx, "read managed records: start")
+	defer tflog.Info(ctx, "read managed records: end")
+	d.reqMutex.Lock()
+	defer d.reqMutex.Unlock()
+
+	if config.Comment.IsNull() || config.Comment.IsUnknown() {
+		config.Comment = types.StringValue(d.client.ManagedComment())
+	}
+
+	zoneName := domainToASCII(config.Zone.ValueString())
+	records, err := d.client.GetZoneRecords(ctx, zoneName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading records of zone %s: query failed: %s", zoneName, err))
+		return
+	}
+
+	config.Records = managedRecords(records, zoneName, config.Comment.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// records whose comments contain the comment
+func managedRecords(records []model.DNSRecord, zoneName string, comment string) []tfManagedRecord {
+	managed := []tfManagedRecord{}
+	for _, record := range records {
+		if !strings.Contains(record.Comments, comment) {
+			continue
+		}
+		tfRec := nullRecordData()
+		tfRec.Zone = types.StringValue(zoneName)
+		model2tf(record, &tfRec)
+		model2tfZeroValues(record, &tfRec)
+		managed = append(managed, tfManagedRecord{
+			Domain:   types.StringValue(string(record.Domain)),
+			Type:     types.StringValue(string(record.Type)),
+			TTL:      types.Int64Value(int64(record.TTL)),
+			Value:    types.StringValue(formatImportValue(tfRec)),
+			Comments: types.StringValue(record.Comments),
+			ImportID: types.StringValue(recordImportID(tfRec, zoneName)),
+		})
+	}
+	return managed
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestManagedRecords(t *testing.T) {
+	t.Parallel()
+	records := []model.DNSRecord{
+		{Type: model.REC_A, Domain: "www.example.com", TTL: 300, IPAddress: "192.0.2.1", Comments: "Managed by terraform"},
+		{Type: model.REC_A, Domain: "manual.example.com", TTL: 300, IPAddress: "192.0.2.2", Comments: "added by hand"},
+		{Type: model.REC_MX, Domain: "example.com", TTL: 3600, Exchange: "mail.example.com", Comments: "note\nManaged by terraform"},
+	}
+
+	got := managedRecords(records, "example.com", "Managed by terraform")
+	want := []tfManagedRecord{
+		{
+			Domain:   types.StringValue("www.example.com"),
+			Type:     types.StringValue("A"),
+			TTL:      types.Int64Value(300),
+			Value:    types.StringValue("192.0.2.1"),
+			Comments: types.StringValue("Managed by terraform"),
+			ImportID: types.StringValue("example.com:www:A:192.0.2.1"),
+		},
+		{
+			Domain:   types.StringValue("example.com"),
+			Type:     types.StringValue("MX"),
+			TTL:      types.Int64Value(3600),
+			Value:    types.StringValue("0:mail.example.com"),
+			Comments: types.StringValue("note\nManaged by terraform"),
+			ImportID: types.StringValue("example.com:@:MX:0:mail.example.com"),
+		},
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,419 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &MXSetResource{}
+	_ resource.ResourceWithConfigure      = &MXSetResource{}
+	_ resource.ResourceWithImportState    = &MXSetResource{}
+	_ resource.ResourceWithValidateConfig = &MXSetResource{}
+)
+
+// default TTL of the MX records of a set
+const DEFAULT_MX_SET_TTL = 3600
+
+type tfMXSet struct {
+	ID       types.String   `tfsdk:"id"`
+	Zone     types.String   `tfsdk:"zone"`
+	Domain   types.String   `tfsdk:"domain"`
+	Records  []tfMXRecord   `tfsdk:"records"`
+	TTL      types.Int64    `tfsdk:"ttl"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type tfMXRecord struct {
+	Preference types.Int64  `tfsdk:"preference"`
+	Exchange   types.String `tfsdk:"exchange"`
+}
+
+// MXSetResource manages all the MX records of a domain, so that a single
+// resource owns each mail exchange
+type MXSetResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func MXSetResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &MXSetResource{reqMutex: m}
+	}
+}
+
+func (r *MXSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mx_set"
+}
+
+func (r *MXSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages all the MX records of a domain as a whole: mail exchanges added, changed or removed are " +
+			"reconciled with the records of the server, and MX records at the domain that are not configured are deleted. " +
+			"Creating the set fails when the domain already has MX records, e.g. of another set or `technitium_record`: import them instead.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The domain of the records.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The zone holding the records. Defaults to the closest zone of `domain`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain receiving mail, e.g. `example.com`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfOtherDomain(),
+				},
+			},
+			"records": schema.SetNestedAttribute{
+				MarkdownDescription: "The mail exchanges of the domain.",
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"preference": schema.Int64Attribute{
+							MarkdownDescription: "The preference of the mail exchange, lowest first.",
+							Required:            true,
+							Validators: []validator.Int64{
+								uint16Validator(),
+							},
+						},
+						"exchange": schema.StringAttribute{
+							MarkdownDescription: "The host name of the mail exchange.",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "The time-to-live (TTL) of the MX records, in seconds.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(DEFAULT_MX_SET_TTL),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 604800),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *MXSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// the server holds a single MX record per exchange
+func (r *MXSetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var records []tfMXRecord
+	if !configSet(ctx, req.Config, "records", &records) {
+		return
+	}
+
+	for i, record := range records {
+		if record.Exchange.IsUnknown() {
+			continue
+		}
+		for _, other := range records[:i] {
+			if !other.Exchange.IsUnknown() && sameHostName(other.Exchange.ValueString(), record.Exchange.ValueString()) {
+				resp.Diagnostics.AddAttributeError(path.Root("records"), "Duplicate exchange",
+					fmt.Sprintf("'%s' is set more than once, a domain has a single MX record per exchange", record.Exchange.ValueString()))
+			}
+		}
+	}
+}
+
+func (r *MXSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfMXSet
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	ctx = tflog.SetField(ctx, "domain", planData.Domain.ValueString())
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	zone, ok := checkRecordZone(ctx, r.client, tfDNSRecord{Zone: planData.Zone, Domain: planData.Domain}, true, &resp.Diagnostics)
+	if !ok || resp.Diagnostics.HasError() {
+		return
+	}
+	planData.Zone = types.StringValue(zone.Name)
+	planData.ID = planData.Domain
+
+	current, err := readRecordSet(ctx, r.client, zone.Name, domainToASCII(planData.Domain.ValueString()), model.REC_MX)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading MX records of %s: %s", planData.Domain.ValueString(), err))
+		return
+	}
+	if len(current) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("domain"), "MX records already exist",
+			fmt.Sprintf("'%s' already has %d MX records in zone '%s', managed elsewhere or not at all: "+
+				"import them with terraform import, or delete them first", planData.Domain.ValueString(), len(current), zone.Name))
+		return
+	}
+
+	if err := reconcileRecordSet(ctx, r.client, nil, tfMXSet2model(planData), mxChanged); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Creating MX records of %s: %s", planData.Domain.ValueString(), err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *MXSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateData tfMXSet
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "domain", stateData.Domain.ValueString())
+	tflog.Info(ctx, "read: start")
+	defer tflog.Info(ctx, "read: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	current, err := readRecordSet(ctx, r.client, stateData.Zone.ValueString(), domainToASCII(stateData.Domain.ValueString()), model.REC_MX)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading MX records of %s: %s", stateData.Domain.ValueString(), err))
+		return
+	}
+	if len(current) == 0 {
+		tflog.Warn(ctx, "read: MX records not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	stateData = mergeMXSetState(stateData, current)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func (r *MXSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData tfMXSet
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "update")
+	ctx = tflog.SetField(ctx, "domain", planData.Domain.ValueString())
+	tflog.Info(ctx, "update: start")
+	defer tflog.Info(ctx, "update: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	current, err := readRecordSet(ctx, r.client, planData.Zone.ValueString(), domainToASCII(planData.Domain.ValueString()), model.REC_MX)
+	if err == nil {
+		err = reconcileRecordSet(ctx, r.client, current, tfMXSet2model(planData), mxChanged)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Updating MX records of %s: %s", planData.Domain.ValueString(), err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *MXSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateData tfMXSet
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "delete")
+	ctx = tflog.SetField(ctx, "domain", stateData.Domain.ValueString())
+	tflog.Info(ctx, "delete: start")
+	defer tflog.Info(ctx, "delete: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	deleteTimeout, diags := stateData.Timeouts.Delete(ctx, DEFAULT_DELETE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	current, err := readRecordSet(ctx, r.client, stateData.Zone.ValueString(), domainToASCII(stateData.Domain.ValueString()), model.REC_MX)
+	if err == nil {
+		err = reconcileRecordSet(ctx, r.client, current, nil, mxChanged)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Deleting MX records of %s: %s", stateData.Domain.ValueString(), err))
+	}
+}
+
+// terraform import technitium_mx_set.example example.com
+func (r *MXSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = tflog.SetField(ctx, "operation", "import")
+	ctx = tflog.SetField(ctx, "domain", req.ID)
+	tflog.Info(ctx, "import: start")
+	defer tflog.Info(ctx, "import: end")
+
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
+
+	stateData := tfMXSet{
+		ID:       types.StringValue(req.ID),
+		Zone:     types.StringNull(),
+		Domain:   types.StringValue(req.ID),
+		TTL:      types.Int64Null(),
+		Timeouts: nullTimeouts(),
+	}
+	zone, ok := checkRecordZone(ctx, r.client, tfDNSRecord{Zone: stateData.Zone, Domain: stateData.Domain}, true, &resp.Diagnostics)
+	if !ok || resp.Diagnostics.HasError() {
+		return
+	}
+	stateData.Zone = types.StringValue(zone.Name)
+
+	current, err := readRecordSet(ctx, r.client, zone.Name, domainToASCII(req.ID), model.REC_MX)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading MX records of %s: %s", req.ID, err))
+		return
+	}
+	if len(current) == 0 {
+		resp.Diagnostics.AddError("MX records not found",
+			fmt.Sprintf("'%s' has no MX records in zone '%s'", req.ID, zone.Name))
+		return
+	}
+
+	stateData = mergeMXSetState(stateData, current)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func tfMXSet2model(data tfMXSet) []model.DNSRecord {
+	records := make([]model.DNSRecord, 0, len(data.Records))
+	for _, record := range data.Records {
+		records = append(records, model.DNSRecord{
+			Type:       model.REC_MX,
+			Domain:     model.DNSRecordName(domainToASCII(data.Domain.ValueString())),
+			Zone:       data.Zone.ValueString(),
+			TTL:        model.DNSRecordTTL(data.TTL.ValueInt64()),
+			Exchange:   strings.TrimSuffix(domainToASCII(record.Exchange.ValueString()), "."),
+			Preference: model.DNSRecordPrio(record.Preference.ValueInt64()),
+		})
+	}
+	return records
+}
+
+func mxChanged(current, desired model.DNSRecord) bool {
+	return current.TTL != desired.TTL || current.Preference != desired.Preference
+}
+
+// the records as found on the server, keeping the prior spelling of the
+// exchanges
+func mergeMXSetState(prior tfMXSet, records []model.DNSRecord) tfMXSet {
+	result := prior
+	result.ID = prior.Domain
+	result.Records = []tfMXRecord{}
+	for _, record := range records {
+		exchange := record.Exchange
+		for _, priorRecord := range prior.Records {
+			if sameHostName(priorRecord.Exchange.ValueString(), exchange) {
+				exchange = priorRecord.Exchange.ValueString()
+			}
+		}
+		result.Records = append(result.Records, tfMXRecord{
+			Preference: types.Int64Value(int64(record.Preference)),
+			Exchange:   types.StringValue(exchange),
+		})
+	}
+
+	if result.TTL.IsNull() || result.TTL.IsUnknown() {
+		result.TTL = types.Int64Value(int64(records[0].TTL))
+	}
+	for _, record := range records {
+		if int64(record.TTL) != result.TTL.ValueInt64() {
+			result.TTL = types.Int64Value(int64(record.TTL))
+			break
+		}
+	}
+	return result
+}
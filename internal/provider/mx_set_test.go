@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestMXSetReconcile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := model.NewFakeDNSApiClient()
+	if err := client.CreateZone(ctx, model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY}); err != nil {
+		t.Fatal(err)
+	}
+
+	set := tfMXSet{
+		Zone:   types.StringValue("example.com"),
+		Domain: types.StringValue("example.com"),
+		Records: []tfMXRecord{
+			{Preference: types.Int64Value(10), Exchange: types.StringValue("mx1.example.com")},
+			{Preference: types.Int64Value(20), Exchange: types.StringValue("mx2.example.com")},
+		},
+		TTL: types.Int64Value(3600),
+	}
+	if err := reconcileRecordSet(ctx, client, nil, tfMXSet2model(set), mxChanged); err != nil {
+		t.Fatal(err)
+	}
+
+	// swap the preferences, replace mx2 with a backup
+	set.Records = []tfMXRecord{
+		{Preference: types.Int64Value(20), Exchange: types.StringValue("MX1.example.com.")},
+		{Preference: types.Int64Value(90), Exchange: types.StringValue("backup.example.net")},
+	}
+	current, err := readRecordSet(ctx, client, "example.com", "example.com", model.REC_MX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reconcileRecordSet(ctx, client, current, tfMXSet2model(set), mxChanged); err != nil {
+		t.Fatal(err)
+	}
+
+	current, err = readRecordSet(ctx, client, "example.com", "example.com", model.REC_MX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := mergeMXSetState(set, current)
+	want := map[string]int64{"MX1.example.com.": 20, "backup.example.net": 90}
+	if len(got.Records) != len(want) {
+		t.Fatalf("records = %+v, want %v", got.Records, want)
+	}
+	for _, record := range got.Records {
+		if preference, ok := want[record.Exchange.ValueString()]; !ok || preference != record.Preference.ValueInt64() {
+			t.Errorf("record %s with preference %d, want %v", record.Exchange, record.Preference.ValueInt64(), want)
+		}
+	}
+}
@@ -0,0 +1,510 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &NSDelegationResource{}
+	_ resource.ResourceWithConfigure      = &NSDelegationResource{}
+	_ resource.ResourceWithImportState    = &NSDelegationResource{}
+	_ resource.ResourceWithValidateConfig = &NSDelegationResource{}
+)
+
+// default TTL of the delegation NS records
+const DEFAULT_NS_DELEGATION_TTL = 3600
+
+type tfNSDelegation struct {
+	ID          types.String              `tfsdk:"id"`
+	Zone        types.String              `tfsdk:"zone"`
+	Domain      types.String              `tfsdk:"domain"`
+	NameServers []types.String            `tfsdk:"name_servers"`
+	Glue        map[string][]types.String `tfsdk:"glue"`
+	TTL         types.Int64               `tfsdk:"ttl"`
+	Timeouts    timeouts.Value            `tfsdk:"timeouts"`
+}
+
+// NSDelegationResource manages the delegation of a subdomain: the NS records
+// of the parent zone at the subdomain, with the glue addresses of their name
+// servers
+type NSDelegationResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func NSDelegationResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &NSDelegationResource{reqMutex: m}
+	}
+}
+
+func (r *NSDelegationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ns_delegation"
+}
+
+func (r *NSDelegationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Delegates a subdomain to other name servers: manages all the NS records of the parent zone at the subdomain, " +
+			"with the glue addresses of the name servers, as a whole. NS records at the subdomain that are not configured are deleted.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The delegated subdomain.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The parent zone holding the delegation. Defaults to the closest zone above `domain`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The delegated subdomain, e.g. `sub.example.com`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfOtherDomain(),
+				},
+			},
+			"name_servers": schema.SetAttribute{
+				MarkdownDescription: "The name servers the subdomain is delegated to.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"glue": schema.MapAttribute{
+				MarkdownDescription: "The glue addresses of name servers, by name server. " +
+					"Only needed for name servers within the delegated subdomain, which could not be resolved otherwise.",
+				ElementType: types.SetType{ElemType: types.StringType},
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ValueSetsAre(
+						setvalidator.SizeAtLeast(1),
+						setvalidator.ValueStringsAre(ipAddressValidator{}),
+					),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "The time-to-live (TTL) of the NS records, in seconds.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(DEFAULT_NS_DELEGATION_TTL),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 604800),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *NSDelegationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// glue is only known for the delegation name servers
+func (r *NSDelegationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var nameServers []types.String
+	var glue types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("glue"), &glue)...)
+	if !configSet(ctx, req.Config, "name_servers", &nameServers) || resp.Diagnostics.HasError() || glue.IsUnknown() {
+		return
+	}
+
+	for nameServer := range glue.Elements() {
+		known := slices.ContainsFunc(nameServers, func(value types.String) bool {
+			return value.IsUnknown() || sameHostName(value.ValueString(), nameServer)
+		})
+		if !known {
+			resp.Diagnostics.AddAttributeError(path.Root("glue").AtMapKey(nameServer), "Unknown name server",
+				fmt.Sprintf("'%s' is not one of name_servers, glue is only served for the delegation name servers", nameServer))
+		}
+	}
+}
+
+func (r *NSDelegationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfNSDelegation
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	ctx = tflog.SetField(ctx, "domain", planData.Domain.ValueString())
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	zone, ok := checkDelegationZone(ctx, r.client, planData, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	planData.Zone = types.StringValue(zone.Name)
+	planData.ID = planData.Domain
+
+	current, err := readRecordSet(ctx, r.client, zone.Name, domainToASCII(planData.Domain.ValueString()), model.REC_NS)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading NS records of %s: %s", planData.Domain.ValueString(), err))
+		return
+	}
+	if len(current) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("domain"), "Delegation already exists",
+			fmt.Sprintf("'%s' already has %d NS records in zone '%s': import them with terraform import, or delete them first",
+				planData.Domain.ValueString(), len(current), zone.Name))
+		return
+	}
+
+	if err := reconcileRecordSet(ctx, r.client, nil, tfNSDelegation2model(planData), delegationChanged); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Creating delegation of %s: %s", planData.Domain.ValueString(), err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *NSDelegationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateData tfNSDelegation
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "domain", stateData.Domain.ValueString())
+	tflog.Info(ctx, "read: start")
+	defer tflog.Info(ctx, "read: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	current, err := readRecordSet(ctx, r.client, stateData.Zone.ValueString(), domainToASCII(stateData.Domain.ValueString()), model.REC_NS)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading NS records of %s: %s", stateData.Domain.ValueString(), err))
+		return
+	}
+	if len(current) == 0 {
+		tflog.Warn(ctx, "read: delegation not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	stateData = mergeNSDelegationState(stateData, current)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func (r *NSDelegationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData tfNSDelegation
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "update")
+	ctx = tflog.SetField(ctx, "domain", planData.Domain.ValueString())
+	tflog.Info(ctx, "update: start")
+	defer tflog.Info(ctx, "update: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	current, err := readRecordSet(ctx, r.client, planData.Zone.ValueString(), domainToASCII(planData.Domain.ValueString()), model.REC_NS)
+	if err == nil {
+		err = reconcileRecordSet(ctx, r.client, current, tfNSDelegation2model(planData), delegationChanged)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Updating delegation of %s: %s", planData.Domain.ValueString(), err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *NSDelegationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateData tfNSDelegation
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "delete")
+	ctx = tflog.SetField(ctx, "domain", stateData.Domain.ValueString())
+	tflog.Info(ctx, "delete: start")
+	defer tflog.Info(ctx, "delete: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	deleteTimeout, diags := stateData.Timeouts.Delete(ctx, DEFAULT_DELETE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	current, err := readRecordSet(ctx, r.client, stateData.Zone.ValueString(), domainToASCII(stateData.Domain.ValueString()), model.REC_NS)
+	if err == nil {
+		err = reconcileRecordSet(ctx, r.client, current, nil, delegationChanged)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Deleting delegation of %s: %s", stateData.Domain.ValueString(), err))
+	}
+}
+
+// terraform import technitium_ns_delegation.example sub.example.com
+func (r *NSDelegationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = tflog.SetField(ctx, "operation", "import")
+	ctx = tflog.SetField(ctx, "domain", req.ID)
+	tflog.Info(ctx, "import: start")
+	defer tflog.Info(ctx, "import: end")
+
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
+
+	stateData := tfNSDelegation{
+		ID:       types.StringValue(req.ID),
+		Zone:     types.StringNull(),
+		Domain:   types.StringValue(req.ID),
+		TTL:      types.Int64Null(),
+		Timeouts: nullTimeouts(),
+	}
+	zone, ok := checkDelegationZone(ctx, r.client, stateData, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	stateData.Zone = types.StringValue(zone.Name)
+
+	current, err := readRecordSet(ctx, r.client, zone.Name, domainToASCII(req.ID), model.REC_NS)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading NS records of %s: %s", req.ID, err))
+		return
+	}
+	if len(current) == 0 {
+		resp.Diagnostics.AddError("Delegation not found",
+			fmt.Sprintf("'%s' has no NS records in zone '%s'", req.ID, zone.Name))
+		return
+	}
+
+	stateData = mergeNSDelegationState(stateData, current)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+// the zone named, or the closest one above the domain: delegations are only
+// managed in primary zones of the server, for subdomains the server does not
+// hold a zone of
+func checkDelegationZone(ctx context.Context, client model.DNSApiClient, data tfNSDelegation, diags *diag.Diagnostics) (model.DNSZone, bool) {
+	zones, err := client.ListZones(ctx)
+	if err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
+		return model.DNSZone{}, false
+	}
+
+	domain := strings.TrimSuffix(domainToASCII(data.Domain.ValueString()), ".")
+	zoneName := domainToASCII(data.Zone.ValueString())
+	for _, zone := range zones {
+		if sameDomainName(zone.Name, domain) {
+			diags.AddAttributeError(path.Root("domain"), "Subdomain is a zone",
+				fmt.Sprintf("'%s' is a zone of this server: delegations to it are only managed in parent zones of other servers", zone.Name))
+			return model.DNSZone{}, false
+		}
+	}
+
+	parent := ""
+	if _, above, ok := strings.Cut(domain, "."); ok {
+		parent = above
+	}
+	zone, found := recordZone(zones, zoneName, parent)
+	attrPath := path.Root("zone")
+	if zoneName == "" {
+		attrPath = path.Root("domain")
+	}
+	switch {
+	case !found:
+		diags.AddAttributeError(attrPath, "Zone not found",
+			fmt.Sprintf("No zone holds '%s': create one first, e.g. with a technitium_zone resource", data.Domain.ValueString()))
+	case !inZone(parent, zone.Name):
+		diags.AddAttributeError(attrPath, "Not a subdomain",
+			fmt.Sprintf("'%s' is not a subdomain of zone '%s'", data.Domain.ValueString(), zone.Name))
+	case zone.Internal:
+		diags.AddAttributeError(attrPath, "Read-only zone",
+			fmt.Sprintf("Zone '%s' is an internal zone of the DNS server, its records cannot be managed", zone.Name))
+	case isReadOnlyZoneType(zone.Type):
+		diags.AddAttributeError(attrPath, "Read-only zone",
+			fmt.Sprintf("Zone '%s' is a %s zone, its records come from the primary server and cannot be managed here", zone.Name, zone.Type))
+	default:
+		return zone, true
+	}
+	return zone, false
+}
+
+// one NS record per name server, its glue addresses sent with it
+func tfNSDelegation2model(data tfNSDelegation) []model.DNSRecord {
+	records := make([]model.DNSRecord, 0, len(data.NameServers))
+	for _, nameServer := range data.NameServers {
+		record := model.DNSRecord{
+			Type:       model.REC_NS,
+			Domain:     model.DNSRecordName(domainToASCII(data.Domain.ValueString())),
+			Zone:       data.Zone.ValueString(),
+			TTL:        model.DNSRecordTTL(data.TTL.ValueInt64()),
+			NameServer: strings.TrimSuffix(domainToASCII(nameServer.ValueString()), "."),
+		}
+		for glueServer, addresses := range data.Glue {
+			if sameHostName(glueServer, nameServer.ValueString()) {
+				record.Glue = strings.Join(tfStrings2model(addresses), ", ")
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func delegationChanged(current, desired model.DNSRecord) bool {
+	return current.TTL != desired.TTL || !slices.Equal(glueAddresses(current.Glue), glueAddresses(desired.Glue))
+}
+
+// the server lists glue addresses separated by commas
+func glueAddresses(glue string) []string {
+	addresses := []string{}
+	for _, address := range strings.Split(glue, ",") {
+		if address = strings.TrimSpace(address); address == "" {
+			continue
+		}
+		if ip := net.ParseIP(address); ip != nil {
+			address = ip.String()
+		}
+		addresses = append(addresses, address)
+	}
+	slices.Sort(addresses)
+	return addresses
+}
+
+// the delegation as found on the server, keeping the prior spelling of
+// names and addresses it does not change
+func mergeNSDelegationState(prior tfNSDelegation, records []model.DNSRecord) tfNSDelegation {
+	result := prior
+	result.ID = prior.Domain
+	result.NameServers = []types.String{}
+	result.Glue = nil
+	for _, record := range records {
+		nameServer := record.NameServer
+		for _, priorServer := range prior.NameServers {
+			if sameHostName(priorServer.ValueString(), nameServer) {
+				nameServer = priorServer.ValueString()
+			}
+		}
+		result.NameServers = append(result.NameServers, types.StringValue(nameServer))
+
+		addresses := glueAddresses(record.Glue)
+		if len(addresses) == 0 {
+			continue
+		}
+		glueServer := nameServer
+		priorAddresses := []types.String{}
+		for priorServer, priorGlue := range prior.Glue {
+			if sameHostName(priorServer, nameServer) {
+				glueServer, priorAddresses = priorServer, priorGlue
+			}
+		}
+		if result.Glue == nil {
+			result.Glue = map[string][]types.String{}
+		}
+		result.Glue[glueServer] = sameAddresses(priorAddresses, addresses)
+	}
+
+	if result.TTL.IsNull() || result.TTL.IsUnknown() {
+		result.TTL = types.Int64Value(int64(records[0].TTL))
+	}
+	for _, record := range records {
+		if int64(record.TTL) != result.TTL.ValueInt64() {
+			result.TTL = types.Int64Value(int64(record.TTL))
+			break
+		}
+	}
+	return result
+}
+
+// the prior addresses when they are the same ones, the ones found otherwise
+func sameAddresses(prior []types.String, addresses []string) []types.String {
+	if slices.Equal(glueAddresses(strings.Join(tfStrings2model(prior), ",")), addresses) {
+		return prior
+	}
+	return modelStrings2tf(addresses)
+}
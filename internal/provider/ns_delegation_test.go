@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestNSDelegationReconcile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := model.NewFakeDNSApiClient()
+	if err := client.CreateZone(ctx, model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY}); err != nil {
+		t.Fatal(err)
+	}
+	// not part of the delegation
+	if err := client.AddRecord(ctx, model.DNSRecord{Type: model.REC_A, Domain: "sub.example.com", IPAddress: "192.0.2.1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	delegation := tfNSDelegation{
+		Zone:   types.StringValue("example.com"),
+		Domain: types.StringValue("sub.example.com"),
+		NameServers: []types.String{
+			types.StringValue("ns1.sub.example.com"),
+			types.StringValue("ns2.sub.example.com"),
+		},
+		Glue: map[string][]types.String{
+			"ns1.sub.example.com": {types.StringValue("192.0.2.53")},
+			"ns2.sub.example.com": {types.StringValue("192.0.2.54")},
+		},
+		TTL: types.Int64Value(3600),
+	}
+	if err := reconcileRecordSet(ctx, client, nil, tfNSDelegation2model(delegation), delegationChanged); err != nil {
+		t.Fatal(err)
+	}
+
+	// drop a name server, add one without glue, change the glue of the other
+	delegation.NameServers = []types.String{
+		types.StringValue("NS1.sub.example.com."),
+		types.StringValue("ns.other.net"),
+	}
+	delegation.Glue = map[string][]types.String{
+		"NS1.sub.example.com.": {types.StringValue("2001:DB8::53"), types.StringValue("192.0.2.53")},
+	}
+	current, err := readRecordSet(ctx, client, "example.com", "sub.example.com", model.REC_NS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reconcileRecordSet(ctx, client, current, tfNSDelegation2model(delegation), delegationChanged); err != nil {
+		t.Fatal(err)
+	}
+
+	current, err = readRecordSet(ctx, client, "example.com", "sub.example.com", model.REC_NS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(current) != 2 {
+		t.Fatalf("NS records = %+v, want ns1.sub.example.com and ns.other.net", current)
+	}
+	got := mergeNSDelegationState(delegation, current)
+	if len(got.NameServers) != 2 || got.NameServers[0].ValueString() != "NS1.sub.example.com." || got.NameServers[1].ValueString() != "ns.other.net" {
+		t.Errorf("name_servers = %v, want the configured spelling", got.NameServers)
+	}
+	if glue := got.Glue["NS1.sub.example.com."]; len(glue) != 2 || glue[0].ValueString() != "2001:DB8::53" || len(got.Glue) != 1 {
+		t.Errorf("glue = %v, want the configured addresses of NS1.sub.example.com.", got.Glue)
+	}
+
+	// the A record at the subdomain is left alone
+	if err := reconcileRecordSet(ctx, client, current, nil, delegationChanged); err != nil {
+		t.Fatal(err)
+	}
+	records, err := client.GetRecords(ctx, "sub.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Type != model.REC_A {
+		t.Errorf("records = %+v, want the A record only", records)
+	}
+}
+
+func TestCheckDelegationZone(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := model.NewFakeDNSApiClient()
+	for _, name := range []string{"example.com", "hosted.example.com"} {
+		if err := client.CreateZone(ctx, model.DNSZone{Name: name, Type: model.ZONE_PRIMARY}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		zone   string
+		domain string
+		want   string // zone found, empty on error
+	}{
+		{domain: "sub.example.com", want: "example.com"},
+		{domain: "a.b.hosted.example.com", want: "hosted.example.com"},
+		{zone: "example.com", domain: "a.hosted.example.com", want: "example.com"},
+		{domain: "hosted.example.com"},
+		{domain: "example.com"},
+		{zone: "hosted.example.com", domain: "sub.example.com"},
+		{domain: "sub.example.org"},
+	}
+	for _, tt := range tests {
+		var diags diag.Diagnostics
+		data := tfNSDelegation{Zone: types.StringValue(tt.zone), Domain: types.StringValue(tt.domain)}
+		zone, ok := checkDelegationZone(ctx, client, data, &diags)
+		if ok != (tt.want != "") || ok == diags.HasError() || (ok && zone.Name != tt.want) {
+			t.Errorf("checkDelegationZone(%q, %q) = %q, %v, %v, want %q", tt.zone, tt.domain, zone.Name, ok, diags, tt.want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &OrphanedRecordsDataSource{}
+)
+
+type tfOrphanedRecords struct {
+	Zone         types.String      `tfsdk:"zone"`
+	Comment      types.String      `tfsdk:"comment"`
+	KnownRecords []tfKnownRecord   `tfsdk:"known_records"`
+	Records      []tfManagedRecord `tfsdk:"records"`
+}
+
+type tfKnownRecord struct {
+	Domain types.String `tfsdk:"domain"`
+	Type   types.String `tfsdk:"type"`
+	Value  types.String `tfsdk:"value"`
+}
+
+// OrphanedRecordsDataSource lists the records of a zone carrying the managed
+// comment that are not among the records known to the configuration
+type OrphanedRecordsDataSource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func OrphanedRecordsDataSourceFactory(m *sync.Mutex) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &OrphanedRecordsDataSource{reqMutex: m}
+	}
+}
+
+func (d *OrphanedRecordsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_orphaned_records"
+}
+
+func (d *OrphanedRecordsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the records of a zone carrying the comment terraform sets on the records it manages, but which are not " +
+			"among `known_records`: leftovers of deleted workspaces or resources removed from state, to be cleaned up or imported. " +
+			"`known_records` can be given the `technitium_record` resources directly, e.g. `values(technitium_record.all)`.",
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The zone to list records of.",
+				Required:            true,
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Text the record comments must contain. The provider `managed_comment` when not set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"known_records": schema.ListNestedAttribute{
+				MarkdownDescription: "Records managed by the configuration. Records of the zone matching none of them are orphaned.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The domain name of the record.",
+							Required:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The record type.",
+							Required:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The record value, in the format of the `value` part of import IDs. " +
+								"All the records of the type at the domain match when not set.",
+							Optional: true,
+						},
+					},
+				},
+			},
+			"records": schema.ListNestedAttribute{
+				MarkdownDescription: "Orphaned records, in server order.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The domain name of the record.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The record type.",
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "The record TTL.",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The record value, in the format of the `value` part of import IDs.",
+							Computed:            true,
+						},
+						"comments": schema.StringAttribute{
+							MarkdownDescription: "The record comments.",
+							Computed:            true,
+						},
+						"import_id": schema.StringAttribute{
+							MarkdownDescription: "ID to import the record with into a `technitium_record`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrphanedRecordsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrphanedRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config tfOrphanedRecords
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "zone", config.Zone.ValueString())
+	tflog.Info(ctx, "read orphaned records: start")
+	defer tflog.Info(ctx, "read orphaned records: end")
+	d.reqMutex.Lock()
+	defer d.reqMutex.Unlock()
+
+	if config.Comment.IsNull() || config.Comment.IsUnknown() {
+		config.Comment = types.StringValue(d.client.ManagedComment())
+	}
+
+	zoneName := domainToASCII(config.Zone.ValueString())
+	records, err := d.client.GetZoneRecords(ctx, zoneName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading records of zone %s: query failed: %s", zoneName, err))
+		return
+	}
+
+	config.Records = orphanedRecords(managedRecords(records, zoneName, config.Comment.ValueString()), config.KnownRecords)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// managed records matching none of the known ones
+func orphanedRecords(managed []tfManagedRecord, known []tfKnownRecord) []tfManagedRecord {
+	orphaned := []tfManagedRecord{}
+	for _, record := range managed {
+		isKnown := false
+		for _, knownRecord := range known {
+			if sameDomainName(knownRecord.Domain.ValueString(), record.Domain.ValueString()) &&
+				strings.EqualFold(knownRecord.Type.ValueString(), record.Type.ValueString()) &&
+				(knownRecord.Value.IsNull() || knownRecord.Value.ValueString() == record.Value.ValueString()) {
+				isKnown = true
+				break
+			}
+		}
+		if !isKnown {
+			orphaned = append(orphaned, record)
+		}
+	}
+	return orphaned
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOrphanedRecords(t *testing.T) {
+	t.Parallel()
+	managed := []tfManagedRecord{
+		{Domain: types.StringValue("www.example.com"), Type: types.StringValue("A"), Value: types.StringValue("192.0.2.1")},
+		{Domain: types.StringValue("www.example.com"), Type: types.StringValue("A"), Value: types.StringValue("192.0.2.2")},
+		{Domain: types.StringValue("example.com"), Type: types.StringValue("MX"), Value: types.StringValue("10:mail.example.com")},
+		{Domain: types.StringValue("old.example.com"), Type: types.StringValue("CNAME"), Value: types.StringValue("www.example.com")},
+	}
+	known := []tfKnownRecord{
+		{Domain: types.StringValue("WWW.example.com"), Type: types.StringValue("A"), Value: types.StringValue("192.0.2.1")},
+		{Domain: types.StringValue("example.com"), Type: types.StringValue("MX"), Value: types.StringNull()},
+	}
+
+	got := orphanedRecords(managed, known)
+	var gotValues []string
+	for _, record := range got {
+		gotValues = append(gotValues, record.Domain.ValueString()+" "+record.Value.ValueString())
+	}
+	want := []string{"www.example.com 192.0.2.2", "old.example.com www.example.com"}
+	if len(gotValues) != len(want) || gotValues[0] != want[0] || gotValues[1] != want[1] {
+		t.Errorf("orphaned records = %q, want %q", gotValues, want)
+	}
+}
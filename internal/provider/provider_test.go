@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEnvString(t *testing.T) {
+	t.Setenv("TECHNITIUM_BASE_PATH", "/dns")
+
+	if got := envString(types.StringNull(), "TECHNITIUM_BASE_PATH"); got != "/dns" {
+		t.Errorf("not configured: got %q, want the environment value", got)
+	}
+	if got := envString(types.StringValue("/api-dns"), "TECHNITIUM_BASE_PATH"); got != "/api-dns" {
+		t.Errorf("configured: got %q, want the configured value", got)
+	}
+	if got := envString(types.StringNull(), "TECHNITIUM_UNSET_FOR_TEST"); got != "" {
+		t.Errorf("unset: got %q, want empty", got)
+	}
+}
+
+func TestEnvBool(t *testing.T) {
+	t.Setenv("TECHNITIUM_READ_ONLY", "true")
+	t.Setenv("TECHNITIUM_DEBUG_HTTP", "sometimes")
+
+	var diags diag.Diagnostics
+	if !envBool(types.BoolNull(), "read_only", "TECHNITIUM_READ_ONLY", &diags) {
+		t.Error("not configured: got false, want the environment value")
+	}
+	if envBool(types.BoolValue(false), "read_only", "TECHNITIUM_READ_ONLY", &diags) {
+		t.Error("configured: got true, want the configured value")
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	envBool(types.BoolNull(), "debug_http", "TECHNITIUM_DEBUG_HTTP", &diags)
+	if !diags.HasError() {
+		t.Error("invalid environment value: got no error")
+	}
+}
+
+func TestCheckInsecureHTTP(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		url         string
+		allowed     bool
+		wantError   bool
+		wantWarning bool
+	}{
+		{name: "https", url: "https://dns.example.com"},
+		{name: "local host", url: "http://localhost:5380"},
+		{name: "loopback address", url: "http://127.0.0.1:5380"},
+		{name: "not allowed", url: "http://dns.example.com:5380", wantError: true},
+		{name: "allowed", url: "HTTP://192.0.2.1:5380", allowed: true, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var diags diag.Diagnostics
+			checkInsecureHTTP([]string{tt.url}, tt.allowed, &diags)
+			if diags.HasError() != tt.wantError {
+				t.Errorf("got errors %v, want error: %t", diags.Errors(), tt.wantError)
+			}
+			if gotWarning := diags.WarningsCount() > 0; gotWarning != tt.wantWarning {
+				t.Errorf("got warnings %v, want warning: %t", diags.Warnings(), tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// entries of a page, bounded for the page to fit in the state
+const (
+	DEFAULT_QUERY_LOG_ENTRIES_PER_PAGE = 100
+	MAX_QUERY_LOG_ENTRIES_PER_PAGE     = 1000
+)
+
+var _ datasource.DataSourceWithConfigure = &QueryLogsDataSource{}
+
+type tfQueryLogs struct {
+	AppName         types.String      `tfsdk:"app_name"`
+	ClassPath       types.String      `tfsdk:"class_path"`
+	PageNumber      types.Int64       `tfsdk:"page_number"`
+	EntriesPerPage  types.Int64       `tfsdk:"entries_per_page"`
+	DescendingOrder types.Bool        `tfsdk:"descending_order"`
+	Start           types.String      `tfsdk:"start"`
+	End             types.String      `tfsdk:"end"`
+	ClientIPAddress types.String      `tfsdk:"client_ip_address"`
+	Protocol        types.String      `tfsdk:"protocol"`
+	ResponseType    types.String      `tfsdk:"response_type"`
+	RCode           types.String      `tfsdk:"rcode"`
+	QName           types.String      `tfsdk:"qname"`
+	QType           types.String      `tfsdk:"qtype"`
+	TotalPages      types.Int64       `tfsdk:"total_pages"`
+	TotalEntries    types.Int64       `tfsdk:"total_entries"`
+	Entries         []tfQueryLogEntry `tfsdk:"entries"`
+}
+
+type tfQueryLogEntry struct {
+	RowNumber       types.Int64   `tfsdk:"row_number"`
+	Timestamp       types.String  `tfsdk:"timestamp"`
+	ClientIPAddress types.String  `tfsdk:"client_ip_address"`
+	Protocol        types.String  `tfsdk:"protocol"`
+	ResponseType    types.String  `tfsdk:"response_type"`
+	ResponseRtt     types.Float64 `tfsdk:"response_rtt"`
+	RCode           types.String  `tfsdk:"rcode"`
+	QName           types.String  `tfsdk:"qname"`
+	QType           types.String  `tfsdk:"qtype"`
+	QClass          types.String  `tfsdk:"qclass"`
+	Answer          types.String  `tfsdk:"answer"`
+}
+
+// QueryLogsDataSource searches the query logs kept by a query logger app, one
+// page at a time
+type QueryLogsDataSource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func QueryLogsDataSourceFactory(m *sync.Mutex) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &QueryLogsDataSource{reqMutex: m}
+	}
+}
+
+func (d *QueryLogsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_logs"
+}
+
+func (d *QueryLogsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Searches the DNS query logs kept by a query logger app, such as `" + model.APP_QUERY_LOGS_SQLITE + "`. " +
+			"The server filters, sorts and pages the entries: a single page is read, use `total_pages` to read the others.",
+		Attributes: map[string]schema.Attribute{
+			"app_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the installed app keeping the logs, `" + model.APP_QUERY_LOGS_SQLITE + "` by default.",
+				Optional:            true,
+			},
+			"class_path": schema.StringAttribute{
+				MarkdownDescription: "Class of the app serving the logs, `" + model.APP_QUERY_LOGS_SQLITE_CLASS + "` by default.",
+				Optional:            true,
+			},
+			"page_number": schema.Int64Attribute{
+				MarkdownDescription: "Page to read, from 1 (default).",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"entries_per_page": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of entries of a page, %d by default, %d at most.",
+					DEFAULT_QUERY_LOG_ENTRIES_PER_PAGE, MAX_QUERY_LOG_ENTRIES_PER_PAGE),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, MAX_QUERY_LOG_ENTRIES_PER_PAGE),
+				},
+			},
+			"descending_order": schema.BoolAttribute{
+				MarkdownDescription: "Sort the entries newest first (default), or oldest first when `false`.",
+				Optional:            true,
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "Only entries logged from this time, RFC 3339 timestamp.",
+				Optional:            true,
+			},
+			"end": schema.StringAttribute{
+				MarkdownDescription: "Only entries logged until this time, RFC 3339 timestamp.",
+				Optional:            true,
+			},
+			"client_ip_address": schema.StringAttribute{
+				MarkdownDescription: "Only queries from this client IP address.",
+				Optional:            true,
+			},
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "Only queries received over this protocol. Valid values are `Udp`, `Tcp`, `Tls`, `Https`, `Quic`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Udp", "Tcp", "Tls", "Https", "Quic"),
+				},
+			},
+			"response_type": schema.StringAttribute{
+				MarkdownDescription: "Only queries answered this way. Valid values are `Authoritative`, `Recursive`, `Cached`, `Blocked`, " +
+					"`UpstreamBlocked`, `UpstreamBlockedCached`, `CacheBlocked`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(model.QUERY_LOG_AUTHORITATIVE, model.QUERY_LOG_RECURSIVE, model.QUERY_LOG_CACHED,
+						model.QUERY_LOG_BLOCKED, model.QUERY_LOG_UPSTREAM_BLOCKED, model.QUERY_LOG_UPSTREAM_BLOCKED_CACHED,
+						model.QUERY_LOG_CACHE_BLOCKED),
+				},
+			},
+			"rcode": schema.StringAttribute{
+				MarkdownDescription: "Only queries answered with this response code, e.g. `NoError`, `NxDomain`, `ServerFailure`, `Refused`.",
+				Optional:            true,
+			},
+			"qname": schema.StringAttribute{
+				MarkdownDescription: "Only queries for this domain name.",
+				Optional:            true,
+			},
+			"qtype": schema.StringAttribute{
+				MarkdownDescription: "Only queries for this record type, e.g. `A`.",
+				Optional:            true,
+			},
+			"total_pages": schema.Int64Attribute{
+				MarkdownDescription: "Number of pages of the entries matching the filters.",
+				Computed:            true,
+			},
+			"total_entries": schema.Int64Attribute{
+				MarkdownDescription: "Number of entries matching the filters.",
+				Computed:            true,
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "Entries of the page, in the requested order.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"row_number": schema.Int64Attribute{
+							MarkdownDescription: "Position of the entry among all the matching ones.",
+							Computed:            true,
+						},
+						"timestamp": schema.StringAttribute{
+							MarkdownDescription: "When the query was received.",
+							Computed:            true,
+						},
+						"client_ip_address": schema.StringAttribute{
+							MarkdownDescription: "IP address of the client.",
+							Computed:            true,
+						},
+						"protocol": schema.StringAttribute{
+							MarkdownDescription: "Protocol the query was received over.",
+							Computed:            true,
+						},
+						"response_type": schema.StringAttribute{
+							MarkdownDescription: "How the query was answered.",
+							Computed:            true,
+						},
+						"response_rtt": schema.Float64Attribute{
+							MarkdownDescription: "Round trip time of the upstream answer in milliseconds, null for answers not from upstream.",
+							Computed:            true,
+						},
+						"rcode": schema.StringAttribute{
+							MarkdownDescription: "Response code.",
+							Computed:            true,
+						},
+						"qname": schema.StringAttribute{
+							MarkdownDescription: "Queried domain name.",
+							Computed:            true,
+						},
+						"qtype": schema.StringAttribute{
+							MarkdownDescription: "Queried record type.",
+							Computed:            true,
+						},
+						"qclass": schema.StringAttribute{
+							MarkdownDescription: "Queried class.",
+							Computed:            true,
+						},
+						"answer": schema.StringAttribute{
+							MarkdownDescription: "Answer records, as logged by the app.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *QueryLogsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *QueryLogsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config tfQueryLogs
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for attrName, value := range map[string]types.String{"start": config.Start, "end": config.End} {
+		if value.IsNull() {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attrName), "Invalid timestamp",
+				fmt.Sprintf("%s must be an RFC 3339 timestamp: %s", attrName, err))
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := queryLogQuery(config)
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "app_name", query.AppName)
+	tflog.Info(ctx, "read query logs: start")
+	defer tflog.Info(ctx, "read query logs: end")
+	d.reqMutex.Lock()
+	defer d.reqMutex.Unlock()
+
+	page, err := d.client.QueryLogs(ctx, query)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading query logs of app %s: query failed: %s", query.AppName, err))
+		return
+	}
+
+	config.TotalPages = types.Int64Value(page.TotalPages)
+	config.TotalEntries = types.Int64Value(page.TotalEntries)
+	config.Entries = make([]tfQueryLogEntry, len(page.Entries))
+	for i, entry := range page.Entries {
+		config.Entries[i] = tfQueryLogEntry{
+			RowNumber:       types.Int64Value(entry.RowNumber),
+			Timestamp:       types.StringValue(entry.Timestamp),
+			ClientIPAddress: types.StringValue(entry.ClientIPAddress),
+			Protocol:        types.StringValue(entry.Protocol),
+			ResponseType:    types.StringValue(entry.ResponseType),
+			ResponseRtt:     types.Float64PointerValue(entry.ResponseRtt),
+			RCode:           types.StringValue(entry.RCode),
+			QName:           types.StringValue(entry.QName),
+			QType:           types.StringValue(entry.QType),
+			QClass:          types.StringValue(entry.QClass),
+			Answer:          types.StringValue(entry.Answer),
+		}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// search of the configuration, with the defaults of the attributes not set
+func queryLogQuery(config tfQueryLogs) model.DNSQueryLogQuery {
+	query := model.DNSQueryLogQuery{
+		AppName:         model.APP_QUERY_LOGS_SQLITE,
+		ClassPath:       model.APP_QUERY_LOGS_SQLITE_CLASS,
+		PageNumber:      1,
+		EntriesPerPage:  DEFAULT_QUERY_LOG_ENTRIES_PER_PAGE,
+		DescendingOrder: true,
+		Start:           config.Start.ValueString(),
+		End:             config.End.ValueString(),
+		ClientIPAddress: config.ClientIPAddress.ValueString(),
+		Protocol:        config.Protocol.ValueString(),
+		ResponseType:    config.ResponseType.ValueString(),
+		RCode:           config.RCode.ValueString(),
+		QName:           config.QName.ValueString(),
+		QType:           config.QType.ValueString(),
+	}
+	if !config.AppName.IsNull() {
+		query.AppName = config.AppName.ValueString()
+	}
+	if !config.ClassPath.IsNull() {
+		query.ClassPath = config.ClassPath.ValueString()
+	}
+	if !config.PageNumber.IsNull() {
+		query.PageNumber = config.PageNumber.ValueInt64()
+	}
+	if !config.EntriesPerPage.IsNull() {
+		query.EntriesPerPage = config.EntriesPerPage.ValueInt64()
+	}
+	if !config.DescendingOrder.IsNull() {
+		query.DescendingOrder = config.DescendingOrder.ValueBool()
+	}
+	return query
+}
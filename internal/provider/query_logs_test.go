@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestQueryLogQuery(t *testing.T) {
+	t.Parallel()
+
+	got := queryLogQuery(tfQueryLogs{ResponseType: types.StringValue(model.QUERY_LOG_BLOCKED)})
+	want := model.DNSQueryLogQuery{
+		AppName:         model.APP_QUERY_LOGS_SQLITE,
+		ClassPath:       model.APP_QUERY_LOGS_SQLITE_CLASS,
+		PageNumber:      1,
+		EntriesPerPage:  DEFAULT_QUERY_LOG_ENTRIES_PER_PAGE,
+		DescendingOrder: true,
+		ResponseType:    model.QUERY_LOG_BLOCKED,
+	}
+	if got != want {
+		t.Errorf("defaults: got %+v, want %+v", got, want)
+	}
+
+	got = queryLogQuery(tfQueryLogs{
+		PageNumber:      types.Int64Value(3),
+		EntriesPerPage:  types.Int64Value(20),
+		DescendingOrder: types.BoolValue(false),
+	})
+	if got.PageNumber != 3 || got.EntriesPerPage != 20 || got.DescendingOrder {
+		t.Errorf("configured paging: got %+v", got)
+	}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+	"github.com/pkg/errors"
+)
+
+// wraps API client in read_only mode: reads pass through, changes are refused.
+type readOnlyClient struct {
+	model.DNSApiClient
+}
+
+var _ model.DNSApiClient = readOnlyClient{}
+
+func errReadOnly(operation string) error {
+	return errors.Errorf("provider is configured with read_only = true, refusing to %s", operation)
+}
+
+func (c readOnlyClient) AddRecord(ctx context.Context, record model.DNSRecord) error {
+	return errReadOnly("add record " + string(record.Domain) + " " + string(record.Type))
+}
+
+func (c readOnlyClient) UpdateRecord(ctx context.Context, oldRecord model.DNSRecord, newRecord model.DNSRecord) error {
+	return errReadOnly("update record " + string(oldRecord.Domain) + " " + string(oldRecord.Type))
+}
+
+func (c readOnlyClient) DeleteRecord(ctx context.Context, record model.DNSRecord) error {
+	return errReadOnly("delete record " + string(record.Domain) + " " + string(record.Type))
+}
+
+func (c readOnlyClient) CreateZone(ctx context.Context, zone model.DNSZone) error {
+	return errReadOnly("create zone " + zone.Name)
+}
+
+func (c readOnlyClient) DeleteZone(ctx context.Context, zoneName string) error {
+	return errReadOnly("delete zone " + zoneName)
+}
+
+func (c readOnlyClient) ResyncZone(ctx context.Context, zoneName string) error {
+	return errReadOnly("resync zone " + zoneName)
+}
+
+func (c readOnlyClient) SetZoneOptions(ctx context.Context, zoneName string, options model.DNSZoneOptionsUpdate) error {
+	return errReadOnly("set options of zone " + zoneName)
+}
+
+func (c readOnlyClient) SignZone(ctx context.Context, zoneName string, signing model.DNSZoneSigning) error {
+	return errReadOnly("sign zone " + zoneName)
+}
+
+func (c readOnlyClient) UnsignZone(ctx context.Context, zoneName string) error {
+	return errReadOnly("unsign zone " + zoneName)
+}
+
+func (c readOnlyClient) UpdateDnsKeyTtl(ctx context.Context, zoneName string, ttl int64) error {
+	return errReadOnly("update DNSKEY TTL of zone " + zoneName)
+}
+
+func (c readOnlyClient) UpdateDnssecKeyRollover(ctx context.Context, zoneName string, keyType string, rolloverDays int64) error {
+	return errReadOnly("update DNSSEC key rollover of zone " + zoneName)
+}
+
+func (c readOnlyClient) RolloverDnssecAlgorithm(ctx context.Context, zoneName string, signing model.DNSZoneSigning) error {
+	return errReadOnly("roll over DNSSEC algorithm of zone " + zoneName)
+}
+
+func (c readOnlyClient) UpdateDnssecNxProof(ctx context.Context, zoneName string, nxProof string, iterations int64, saltLength int64) error {
+	return errReadOnly("update DNSSEC proof of non-existence of zone " + zoneName)
+}
+
+func (c readOnlyClient) RemoveDhcpLease(ctx context.Context, scopeName string, clientIdentifier string) error {
+	return errReadOnly("remove DHCP lease " + clientIdentifier + " of scope " + scopeName)
+}
+
+func (c readOnlyClient) ForceUpdateBlockLists(ctx context.Context) error {
+	return errReadOnly("force update of block lists")
+}
+
+func (c readOnlyClient) SetSettings(ctx context.Context, settings model.DNSSettingsUpdate) error {
+	return errReadOnly("set server settings")
+}
+
+func (c readOnlyClient) SetAppConfig(ctx context.Context, appName string, config string) error {
+	return errReadOnly("set config of app " + appName)
+}
@@ -2,13 +2,18 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,77 +28,95 @@ import (
 // import separator
 const IMPORT_SEP = ":"
 
+// private state key for the PTR record created along with A/AAAA record
+const PTR_PRIVATE_KEY = "ptr"
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &RecordResource{}
-	_ resource.ResourceWithConfigure   = &RecordResource{}
-	_ resource.ResourceWithImportState = &RecordResource{}
+	_ resource.Resource                   = &RecordResource{}
+	_ resource.ResourceWithConfigure      = &RecordResource{}
+	_ resource.ResourceWithImportState    = &RecordResource{}
+	_ resource.ResourceWithValidateConfig = &RecordResource{}
+	_ resource.ResourceWithModifyPlan     = &RecordResource{}
 )
 
 type tfDNSRecord struct {
-	Zone                           types.String `tfsdk:"zone"`
-	Type                           types.String `tfsdk:"type"`
-	Domain                         types.String `tfsdk:"domain"`
-	TTL                            types.Int64  `tfsdk:"ttl"`
-	IPAddress                      types.String `tfsdk:"ip_address"`
-	Ptr                            types.Bool   `tfsdk:"ptr"`
-	CreatePtrZone                  types.Bool   `tfsdk:"create_ptr_zone"`
-	UpdateSvcbHints                types.Bool   `tfsdk:"update_svcb_hints"`
-	NameServer                     types.String `tfsdk:"name_server"`
-	Glue                           types.String `tfsdk:"glue"`
-	CName                          types.String `tfsdk:"cname"`
-	PtrName                        types.String `tfsdk:"ptr_name"`
-	Exchange                       types.String `tfsdk:"exchange"`
-	Preference                     types.Int64  `tfsdk:"preference"`
-	Text                           types.String `tfsdk:"text"`
-	SplitText                      types.Bool   `tfsdk:"split_text"`
-	Mailbox                        types.String `tfsdk:"mailbox"`
-	TxtDomain                      types.String `tfsdk:"txt_domain"`
-	Priority                       types.Int64  `tfsdk:"priority"`
-	Weight                         types.Int64  `tfsdk:"weight"`
-	Port                           types.Int64  `tfsdk:"port"`
-	Target                         types.String `tfsdk:"target"`
-	NaptrOrder                     types.Int64  `tfsdk:"naptr_order"`
-	NaptrPreference                types.Int64  `tfsdk:"naptr_preference"`
-	NaptrFlags                     types.String `tfsdk:"naptr_flags"`
-	NaptrServices                  types.String `tfsdk:"naptr_services"`
-	NaptrRegexp                    types.String `tfsdk:"naptr_regexp"`
-	NaptrReplacement               types.String `tfsdk:"naptr_replacement"`
-	DName                          types.String `tfsdk:"dname"`
-	KeyTag                         types.Int64  `tfsdk:"key_tag"`
-	Algorithm                      types.String `tfsdk:"algorithm"`
-	DigestType                     types.String `tfsdk:"digest_type"`
-	Digest                         types.String `tfsdk:"digest"`
-	SshfpAlgorithm                 types.String `tfsdk:"sshfp_algorithm"`
-	SshfpFingerprintType           types.String `tfsdk:"sshfp_fingerprint_type"`
-	SshfpFingerprint               types.String `tfsdk:"sshfp_fingerprint"`
-	TlsaCertificateUsage           types.String `tfsdk:"tlsa_certificate_usage"`
-	TlsaSelector                   types.String `tfsdk:"tlsa_selector"`
-	TlsaMatchingType               types.String `tfsdk:"tlsa_matching_type"`
-	TlsaCertificateAssociationData types.String `tfsdk:"tlsa_certificate_association_data"`
-	SvcPriority                    types.Int64  `tfsdk:"svc_priority"`
-	SvcTargetName                  types.String `tfsdk:"svc_target_name"`
-	SvcParams                      types.String `tfsdk:"svc_params"`
-	AutoIpv4Hint                   types.Bool   `tfsdk:"auto_ipv4_hint"`
-	AutoIpv6Hint                   types.Bool   `tfsdk:"auto_ipv6_hint"`
-	UriPriority                    types.Int64  `tfsdk:"uri_priority"`
-	UriWeight                      types.Int64  `tfsdk:"uri_weight"`
-	Uri                            types.String `tfsdk:"uri"`
-	Flags                          types.String `tfsdk:"flags"`
-	Tag                            types.String `tfsdk:"tag"`
-	Value                          types.String `tfsdk:"value"`
-	AName                          types.String `tfsdk:"aname"`
-	Forwarder                      types.String `tfsdk:"forwarder"`
-	ForwarderPriority              types.Int64  `tfsdk:"forwarder_priority"`
-	DnssecValidation               types.Bool   `tfsdk:"dnssec_validation"`
-	ProxyType                      types.String `tfsdk:"proxy_type"`
-	ProxyAddress                   types.String `tfsdk:"proxy_address"`
-	ProxyPort                      types.Int64  `tfsdk:"proxy_port"`
-	ProxyUsername                  types.String `tfsdk:"proxy_username"`
-	ProxyPassword                  types.String `tfsdk:"proxy_password"`
-	AppName                        types.String `tfsdk:"app_name"`
-	ClassPath                      types.String `tfsdk:"class_path"`
-	RecordData                     types.String `tfsdk:"record_data"`
+	Zone                           types.String   `tfsdk:"zone"`
+	Type                           types.String   `tfsdk:"type"`
+	Domain                         types.String   `tfsdk:"domain"`
+	Name                           types.String   `tfsdk:"name"`
+	Fqdn                           types.String   `tfsdk:"fqdn"`
+	TTL                            types.Int64    `tfsdk:"ttl"`
+	IPAddress                      types.String   `tfsdk:"ip_address"`
+	Ptr                            types.Bool     `tfsdk:"ptr"`
+	ManagePtr                      types.Bool     `tfsdk:"manage_ptr"`
+	CreatePtrZone                  types.Bool     `tfsdk:"create_ptr_zone"`
+	OverwriteOnCreate              types.Bool     `tfsdk:"overwrite_on_create"`
+	UpdateSvcbHints                types.Bool     `tfsdk:"update_svcb_hints"`
+	NameServer                     types.String   `tfsdk:"name_server"`
+	Glue                           types.String   `tfsdk:"glue"`
+	CName                          types.String   `tfsdk:"cname"`
+	PtrName                        types.String   `tfsdk:"ptr_name"`
+	Exchange                       types.String   `tfsdk:"exchange"`
+	Preference                     types.Int64    `tfsdk:"preference"`
+	Text                           types.String   `tfsdk:"text"`
+	SplitText                      types.Bool     `tfsdk:"split_text"`
+	Mailbox                        types.String   `tfsdk:"mailbox"`
+	TxtDomain                      types.String   `tfsdk:"txt_domain"`
+	Priority                       types.Int64    `tfsdk:"priority"`
+	Weight                         types.Int64    `tfsdk:"weight"`
+	Port                           types.Int64    `tfsdk:"port"`
+	Target                         types.String   `tfsdk:"target"`
+	NaptrOrder                     types.Int64    `tfsdk:"naptr_order"`
+	NaptrPreference                types.Int64    `tfsdk:"naptr_preference"`
+	NaptrFlags                     types.String   `tfsdk:"naptr_flags"`
+	NaptrServices                  types.String   `tfsdk:"naptr_services"`
+	NaptrRegexp                    types.String   `tfsdk:"naptr_regexp"`
+	NaptrReplacement               types.String   `tfsdk:"naptr_replacement"`
+	DName                          types.String   `tfsdk:"dname"`
+	KeyTag                         types.Int64    `tfsdk:"key_tag"`
+	Algorithm                      types.String   `tfsdk:"algorithm"`
+	DigestType                     types.String   `tfsdk:"digest_type"`
+	Digest                         types.String   `tfsdk:"digest"`
+	SshfpAlgorithm                 types.String   `tfsdk:"sshfp_algorithm"`
+	SshfpFingerprintType           types.String   `tfsdk:"sshfp_fingerprint_type"`
+	SshfpFingerprint               types.String   `tfsdk:"sshfp_fingerprint"`
+	TlsaCertificateUsage           types.String   `tfsdk:"tlsa_certificate_usage"`
+	TlsaSelector                   types.String   `tfsdk:"tlsa_selector"`
+	TlsaMatchingType               types.String   `tfsdk:"tlsa_matching_type"`
+	TlsaCertificateAssociationData types.String   `tfsdk:"tlsa_certificate_association_data"`
+	SvcPriority                    types.Int64    `tfsdk:"svc_priority"`
+	SvcTargetName                  types.String   `tfsdk:"svc_target_name"`
+	SvcParams                      types.String   `tfsdk:"svc_params"`
+	AutoIpv4Hint                   types.Bool     `tfsdk:"auto_ipv4_hint"`
+	AutoIpv6Hint                   types.Bool     `tfsdk:"auto_ipv6_hint"`
+	UriPriority                    types.Int64    `tfsdk:"uri_priority"`
+	UriWeight                      types.Int64    `tfsdk:"uri_weight"`
+	Uri                            types.String   `tfsdk:"uri"`
+	Flags                          types.String   `tfsdk:"flags"`
+	Tag                            types.String   `tfsdk:"tag"`
+	Value                          types.String   `tfsdk:"value"`
+	AName                          types.String   `tfsdk:"aname"`
+	Forwarder                      types.String   `tfsdk:"forwarder"`
+	ForwarderPriority              types.Int64    `tfsdk:"forwarder_priority"`
+	DnssecValidation               types.Bool     `tfsdk:"dnssec_validation"`
+	ProxyType                      types.String   `tfsdk:"proxy_type"`
+	ProxyAddress                   types.String   `tfsdk:"proxy_address"`
+	ProxyPort                      types.Int64    `tfsdk:"proxy_port"`
+	ProxyUsername                  types.String   `tfsdk:"proxy_username"`
+	ProxyPassword                  types.String   `tfsdk:"proxy_password"`
+	ProxyPasswordWO                types.String   `tfsdk:"proxy_password_wo"`
+	ProxyPasswordWOVersion         types.Int64    `tfsdk:"proxy_password_wo_version"`
+	AppName                        types.String   `tfsdk:"app_name"`
+	ClassPath                      types.String   `tfsdk:"class_path"`
+	RecordData                     types.String   `tfsdk:"record_data"`
+	AppAnswers                     types.Map      `tfsdk:"app_answers"`
+	AppCnameAnswers                types.Map      `tfsdk:"app_cname_answers"`
+	ExtraRData                     types.Map      `tfsdk:"extra_rdata"`
+	Timeouts                       timeouts.Value `tfsdk:"timeouts"`
+
+	Verify             *tfRecordVerify       `tfsdk:"verify"`
+	WaitForSecondaries *tfWaitForSecondaries `tfsdk:"wait_for_secondaries"`
 }
 
 // RecordResource defines the implementation of Technitium DNS records
@@ -117,8 +140,15 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		MarkdownDescription: "Manages a DNS record in Technitium DNS Server.",
 		Attributes: map[string]schema.Attribute{
 			"zone": schema.StringAttribute{
-				MarkdownDescription: "The DNS zone name. If not specified, it will be inferred from the domain.",
-				Optional:            true,
+				MarkdownDescription: "The DNS zone name. If not specified, it will be inferred from the domain. " +
+					"Set it from the zone resource, e.g. `technitium_zone.example.name`, so that the record is created after " +
+					"the zone and deleted before it without `depends_on`. The domain must be in the zone. " +
+					"When not set, the zone given on import is kept.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					recordZoneModifier{},
+				},
 			},
 			"type": schema.StringAttribute{
 				MarkdownDescription: "The DNS record type (e.g., A, AAAA, CNAME, etc.).",
@@ -131,10 +161,37 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The domain name for the DNS record (FQN)",
-				Required:            true,
+				MarkdownDescription: "The domain name for the DNS record (FQN). Internationalized names can be given in Unicode or punycode form. " +
+					"Built from `name` and `zone` when these are used instead.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					recordDomainModifier{},
+					requiresReplaceIfOtherDomain(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the record relative to `zone`, `@` for the zone apex, instead of `domain`. " +
+					"Computed from `domain` otherwise, relative to `zone` or to the closest zone of the server holding it.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("zone")),
+				},
+				PlanModifiers: []planmodifier.String{
+					recordNameModifier{},
+				},
+			},
+			"fqdn": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified domain name of the record, in lowercase punycode form and without trailing dot, " +
+					"whichever of `domain` or `name` is used.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					recordFqdnModifier{},
 				},
 			},
 			"ttl": schema.Int64Attribute{
@@ -152,10 +209,20 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Specifies if this record should create a PTR record for A/AAAA types.",
 				Optional:            true,
 			},
+			"manage_ptr": schema.BoolAttribute{
+				MarkdownDescription: "Delete the PTR record created with `ptr` when this record is destroyed.",
+				Optional:            true,
+			},
 			"create_ptr_zone": schema.BoolAttribute{
 				MarkdownDescription: "Specifies if the PTR zone should be automatically created for A/AAAA records.",
 				Optional:            true,
 			},
+			"overwrite_on_create": schema.BoolAttribute{
+				MarkdownDescription: "Replace the records of the same type and domain already on the server when creating this record, " +
+					"e.g. a stray CNAME left by a manual edit, instead of failing. " +
+					"For types with several records per domain (A, TXT, ...) this removes the other records too.",
+				Optional: true,
+			},
 			"update_svcb_hints": schema.BoolAttribute{
 				MarkdownDescription: "Whether to update SVCB hints for this record.",
 				Optional:            true,
@@ -183,6 +250,9 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"preference": schema.Int64Attribute{
 				MarkdownDescription: "The priority for MX records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"text": schema.StringAttribute{
 				MarkdownDescription: "The text value for TXT records.",
@@ -203,14 +273,23 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"priority": schema.Int64Attribute{
 				MarkdownDescription: "The priority for SRV records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"weight": schema.Int64Attribute{
 				MarkdownDescription: "The weight for SRV records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"port": schema.Int64Attribute{
 				MarkdownDescription: "The port for SRV records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"target": schema.StringAttribute{
 				MarkdownDescription: "The target for SRV records.",
@@ -219,10 +298,16 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"naptr_order": schema.Int64Attribute{
 				MarkdownDescription: "The order for NAPTR records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"naptr_preference": schema.Int64Attribute{
 				MarkdownDescription: "The preference for NAPTR records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"naptr_flags": schema.StringAttribute{
 				MarkdownDescription: "The flags for NAPTR records.",
@@ -247,6 +332,9 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"key_tag": schema.Int64Attribute{
 				MarkdownDescription: "The key tag for DS records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"algorithm": schema.StringAttribute{
 				MarkdownDescription: "The algorithm for DS records.",
@@ -291,6 +379,9 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"svc_priority": schema.Int64Attribute{
 				MarkdownDescription: "The priority for SVCB/HTTPS records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"svc_target_name": schema.StringAttribute{
 				MarkdownDescription: "The target name for SVCB/HTTPS records.",
@@ -311,10 +402,16 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"uri_priority": schema.Int64Attribute{
 				MarkdownDescription: "The priority for URI records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"uri_weight": schema.Int64Attribute{
 				MarkdownDescription: "The weight for URI records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"uri": schema.StringAttribute{
 				MarkdownDescription: "The URI for URI records.",
@@ -343,6 +440,9 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"forwarder_priority": schema.Int64Attribute{
 				MarkdownDescription: "The priority for FWD records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"dnssec_validation": schema.BoolAttribute{
 				MarkdownDescription: "Whether DNSSEC validation is enabled for FWD records.",
@@ -359,6 +459,9 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"proxy_port": schema.Int64Attribute{
 				MarkdownDescription: "The proxy port for FWD records.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"proxy_username": schema.StringAttribute{
 				MarkdownDescription: "The proxy username for FWD records.",
@@ -369,19 +472,58 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:            true,
 				Sensitive:           true,
 			},
+			PROXY_PASSWORD_WO:         proxyPasswordWOAttribute(),
+			PROXY_PASSWORD_WO_VERSION: proxyPasswordWOVersionAttribute(),
 			"app_name": schema.StringAttribute{
-				MarkdownDescription: "The app name for APP records.",
+				MarkdownDescription: "The app name for APP records, checked against the installed apps when planning.",
 				Optional:            true,
 			},
 			"class_path": schema.StringAttribute{
-				MarkdownDescription: "The class path for APP records.",
+				MarkdownDescription: "The class path for APP records, must be an APP record handler of the app.",
 				Optional:            true,
 			},
 			"record_data": schema.StringAttribute{
-				MarkdownDescription: "The record data for APP records.",
+				MarkdownDescription: "The record data for APP records. Built from `app_answers` or `app_cname_answers` when these are used instead.",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					appRecordDataModifier{},
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("app_answers"), path.MatchRoot("app_cname_answers")),
+				},
+			},
+			"app_answers": schema.MapAttribute{
+				MarkdownDescription: "Typed answers of `GeoContinent.Address`, `GeoCountry.Address` and `SplitHorizon.SimpleAddress` APP records, " +
+					"keyed by continent code, country code or network (name or CIDR), `default` for all other clients. " +
+					"E.g. `{ EU = [\"192.0.2.1\"], default = [\"192.0.2.2\"] }`.",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ConflictsWith(path.MatchRoot("app_cname_answers")),
+				},
+			},
+			"app_cname_answers": schema.MapAttribute{
+				MarkdownDescription: "Typed answers of `GeoContinent.CNAME`, `GeoCountry.CNAME` and `SplitHorizon.SimpleCNAME` APP records, " +
+					"keyed like `app_answers` with the domain name to answer with.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"extra_rdata": schema.MapAttribute{
+				MarkdownDescription: "Raw API parameters appended as is to add and update requests, for server parameters the provider " +
+					"does not model yet. E.g. `{ newParameter = \"value\" }`. They are not read back, so drift is not detected.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"verify":               verifyBlock(),
+			"wait_for_secondaries": waitForSecondariesBlock(),
+			"timeouts":             timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -403,6 +545,328 @@ func (r *RecordResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+func (r *RecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateAppAnswers(ctx, req.Config, &resp.Diagnostics)
+}
+
+// the domain must be in the zone, which is usually a reference to a zone
+// resource: it is only known at plan time
+func validateRecordZone(tfRec tfDNSRecord, diags *diag.Diagnostics) {
+	zone, domain := tfRec.Zone, tfRec.Domain
+	if zone.IsNull() || zone.IsUnknown() || domain.IsNull() || domain.IsUnknown() {
+		return
+	}
+	if !inZone(domainToASCII(domain.ValueString()), domainToASCII(zone.ValueString())) {
+		diags.AddAttributeError(path.Root("domain"), "Domain outside of zone",
+			fmt.Sprintf("Domain '%s' is not in zone '%s'", domain.ValueString(), zone.ValueString()))
+	}
+}
+
+// the zone of a new or moved record is checked not to be read-only, and APP
+// records are checked against the installed apps when app_name or class_path
+// change, as the server only reports both vaguely on apply
+func (r *RecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planData, stateData tfDNSRecord
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	isCreate := req.State.Raw.IsNull()
+	if !isCreate {
+		resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateRecordZone(planData, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || r.client == nil {
+		return
+	}
+
+	checkZone := !planData.Zone.IsUnknown() && !planData.Domain.IsUnknown() &&
+		(isCreate || !planData.Zone.Equal(stateData.Zone) || !planData.Domain.Equal(stateData.Domain))
+	checkApp := planData.Type.ValueString() == string(model.REC_APP) &&
+		!planData.AppName.IsUnknown() && !planData.ClassPath.IsUnknown() &&
+		(isCreate || !planData.AppName.Equal(stateData.AppName) || !planData.ClassPath.Equal(stateData.ClassPath))
+	if !checkZone && !checkApp {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "plan")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
+
+	// the zone may be created in the same apply, its absence is only an error on create
+	if checkZone {
+		checkRecordZone(ctx, r.client, planData, false, &resp.Diagnostics)
+	}
+	// a zone set on a record created without one keeps the record in place
+	// when it is the zone the server put the record in
+	if checkZone && !isCreate && stateData.Zone.IsNull() {
+		zone, found := r.inferredZone(ctx, stateData.Domain.ValueString(), &resp.Diagnostics)
+		if !found || !sameHostName(zone.Name, planData.Zone.ValueString()) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("zone"))
+		}
+	}
+	if checkApp {
+		ctx = tflog.SetField(ctx, "app", planData.AppName.ValueString())
+		checkAppRecordHandler(ctx, r.client, planData.AppName.ValueString(), planData.ClassPath.ValueString(), &resp.Diagnostics)
+	}
+}
+
+// records can only be added to existing zones the server lets us write to:
+// report an error otherwise, naming the zone, as the API error is generic
+func checkRecordZone(ctx context.Context, client model.DNSApiClient, tfRec tfDNSRecord, mustExist bool, diags *diag.Diagnostics) (model.DNSZone, bool) {
+	zones, err := client.ListZones(ctx)
+	if err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
+		return model.DNSZone{}, false
+	}
+
+	zone, found := recordZone(zones, domainToASCII(tfRec.Zone.ValueString()), domainToASCII(tfRec.Domain.ValueString()))
+	attrPath := path.Root("zone")
+	if tfRec.Zone.ValueString() == "" {
+		attrPath = path.Root("domain")
+	}
+	switch {
+	case !found && !mustExist:
+	case !found && tfRec.Zone.ValueString() != "":
+		diags.AddAttributeError(attrPath, "Zone not found",
+			fmt.Sprintf("Zone '%s' does not exist: create it first, e.g. with a technitium_zone resource", tfRec.Zone.ValueString()))
+	case !found:
+		diags.AddAttributeError(attrPath, "Zone not found",
+			fmt.Sprintf("No zone holds '%s': create one first, e.g. with a technitium_zone resource", tfRec.Domain.ValueString()))
+	case zone.Internal:
+		diags.AddAttributeError(attrPath, "Read-only zone",
+			fmt.Sprintf("Zone '%s' is an internal zone of the DNS server, its records cannot be managed", zone.Name))
+	case isReadOnlyZoneType(zone.Type):
+		diags.AddAttributeError(attrPath, "Read-only zone",
+			fmt.Sprintf("Zone '%s' is a %s zone, its records come from the primary server and cannot be managed here", zone.Name, zone.Type))
+	}
+	return zone, found
+}
+
+// the zone named, or without a name the closest one holding the domain
+func recordZone(zones []model.DNSZone, zoneName string, domain string) (model.DNSZone, bool) {
+	var closest model.DNSZone
+	found := false
+	for _, zone := range zones {
+		switch {
+		case zoneName != "":
+			if strings.EqualFold(zone.Name, zoneName) {
+				return zone, true
+			}
+		case inZone(domain, zone.Name):
+			if len(zone.Name) > len(closest.Name) {
+				closest, found = zone, true
+			}
+		}
+	}
+	return closest, found
+}
+
+// recordZoneModifier replaces the record when it moves to another zone.
+// Setting the zone of a record created without one is checked by ModifyPlan
+// against the zone the server put it in, as only the server knows it. Not
+// set, the zone stays as in state: null, or the zone of the import ID
+type recordZoneModifier struct{}
+
+func (m recordZoneModifier) Description(ctx context.Context) string {
+	return "changing the zone requires replacement, setting the zone the record is already in does not"
+}
+
+func (m recordZoneModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m recordZoneModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.ConfigValue.IsNull() {
+		resp.PlanValue = req.StateValue
+		return
+	}
+	if req.State.Raw.IsNull() || req.StateValue.IsNull() || req.PlanValue.IsNull() {
+		return
+	}
+	resp.RequiresReplace = req.PlanValue.IsUnknown() || !sameHostName(req.StateValue.ValueString(), req.PlanValue.ValueString())
+}
+
+// recordDomainModifier plans domain from name and zone
+type recordDomainModifier struct{}
+
+func (m recordDomainModifier) Description(ctx context.Context) string {
+	return "domain is built from name and zone when not set"
+}
+
+func (m recordDomainModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m recordDomainModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var name, zone types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	if resp.Diagnostics.HasError() || name.IsNull() {
+		return
+	}
+
+	if name.IsUnknown() || zone.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	resp.PlanValue = types.StringValue(recordDomain(name.ValueString(), zone.ValueString()))
+}
+
+// recordNameModifier plans name from domain and zone. Without zone, name is
+// relative to the zone found on create and kept afterwards
+type recordNameModifier struct{}
+
+func (m recordNameModifier) Description(ctx context.Context) string {
+	return "name is computed from domain and zone when not set"
+}
+
+func (m recordNameModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m recordNameModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var domain, zone, stateDomain types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("domain"), &domain)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case domain.IsUnknown() || zone.IsUnknown():
+		resp.PlanValue = types.StringUnknown()
+	case !zone.IsNull():
+		resp.PlanValue = types.StringValue(relativeName(domain.ValueString(), zone.ValueString()))
+	case !req.State.Raw.IsNull():
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("domain"), &stateDomain)...)
+		if sameDomainName(stateDomain.ValueString(), domain.ValueString()) {
+			resp.PlanValue = req.StateValue
+		} else {
+			resp.PlanValue = types.StringUnknown()
+		}
+	default:
+		resp.PlanValue = types.StringUnknown()
+	}
+}
+
+// recordFqdnModifier plans fqdn from domain, or name and zone
+type recordFqdnModifier struct{}
+
+func (m recordFqdnModifier) Description(ctx context.Context) string {
+	return "fqdn is the canonical form of the record domain"
+}
+
+func (m recordFqdnModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m recordFqdnModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var domain, name, zone types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("domain"), &domain)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case domain.IsUnknown() || name.IsUnknown() || zone.IsUnknown():
+		resp.PlanValue = types.StringUnknown()
+	case !domain.IsNull():
+		resp.PlanValue = types.StringValue(recordFqdn(domain.ValueString()))
+	case !name.IsNull():
+		resp.PlanValue = types.StringValue(recordFqdn(recordDomain(name.ValueString(), zone.ValueString())))
+	}
+}
+
+// canonical form of a domain: lowercase punycode, without trailing dot
+func recordFqdn(domain string) string {
+	return strings.ToLower(domainToASCII(strings.TrimSuffix(domain, ".")))
+}
+
+// name of a domain relative to a zone holding it, "@" for the apex
+func relativeName(domain string, zoneName string) string {
+	domain, zoneName = strings.TrimSuffix(domain, "."), strings.TrimSuffix(zoneName, ".")
+	if !inZone(domain, zoneName) {
+		// one given in Unicode form, the other in punycode
+		domain, zoneName = domainToASCII(domain), domainToASCII(zoneName)
+	}
+	switch {
+	case !inZone(domain, zoneName):
+		return domain
+	case len(domain) == len(zoneName):
+		return "@"
+	}
+	return domain[:len(domain)-len(zoneName)-1]
+}
+
+// name of the record relative to its zone, read from the server when the
+// zone is not set. Null when no zone holds the record
+func (r *RecordResource) recordName(ctx context.Context, tfRec tfDNSRecord, diags *diag.Diagnostics) types.String {
+	if tfRec.Zone.ValueString() != "" {
+		return types.StringValue(relativeName(tfRec.Domain.ValueString(), tfRec.Zone.ValueString()))
+	}
+	zone, found := r.inferredZone(ctx, tfRec.Domain.ValueString(), diags)
+	if !found {
+		return types.StringNull()
+	}
+	return types.StringValue(relativeName(tfRec.Domain.ValueString(), zone.Name))
+}
+
+// closest zone of the server holding a domain, the one records without zone
+// are added to
+func (r *RecordResource) inferredZone(ctx context.Context, domain string, diags *diag.Diagnostics) (model.DNSZone, bool) {
+	zones, err := r.client.ListZones(ctx)
+	if err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
+		return model.DNSZone{}, false
+	}
+	return recordZone(zones, "", domainToASCII(domain))
+}
+
+// domain of a name relative to a zone
+func recordDomain(name string, zoneName string) string {
+	zoneName = strings.TrimSuffix(zoneName, ".")
+	if name == "@" {
+		return zoneName
+	}
+	return name + "." + zoneName
+}
+
+// whether a domain is the zone apex or a name below it
+func inZone(domain string, zoneName string) bool {
+	domain, zoneName = strings.ToLower(strings.TrimSuffix(domain, ".")), strings.ToLower(strings.TrimSuffix(zoneName, "."))
+	return domain == zoneName || strings.HasSuffix(domain, "."+zoneName)
+}
+
+// zones replicated from a primary server
+func isReadOnlyZoneType(zoneType model.DNSZoneType) bool {
+	switch zoneType {
+	case model.ZONE_SECONDARY, model.ZONE_STUB, model.ZONE_SECONDARYFORWARDER, model.ZONE_SECONDARYCATALOG:
+		return true
+	}
+	return false
+}
+
 // create will complain (and fail with client error) if same record is already present
 // (mb as a result of calling "apply" with updated config with old record already gone)
 // so state must be manually imported to continue (could step around this, but this will
@@ -419,8 +883,27 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 	defer tflog.Info(ctx, "create: end")
 	r.reqMutex.Lock()
 	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
 
-	apiRecPlan := tf2model(planData)
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	zone, _ := checkRecordZone(ctx, r.client, planData, true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if planData.Name.IsUnknown() {
+		planData.Name = types.StringValue(relativeName(planData.Domain.ValueString(), zone.Name))
+	}
+
+	apiRecPlan := r.supportedRecord(ctx, planData, &resp.Diagnostics)
+	apiRecPlan.Overwrite = planData.OverwriteOnCreate.ValueBool()
+	apiRecPlan.ProxyPassword = proxyPassword(ctx, req.Config, apiRecPlan.ProxyPassword, &resp.Diagnostics)
 	// "put"/"add" does not check prior state (terraform does not provide one for Create)
 	// and so will fail on uniqueness violation (e.g. if record already exists
 	// after external modification, or if it is the second CNAME etc)
@@ -428,15 +911,32 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 	// - alt/TODO: read records and do noop if target record is already there
 	//   like `apiAllRecs, err := r.client.GetRecords(ctx, apiDomain, apiRecPlan.Type, apiRecPlan.Name)`
 	//   but lets not be silent about that
-	err := r.client.AddRecord(ctx, apiRecPlan)
+	err := replicaWarning(r.client.AddRecord(ctx, apiRecPlan), &resp.Diagnostics)
 
 	if err != nil {
+		if isRecordExistsError(err) {
+			resp.Diagnostics.AddError("Record already exists",
+				fmt.Sprintf("Unable to create record: %s\n\n"+
+					"The record is already present on the server. To manage it with terraform, import it first:\n\n"+
+					"  terraform import technitium_record.<name> '%s'\n\n"+
+					"or set overwrite_on_create to replace it.", err, recordImportID(planData, zone.Name)))
+			return
+		}
 		resp.Diagnostics.AddError("Client Error",
 			fmt.Sprintf("Unable to create record: %s", err))
 		return
 	}
 
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, PTR_PRIVATE_KEY, ptrPrivateState(ctx, apiRecPlan))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+
+	// the record is created either way, a failed verification taints it
+	if err := r.verifyRecord(ctx, planData.Verify, apiRecPlan); err != nil {
+		resp.Diagnostics.AddError("Record verification failed", err.Error())
+	}
+	if err := r.waitForSecondaries(ctx, planData); err != nil {
+		resp.Diagnostics.AddError("Secondaries not updated", err.Error())
+	}
 }
 
 // TODO: The read function might need some caching mechanism because it is currently refetching the full record list every time.
@@ -452,6 +952,15 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 	defer tflog.Info(ctx, "read: end")
 	r.reqMutex.Lock()
 	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	dnsRecordFromState := tf2model(stateData)
 
@@ -474,6 +983,8 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 			if dnsRecordFromApi.SameKey(dnsRecordFromState) {
 				tflog.Info(ctx, "matching DNS record found")
 				model2tf(dnsRecordFromApi, &stateData)
+				resp.Diagnostics.Append(refreshAppAnswers(ctx, &stateData)...)
+				stateData.Fqdn = types.StringValue(recordFqdn(stateData.Domain.ValueString()))
 				tflog.Info(ctx, " AutoIpv6Hint value "+stateData.AutoIpv6Hint.String())
 				numFound += 1
 			}
@@ -492,6 +1003,10 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 				"Duplicate resource instances present",
 				"Will use the last one")
 		}
+		// states from before name was computed
+		if stateData.Name.IsNull() {
+			stateData.Name = r.recordName(ctx, stateData, &resp.Diagnostics)
+		}
 		resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
 	}
 }
@@ -508,8 +1023,18 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 	defer tflog.Info(ctx, "update: end")
 	r.reqMutex.Lock()
 	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	dnsRecordFromPlan := tf2model(planData)
+	dnsRecordFromPlan := r.supportedRecord(ctx, planData, &resp.Diagnostics)
+	dnsRecordFromPlan.ProxyPassword = proxyPassword(ctx, req.Config, dnsRecordFromPlan.ProxyPassword, &resp.Diagnostics)
 
 	var stateData tfDNSRecord
 	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
@@ -519,15 +1044,37 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	dnsRecordFromState := tf2model(stateData)
 
-	err := r.client.UpdateRecord(ctx, dnsRecordFromState, dnsRecordFromPlan)
+	err := replicaWarning(r.client.UpdateRecord(ctx, dnsRecordFromState, dnsRecordFromPlan), &resp.Diagnostics)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error",
-			fmt.Sprintf("Updating DNS failed: %s", err))
-		return
+		// record could be gone since refresh (deleted externally): re-add it
+		// with planned values instead of failing and requiring another apply
+		exists, lookupErr := r.recordExists(ctx, dnsRecordFromState)
+		if lookupErr != nil || exists {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Updating DNS failed: %s", err))
+			return
+		}
+		tflog.Warn(ctx, "Record to update is absent, creating it instead")
+		if err := replicaWarning(r.client.AddRecord(ctx, dnsRecordFromPlan), &resp.Diagnostics); err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Updating DNS failed: record is absent and re-creating it failed: %s", err))
+			return
+		}
 	}
 
+	if planData.Name.IsUnknown() {
+		planData.Name = r.recordName(ctx, planData, &resp.Diagnostics)
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, PTR_PRIVATE_KEY, ptrPrivateState(ctx, dnsRecordFromPlan))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+
+	if err := r.verifyRecord(ctx, planData.Verify, dnsRecordFromPlan); err != nil {
+		resp.Diagnostics.AddError("Record verification failed", err.Error())
+	}
+	if err := r.waitForSecondaries(ctx, planData); err != nil {
+		resp.Diagnostics.AddError("Secondaries not updated", err.Error())
+	}
 }
 
 func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -543,27 +1090,57 @@ func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	defer tflog.Info(ctx, "delete: end")
 	r.reqMutex.Lock()
 	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	deleteTimeout, diags := stateData.Timeouts.Delete(ctx, DEFAULT_DELETE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	dnsRecordFromState := tf2model(stateData)
 
-	err := r.client.DeleteRecord(ctx, dnsRecordFromState)
+	err := replicaWarning(r.client.DeleteRecord(ctx, dnsRecordFromState), &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error",
 			fmt.Sprintf("Deleting DNS record failed: %s", err))
 		return
 	}
+
+	if !stateData.ManagePtr.ValueBool() {
+		return
+	}
+	ptrData, diags := req.Private.GetKey(ctx, PTR_PRIVATE_KEY)
+	resp.Diagnostics.Append(diags...)
+	if len(ptrData) == 0 {
+		return
+	}
+	var ptrRecord model.DNSRecord
+	if err := json.Unmarshal(ptrData, &ptrRecord); err != nil {
+		resp.Diagnostics.AddWarning("Cannot read tracked PTR record",
+			fmt.Sprintf("PTR record is left in place: %s", err))
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("deleting tracked PTR record %s", ptrRecord.Domain))
+	if err := r.client.DeleteRecord(ctx, ptrRecord); err != nil {
+		resp.Diagnostics.AddWarning("Deleting PTR record failed",
+			fmt.Sprintf("PTR record %s is left in place: %s", ptrRecord.Domain, err))
+	}
 }
 
 // terraform import technitium_record.new-cname zone:name:TYPE:value
+// value could be omitted (zone:name:TYPE) if there is only one record of this type
 func (r *RecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	id := req.ID
 
 	// Parse the import ID: zone:name:TYPE:value
 	parts := strings.SplitN(id, IMPORT_SEP, 4)
-	if len(parts) < 4 {
+	if len(parts) < 3 {
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
-			fmt.Sprintf("Import ID must be in format 'zone:name:TYPE:value', got: %s", id),
+			fmt.Sprintf("Import ID must be in format 'zone:name:TYPE:value' or 'zone:name:TYPE', got: %s", id),
 		)
 		return
 	}
@@ -571,126 +1148,344 @@ func (r *RecordResource) ImportState(ctx context.Context, req resource.ImportSta
 	zone := parts[0]
 	name := parts[1]
 	recordType := parts[2]
-	value := parts[3]
+	hasValue := len(parts) == 4
+	value := ""
+	if hasValue {
+		value = parts[3]
+	}
 
-	// Construct full domain name
-	var domain string
-	if name == "@" {
-		domain = zone
-	} else {
-		domain = name + "." + zone
+	domain := recordDomain(name, zone)
+
+	importData := nullRecordData()
+	// zone is not returned by the API, Read keeps it from state afterwards
+	importData.Zone = types.StringValue(zone)
+	importData.Domain = types.StringValue(domain)
+	importData.Name = types.StringValue(name)
+	importData.Fqdn = types.StringValue(recordFqdn(domain))
+	importData.Type = types.StringValue(recordType)
+
+	if hasValue {
+		if err := parseImportValue(recordType, value, &importData); err != nil {
+			resp.Diagnostics.AddError("Invalid import ID",
+				fmt.Sprintf("Cannot parse %s record value: %s", recordType, err))
+			return
+		}
 	}
 
-	// Set the domain and type
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), recordType)...)
+	ctx = setLogCtx(ctx, importData, "import")
+	tflog.Info(ctx, "import: start")
+	defer tflog.Info(ctx, "import: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
 
-	// Set the value based on record type
-	switch recordType {
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
+
+	// Fetch the record to populate all the attributes (and real TTL),
+	// so that the first plan after import does not show bogus diffs
+	dnsRecordFromImport := tf2model(importData)
+	allRecordsFromApi, err := r.client.GetRecords(ctx, dnsRecordFromImport.Domain)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading DNS records: query failed: %s", err))
+		return
+	}
+	var matches []model.DNSRecord
+	for _, dnsRecordFromApi := range allRecordsFromApi {
+		if hasValue && dnsRecordFromApi.SameKey(dnsRecordFromImport) ||
+			!hasValue && dnsRecordFromApi.Type == dnsRecordFromImport.Type && dnsRecordFromApi.Domain == dnsRecordFromImport.Domain {
+			matches = append(matches, dnsRecordFromApi)
+		}
+	}
+	if len(matches) == 0 {
+		resp.Diagnostics.AddError("Record not found",
+			fmt.Sprintf("No %s record matching '%s' found for %s", recordType, value, domain))
+		return
+	}
+	if len(matches) > 1 && !hasValue {
+		// let user pick the right one
+		ids := make([]string, len(matches))
+		for i, match := range matches {
+			var matchData tfDNSRecord
+			model2tf(match, &matchData)
+			ids[i] = "  " + strings.Join([]string{zone, name, recordType, formatImportValue(matchData)}, IMPORT_SEP)
+		}
+		resp.Diagnostics.AddError("Ambiguous import ID",
+			fmt.Sprintf("There are %d %s records for %s, add the value to the import ID to pick one:\n%s",
+				len(matches), recordType, domain, strings.Join(ids, "\n")))
+		return
+	}
+	model2tf(matches[0], &importData)
+	model2tfZeroValues(matches[0], &importData)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &importData)...)
+}
+
+// check if a record with the same key is currently present on the server
+func (r *RecordResource) recordExists(ctx context.Context, record model.DNSRecord) (bool, error) {
+	allRecordsFromApi, err := r.client.GetRecords(ctx, record.Domain)
+	if err != nil {
+		return false, err
+	}
+	for _, dnsRecordFromApi := range allRecordsFromApi {
+		if dnsRecordFromApi.SameKey(record) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// PTR record created by the server for A/AAAA with "ptr" set, serialized for
+// private state; nil (i.e. key removal) if there is none
+func ptrPrivateState(ctx context.Context, record model.DNSRecord) []byte {
+	if !record.Ptr || (record.Type != model.REC_A && record.Type != model.REC_AAAA) {
+		return nil
+	}
+	reverse, err := reverseName(record.IPAddress)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("cannot track PTR record: %s", err))
+		return nil
+	}
+	data, err := json.Marshal(model.DNSRecord{
+		Type:    model.REC_PTR,
+		Domain:  model.DNSRecordName(reverse),
+		PtrName: string(record.Domain),
+	})
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("cannot track PTR record: %s", err))
+		return nil
+	}
+	return data
+}
+
+// reverse lookup domain for ip: in-addr.arpa for v4, nibbles in ip6.arpa for v6
+func reverseName(ipAddress string) (string, error) {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return "", fmt.Errorf("invalid ip address '%s'", ipAddress)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", ip4[3], ip4[2], ip4[1], ip4[0]), nil
+	}
+	const hexDigits = "0123456789abcdef"
+	nibbles := make([]string, 0, 2*net.IPv6len)
+	for i := net.IPv6len - 1; i >= 0; i-- {
+		nibbles = append(nibbles, string(hexDigits[ip[i]&0x0f]), string(hexDigits[ip[i]>>4]))
+	}
+	return strings.Join(nibbles, ".") + ".ip6.arpa", nil
+}
+
+// build the import ID (zone:name:TYPE:value) matching what ImportState
+// expects, for a record of the zone named, e.g. the one the server holds it in
+func recordImportID(tfRec tfDNSRecord, zoneName string) string {
+	name := relativeName(tfRec.Domain.ValueString(), zoneName)
+	return strings.Join([]string{zoneName, name, tfRec.Type.ValueString(), formatImportValue(tfRec)}, IMPORT_SEP)
+}
+
+// import value formats, by record type; multi-field values are joined by IMPORT_SEP
+//   - A, AAAA: ip_address
+//   - CNAME, NS, PTR, TXT, DNAME, ANAME, FWD: the only value field
+//   - MX: preference:exchange
+//   - SRV: priority:weight:port:target
+//   - CAA: flags:tag:value
+//   - URI: priority:weight:uri
+//   - DS: key_tag:algorithm:digest_type:digest
+//   - SSHFP: algorithm:fingerprint_type:fingerprint
+//   - TLSA: certificate_usage:selector:matching_type:certificate_association_data
+//   - NAPTR: order:preference:flags:services:regexp:replacement
+//   - SVCB, HTTPS: priority:target_name:params
+//   - APP: app_name:class_path:record_data
+//
+// last field may contain separators (and regexp for NAPTR, split from both ends)
+func formatImportValue(tfRec tfDNSRecord) string {
+	i64 := func(v types.Int64) string { return strconv.FormatInt(v.ValueInt64(), 10) }
+	join := func(fields ...string) string { return strings.Join(fields, IMPORT_SEP) }
+
+	switch tfRec.Type.ValueString() {
 	case "A", "AAAA":
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip_address"), value)...)
+		return tfRec.IPAddress.ValueString()
 	case "CNAME":
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cname"), value)...)
+		return tfRec.CName.ValueString()
+	case "NS":
+		return tfRec.NameServer.ValueString()
+	case "PTR":
+		return tfRec.PtrName.ValueString()
+	case "TXT":
+		return tfRec.Text.ValueString()
+	case "DNAME":
+		return tfRec.DName.ValueString()
+	case "ANAME":
+		return tfRec.AName.ValueString()
+	case "FWD":
+		return tfRec.Forwarder.ValueString()
 	case "MX":
-		// MX format: preference:exchange
-		mxParts := strings.SplitN(value, IMPORT_SEP, 2)
-		if len(mxParts) < 2 {
-			resp.Diagnostics.AddError(
-				"Invalid MX record format",
-				fmt.Sprintf("MX record value must be in format 'preference:exchange', got: %s", value),
-			)
-			return
+		return join(i64(tfRec.Preference), tfRec.Exchange.ValueString())
+	case "SRV":
+		return join(i64(tfRec.Priority), i64(tfRec.Weight), i64(tfRec.Port), tfRec.Target.ValueString())
+	case "CAA":
+		return join(tfRec.Flags.ValueString(), tfRec.Tag.ValueString(), tfRec.Value.ValueString())
+	case "URI":
+		return join(i64(tfRec.UriPriority), i64(tfRec.UriWeight), tfRec.Uri.ValueString())
+	case "DS":
+		return join(i64(tfRec.KeyTag), tfRec.Algorithm.ValueString(), tfRec.DigestType.ValueString(), tfRec.Digest.ValueString())
+	case "SSHFP":
+		return join(tfRec.SshfpAlgorithm.ValueString(), tfRec.SshfpFingerprintType.ValueString(), tfRec.SshfpFingerprint.ValueString())
+	case "TLSA":
+		return join(tfRec.TlsaCertificateUsage.ValueString(), tfRec.TlsaSelector.ValueString(),
+			tfRec.TlsaMatchingType.ValueString(), tfRec.TlsaCertificateAssociationData.ValueString())
+	case "NAPTR":
+		return join(i64(tfRec.NaptrOrder), i64(tfRec.NaptrPreference), tfRec.NaptrFlags.ValueString(),
+			tfRec.NaptrServices.ValueString(), tfRec.NaptrRegexp.ValueString(), tfRec.NaptrReplacement.ValueString())
+	case "SVCB", "HTTPS":
+		return join(i64(tfRec.SvcPriority), tfRec.SvcTargetName.ValueString(), tfRec.SvcParams.ValueString())
+	case "APP":
+		return join(tfRec.AppName.ValueString(), tfRec.ClassPath.ValueString(), tfRec.RecordData.ValueString())
+	default:
+		return tfRec.RecordData.ValueString()
+	}
+}
+
+// reverse of formatImportValue: set value fields of tfRec from import value
+func parseImportValue(recordType string, value string, tfRec *tfDNSRecord) error {
+	var fields []string
+	split := func(format string) error {
+		n := len(strings.Split(format, IMPORT_SEP))
+		fields = strings.SplitN(value, IMPORT_SEP, n)
+		if len(fields) < n {
+			return fmt.Errorf("value must be in format '%s', got: %s", format, value)
 		}
-		if pref, err := strconv.ParseInt(mxParts[0], 10, 64); err == nil {
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("preference"), pref)...)
-		} else {
-			resp.Diagnostics.AddError(
-				"Invalid MX preference",
-				fmt.Sprintf("MX preference must be a valid integer, got: %s", mxParts[0]),
-			)
-			return
+		return nil
+	}
+	var err error
+	i64 := func(name string, s string) types.Int64 {
+		v, convErr := strconv.ParseInt(s, 10, 64)
+		if convErr != nil && err == nil {
+			err = fmt.Errorf("%s must be a valid integer, got: %s", name, s)
 		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("exchange"), mxParts[1])...)
+		return types.Int64Value(v)
+	}
+
+	switch recordType {
+	case "A", "AAAA":
+		tfRec.IPAddress = types.StringValue(value)
+	case "CNAME":
+		tfRec.CName = types.StringValue(value)
 	case "NS":
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name_server"), value)...)
+		tfRec.NameServer = types.StringValue(value)
 	case "PTR":
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ptr_name"), value)...)
+		tfRec.PtrName = types.StringValue(value)
+	case "TXT":
+		tfRec.Text = types.StringValue(value)
+	case "DNAME":
+		tfRec.DName = types.StringValue(value)
+	case "ANAME":
+		tfRec.AName = types.StringValue(value)
+	case "FWD":
+		tfRec.Forwarder = types.StringValue(value)
+	case "MX":
+		if err := split("preference:exchange"); err != nil {
+			return err
+		}
+		tfRec.Preference = i64("preference", fields[0])
+		tfRec.Exchange = types.StringValue(fields[1])
 	case "SRV":
-		// SRV format: priority:weight:port:target
-		srvParts := strings.SplitN(value, IMPORT_SEP, 4)
-		if len(srvParts) < 4 {
-			resp.Diagnostics.AddError(
-				"Invalid SRV record format",
-				fmt.Sprintf("SRV record value must be in format 'priority:weight:port:target', got: %s", value),
-			)
-			return
+		if err := split("priority:weight:port:target"); err != nil {
+			return err
 		}
-		if prio, err := strconv.ParseInt(srvParts[0], 10, 64); err == nil {
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("priority"), prio)...)
-		} else {
-			resp.Diagnostics.AddError(
-				"Invalid SRV priority",
-				fmt.Sprintf("SRV priority must be a valid integer, got: %s", srvParts[0]),
-			)
-			return
+		tfRec.Priority = i64("priority", fields[0])
+		tfRec.Weight = i64("weight", fields[1])
+		tfRec.Port = i64("port", fields[2])
+		tfRec.Target = types.StringValue(fields[3])
+	case "CAA":
+		if err := split("flags:tag:value"); err != nil {
+			return err
 		}
-		if weight, err := strconv.ParseInt(srvParts[1], 10, 64); err == nil {
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("weight"), weight)...)
-		} else {
-			resp.Diagnostics.AddError(
-				"Invalid SRV weight",
-				fmt.Sprintf("SRV weight must be a valid integer, got: %s", srvParts[1]),
-			)
-			return
+		tfRec.Flags = types.StringValue(fields[0])
+		tfRec.Tag = types.StringValue(fields[1])
+		tfRec.Value = types.StringValue(fields[2])
+	case "URI":
+		if err := split("priority:weight:uri"); err != nil {
+			return err
 		}
-		if port, err := strconv.ParseInt(srvParts[2], 10, 64); err == nil {
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("port"), port)...)
-		} else {
-			resp.Diagnostics.AddError(
-				"Invalid SRV port",
-				fmt.Sprintf("SRV port must be a valid integer, got: %s", srvParts[2]),
-			)
-			return
+		tfRec.UriPriority = i64("priority", fields[0])
+		tfRec.UriWeight = i64("weight", fields[1])
+		tfRec.Uri = types.StringValue(fields[2])
+	case "DS":
+		if err := split("key_tag:algorithm:digest_type:digest"); err != nil {
+			return err
 		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("target"), srvParts[3])...)
-	case "TXT":
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("text"), value)...)
-	case "CAA":
-		// CAA format: flags:tag:value
-		caaParts := strings.SplitN(value, IMPORT_SEP, 3)
-		if len(caaParts) < 3 {
-			resp.Diagnostics.AddError(
-				"Invalid CAA record format",
-				fmt.Sprintf("CAA record value must be in format 'flags:tag:value', got: %s", value),
-			)
-			return
+		tfRec.KeyTag = i64("key_tag", fields[0])
+		tfRec.Algorithm = types.StringValue(fields[1])
+		tfRec.DigestType = types.StringValue(fields[2])
+		tfRec.Digest = types.StringValue(fields[3])
+	case "SSHFP":
+		if err := split("algorithm:fingerprint_type:fingerprint"); err != nil {
+			return err
 		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("flags"), caaParts[0])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), caaParts[1])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("value"), caaParts[2])...)
-	default:
-		// For other record types, try to set a generic value field if it exists
-		switch recordType {
-		case "ANAME":
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("aname"), value)...)
-		case "DNAME":
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dname"), value)...)
-		case "FWD":
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("forwarder"), value)...)
-		case "URI":
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uri"), value)...)
-		default:
-			// For complex records or unknown types, set record_data
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("record_data"), value)...)
+		tfRec.SshfpAlgorithm = types.StringValue(fields[0])
+		tfRec.SshfpFingerprintType = types.StringValue(fields[1])
+		tfRec.SshfpFingerprint = types.StringValue(fields[2])
+	case "TLSA":
+		if err := split("certificate_usage:selector:matching_type:certificate_association_data"); err != nil {
+			return err
+		}
+		tfRec.TlsaCertificateUsage = types.StringValue(fields[0])
+		tfRec.TlsaSelector = types.StringValue(fields[1])
+		tfRec.TlsaMatchingType = types.StringValue(fields[2])
+		tfRec.TlsaCertificateAssociationData = types.StringValue(fields[3])
+	case "NAPTR":
+		// regexp could contain separators (like "!^.*$!sip:info@example.com!"),
+		// replacement is a domain name: split it from the end
+		if err := split("order:preference:flags:services:regexp_and_replacement"); err != nil {
+			return err
+		}
+		last := strings.LastIndex(fields[4], IMPORT_SEP)
+		if last < 0 {
+			return fmt.Errorf("value must be in format 'order:preference:flags:services:regexp:replacement', got: %s", value)
 		}
+		tfRec.NaptrOrder = i64("order", fields[0])
+		tfRec.NaptrPreference = i64("preference", fields[1])
+		tfRec.NaptrFlags = types.StringValue(fields[2])
+		tfRec.NaptrServices = types.StringValue(fields[3])
+		tfRec.NaptrRegexp = types.StringValue(fields[4][:last])
+		tfRec.NaptrReplacement = types.StringValue(fields[4][last+1:])
+	case "SVCB", "HTTPS":
+		if err := split("priority:target_name:params"); err != nil {
+			return err
+		}
+		tfRec.SvcPriority = i64("priority", fields[0])
+		tfRec.SvcTargetName = types.StringValue(fields[1])
+		tfRec.SvcParams = types.StringValue(fields[2])
+	case "APP":
+		if err := split("app_name:class_path:record_data"); err != nil {
+			return err
+		}
+		tfRec.AppName = types.StringValue(fields[0])
+		tfRec.ClassPath = types.StringValue(fields[1])
+		tfRec.RecordData = types.StringValue(fields[2])
+	default:
+		return fmt.Errorf("import of %s records is not supported", recordType)
 	}
 
-	// Set a default TTL since it's required
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ttl"), int64(3600))...)
+	return err
+}
+
+// the API has no error codes, so duplicates can only be told apart by message
+func isRecordExistsError(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "already exists")
+}
+
+// log fields holding credentials: their values are masked in the logs
+var sensitiveLogFields = []string{"proxy_password", PROXY_PASSWORD_WO, "password", "token"}
+
+// mask the credentials of the fields logged with the context, whatever set them
+func maskSensitiveLogFields(ctx context.Context) context.Context {
+	return tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveLogFields...)
 }
 
 // add record fields to context; export TF_LOG=debug to view
 func setLogCtx(ctx context.Context, tfRec tfDNSRecord, op string) context.Context {
+	ctx = maskSensitiveLogFields(ctx)
 	logAttributes := map[string]interface{}{
 		"operation":                         op,
 		"zone":                              tfRec.Zone.ValueString(),
@@ -699,7 +1494,9 @@ func setLogCtx(ctx context.Context, tfRec tfDNSRecord, op string) context.Contex
 		"ttl":                               tfRec.TTL.ValueInt64(),
 		"ip_address":                        tfRec.IPAddress.ValueString(),
 		"ptr":                               tfRec.Ptr.ValueBool(),
+		"manage_ptr":                        tfRec.ManagePtr.ValueBool(),
 		"create_ptr_zone":                   tfRec.CreatePtrZone.ValueBool(),
+		"overwrite_on_create":               tfRec.OverwriteOnCreate.ValueBool(),
 		"update_svcb_hints":                 tfRec.UpdateSvcbHints.ValueBool(),
 		"name_server":                       tfRec.NameServer.ValueString(),
 		"glue":                              tfRec.Glue.ValueString(),
@@ -767,11 +1564,33 @@ func setLogCtx(ctx context.Context, tfRec tfDNSRecord, op string) context.Contex
 	return ctx
 }
 
+// state with every attribute null, to fill for records not read from a plan
+// or state: the zero values of maps and timeouts lack their element types
+func nullRecordData() tfDNSRecord {
+	return tfDNSRecord{
+		AppAnswers:      types.MapNull(types.ListType{ElemType: types.StringType}),
+		AppCnameAnswers: types.MapNull(types.StringType),
+		ExtraRData:      types.MapNull(types.StringType),
+		Timeouts:        nullTimeouts(),
+	}
+}
+
+// record to send for a plan, without the parameters the server does not
+// support, reported as warnings. They are not read back, so they stay in state
+func (r *RecordResource) supportedRecord(ctx context.Context, plan tfDNSRecord, diags *diag.Diagnostics) model.DNSRecord {
+	record := tf2model(plan)
+	if !gateFeature(ctx, r.client, featureSplitText, plan.SplitText, diags) {
+		record.SplitText = false
+	}
+	return record
+}
+
 // convert from terraform data model into api data model
 func tf2model(tfData tfDNSRecord) model.DNSRecord {
 	return model.DNSRecord{
 		Type:                           model.DNSRecordType(tfData.Type.ValueString()),
-		Domain:                         model.DNSRecordName(tfData.Domain.ValueString()),
+		Domain:                         model.DNSRecordName(domainToASCII(tfData.Domain.ValueString())),
+		Zone:                           domainToASCII(tfData.Zone.ValueString()),
 		TTL:                            model.DNSRecordTTL(tfData.TTL.ValueInt64()),
 		IPAddress:                      tfData.IPAddress.ValueString(),
 		Ptr:                            tfData.Ptr.ValueBool(),
@@ -832,7 +1651,22 @@ func tf2model(tfData tfDNSRecord) model.DNSRecord {
 		AppName:                        tfData.AppName.ValueString(),
 		ClassPath:                      tfData.ClassPath.ValueString(),
 		RecordData:                     tfData.RecordData.ValueString(),
+		ExtraRData:                     extraRData(tfData.ExtraRData),
+	}
+}
+
+// values of extra_rdata, nil when it is not set
+func extraRData(extra types.Map) map[string]string {
+	if extra.IsNull() || extra.IsUnknown() {
+		return nil
+	}
+	params := map[string]string{}
+	for name, value := range extra.Elements() {
+		if s, ok := value.(types.String); ok {
+			params[name] = s.ValueString()
+		}
 	}
+	return params
 }
 
 // convert from api data model into terraform data model
@@ -840,7 +1674,8 @@ func model2tf(apiData model.DNSRecord, tfData *tfDNSRecord) {
 	if apiData.Type != "" {
 		tfData.Type = types.StringValue(string(apiData.Type))
 	}
-	if apiData.Domain != "" {
+	// Unicode domains are kept as configured
+	if apiData.Domain != "" && !sameDomainName(tfData.Domain.ValueString(), string(apiData.Domain)) {
 		tfData.Domain = types.StringValue(string(apiData.Domain))
 	}
 	if apiData.TTL != 0 {
@@ -1014,7 +1849,8 @@ func model2tf(apiData model.DNSRecord, tfData *tfDNSRecord) {
 	if apiData.ProxyUsername != "" {
 		tfData.ProxyUsername = types.StringValue(apiData.ProxyUsername)
 	}
-	if apiData.ProxyPassword != "" {
+	// the write-only password is not kept in state
+	if apiData.ProxyPassword != "" && tfData.ProxyPasswordWOVersion.IsNull() {
 		tfData.ProxyPassword = types.StringValue(apiData.ProxyPassword)
 	}
 	if apiData.AppName != "" {
@@ -1027,3 +1863,27 @@ func model2tf(apiData model.DNSRecord, tfData *tfDNSRecord) {
 		tfData.RecordData = types.StringValue(apiData.RecordData)
 	}
 }
+
+// zero is a valid value for these, but model2tf treats it as absent: on import,
+// populate them for the record type so that state (and config generated from it
+// with -generate-config-out) is complete; Read keeps them afterwards
+func model2tfZeroValues(apiData model.DNSRecord, tfData *tfDNSRecord) {
+	switch apiData.Type {
+	case model.REC_MX:
+		tfData.Preference = types.Int64Value(int64(apiData.Preference))
+	case model.REC_SRV:
+		tfData.Priority = types.Int64Value(int64(apiData.Priority))
+		tfData.Weight = types.Int64Value(int64(apiData.Weight))
+		tfData.Port = types.Int64Value(int64(apiData.Port))
+	case model.REC_NAPTR:
+		tfData.NaptrOrder = types.Int64Value(int64(apiData.NaptrOrder))
+		tfData.NaptrPreference = types.Int64Value(int64(apiData.NaptrPreference))
+	case model.REC_DS:
+		tfData.KeyTag = types.Int64Value(int64(apiData.KeyTag))
+	case model.REC_SVCB, model.REC_HTTPS:
+		tfData.SvcPriority = types.Int64Value(int64(apiData.SvcPriority))
+	case model.REC_URI:
+		tfData.UriPriority = types.Int64Value(int64(apiData.UriPriority))
+		tfData.UriWeight = types.Int64Value(int64(apiData.UriWeight))
+	}
+}
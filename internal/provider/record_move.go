@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+var _ resource.ResourceWithMoveState = &RecordResource{}
+
+// provider whose resources can be moved into technitium_record, see moveDNSProviderState
+const DNS_PROVIDER_SOURCE = "/hashicorp/dns"
+
+// record types of the hashicorp/dns provider resources
+var dnsProviderRecordTypes = map[string]model.DNSRecordType{
+	"dns_a_record_set":    model.REC_A,
+	"dns_aaaa_record_set": model.REC_AAAA,
+	"dns_cname_record":    model.REC_CNAME,
+	"dns_ptr_record":      model.REC_PTR,
+	"dns_txt_record_set":  model.REC_TXT,
+	"dns_ns_record_set":   model.REC_NS,
+	"dns_mx_record_set":   model.REC_MX,
+	"dns_srv_record_set":  model.REC_SRV,
+}
+
+// state of the hashicorp/dns provider resources, all types together
+type dnsProviderRecord struct {
+	Zone        string   `json:"zone"` // FQDN with a trailing dot
+	Name        string   `json:"name"` // relative to the zone, empty for the apex
+	TTL         int64    `json:"ttl"`
+	Addresses   []string `json:"addresses"`
+	CName       string   `json:"cname"`
+	Ptr         string   `json:"ptr"`
+	Txt         []string `json:"txt"`
+	Nameservers []string `json:"nameservers"`
+	MX          []struct {
+		Preference int64  `json:"preference"`
+		Exchange   string `json:"exchange"`
+	} `json:"mx"`
+	SRV []struct {
+		Priority int64  `json:"priority"`
+		Weight   int64  `json:"weight"`
+		Port     int64  `json:"port"`
+		Target   string `json:"target"`
+	} `json:"srv"`
+}
+
+// moved blocks from the hashicorp/dns provider record resources, or from the
+// technitium_record resource of another Technitium provider
+func (r *RecordResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{StateMover: moveDNSProviderState},
+		{StateMover: moveTechnitiumRecordState},
+	}
+}
+
+// a technitium_record holds a single record: sets must have exactly one
+// value to be moved, larger ones are to be split first
+func moveDNSProviderState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	recordType, ok := dnsProviderRecordTypes[req.SourceTypeName]
+	if !ok || !strings.HasSuffix(req.SourceProviderAddress, DNS_PROVIDER_SOURCE) || req.SourceRawState == nil {
+		return
+	}
+
+	var source dnsProviderRecord
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError("Unable to Move Resource State",
+			fmt.Sprintf("Cannot read %s state: %s", req.SourceTypeName, err))
+		return
+	}
+
+	zone := strings.TrimSuffix(source.Zone, ".")
+	name := source.Name
+	if name == "" {
+		name = "@"
+	}
+	domain := recordDomain(name, zone)
+	target := nullRecordData()
+	target.Zone = types.StringValue(zone)
+	target.Domain = types.StringValue(domain)
+	target.Name = types.StringValue(name)
+	target.Fqdn = types.StringValue(recordFqdn(domain))
+	target.Type = types.StringValue(string(recordType))
+	if source.TTL != 0 {
+		target.TTL = types.Int64Value(source.TTL)
+	}
+
+	count := 0
+	switch recordType {
+	case model.REC_A, model.REC_AAAA:
+		if count = len(source.Addresses); count == 1 {
+			target.IPAddress = types.StringValue(source.Addresses[0])
+		}
+	case model.REC_CNAME:
+		count = 1
+		target.CName = types.StringValue(strings.TrimSuffix(source.CName, "."))
+	case model.REC_PTR:
+		count = 1
+		target.PtrName = types.StringValue(strings.TrimSuffix(source.Ptr, "."))
+	case model.REC_TXT:
+		if count = len(source.Txt); count == 1 {
+			target.Text = types.StringValue(source.Txt[0])
+		}
+	case model.REC_NS:
+		if count = len(source.Nameservers); count == 1 {
+			target.NameServer = types.StringValue(strings.TrimSuffix(source.Nameservers[0], "."))
+		}
+	case model.REC_MX:
+		if count = len(source.MX); count == 1 {
+			target.Preference = types.Int64Value(source.MX[0].Preference)
+			target.Exchange = types.StringValue(strings.TrimSuffix(source.MX[0].Exchange, "."))
+		}
+	case model.REC_SRV:
+		if count = len(source.SRV); count == 1 {
+			target.Priority = types.Int64Value(source.SRV[0].Priority)
+			target.Weight = types.Int64Value(source.SRV[0].Weight)
+			target.Port = types.Int64Value(source.SRV[0].Port)
+			target.Target = types.StringValue(strings.TrimSuffix(source.SRV[0].Target, "."))
+		}
+	}
+	if count != 1 {
+		resp.Diagnostics.AddError("Unable to Move Resource State",
+			fmt.Sprintf("%s %s holds %d records, technitium_record manages a single one: "+
+				"split the set into one resource per record before moving it", req.SourceTypeName, domain, count))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &target)...)
+}
+
+// forks of this provider share its schema: attributes it does not know are
+// dropped, those it has and the source does not are left null
+func moveTechnitiumRecordState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "technitium_record" || req.SourceRawState == nil {
+		return
+	}
+
+	var source map[string]json.RawMessage
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError("Unable to Move Resource State",
+			fmt.Sprintf("Cannot read %s state: %s", req.SourceTypeName, err))
+		return
+	}
+	schemaType := resp.TargetState.Schema.Type().TerraformType(ctx)
+	known := resp.TargetState.Schema.GetAttributes()
+	blocks := resp.TargetState.Schema.GetBlocks()
+	for name := range source {
+		if _, ok := known[name]; ok {
+			continue
+		}
+		if _, ok := blocks[name]; ok {
+			continue
+		}
+		delete(source, name)
+	}
+
+	filtered, err := json.Marshal(source)
+	if err == nil {
+		resp.TargetState.Raw, err = (&tfprotov6.RawState{JSON: filtered}).Unmarshal(schemaType)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Move Resource State",
+			fmt.Sprintf("Cannot convert %s state from %s: %s", req.SourceTypeName, req.SourceProviderAddress, err))
+	}
+}
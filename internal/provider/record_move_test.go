@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRecordMoveState(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		provider  string
+		typeName  string
+		state     string
+		want      tfDNSRecord
+		wantError bool
+	}{
+		{
+			name:     "dns a record set",
+			provider: "registry.terraform.io/hashicorp/dns",
+			typeName: "dns_a_record_set",
+			state:    `{"id":"www.example.com.","zone":"example.com.","name":"www","addresses":["192.0.2.1"],"ttl":300}`,
+			want:     tfDNSRecord{Zone: types.StringValue("example.com"), Domain: types.StringValue("www.example.com"), Name: types.StringValue("www"), Fqdn: types.StringValue("www.example.com"), Type: types.StringValue("A"), IPAddress: types.StringValue("192.0.2.1"), TTL: types.Int64Value(300)},
+		},
+		{
+			name:     "dns mx record set at apex",
+			provider: "registry.opentofu.org/hashicorp/dns",
+			typeName: "dns_mx_record_set",
+			state:    `{"zone":"example.com.","mx":[{"preference":10,"exchange":"mail.example.com."}],"ttl":3600}`,
+			want:     tfDNSRecord{Zone: types.StringValue("example.com"), Domain: types.StringValue("example.com"), Name: types.StringValue("@"), Fqdn: types.StringValue("example.com"), Type: types.StringValue("MX"), Preference: types.Int64Value(10), Exchange: types.StringValue("mail.example.com"), TTL: types.Int64Value(3600)},
+		},
+		{
+			name:      "dns set of several records",
+			provider:  "registry.terraform.io/hashicorp/dns",
+			typeName:  "dns_txt_record_set",
+			state:     `{"zone":"example.com.","name":"txt","txt":["a","b"],"ttl":300}`,
+			wantError: true,
+		},
+		{
+			name:     "technitium fork",
+			provider: "registry.terraform.io/someone/technitium",
+			typeName: "technitium_record",
+			state:    `{"zone":"example.com","domain":"alias.example.com","type":"CNAME","cname":"www.example.com","ttl":300,"fork_only":true}`,
+			want:     tfDNSRecord{Zone: types.StringValue("example.com"), Domain: types.StringValue("alias.example.com"), Type: types.StringValue("CNAME"), CName: types.StringValue("www.example.com"), TTL: types.Int64Value(300)},
+		},
+		{
+			name:      "unrelated resource",
+			provider:  "registry.terraform.io/hashicorp/aws",
+			typeName:  "aws_route53_record",
+			state:     `{"zone_id":"Z123","name":"www.example.com"}`,
+			wantError: true,
+		},
+	}
+
+	ctx := context.Background()
+	r := &RecordResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := resource.MoveStateRequest{
+				SourceProviderAddress: tt.provider,
+				SourceTypeName:        tt.typeName,
+				SourceRawState:        &tfprotov6.RawState{JSON: []byte(tt.state)},
+			}
+			resp := resource.MoveStateResponse{
+				TargetState: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+			}
+			for _, mover := range r.MoveState(ctx) {
+				mover.StateMover(ctx, req, &resp)
+				if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+					break
+				}
+			}
+
+			moved := !resp.Diagnostics.HasError() && !resp.TargetState.Raw.IsNull()
+			if moved == tt.wantError {
+				t.Fatalf("moved = %t, want %t: %v", moved, !tt.wantError, resp.Diagnostics)
+			}
+			if !moved {
+				return
+			}
+			var got tfDNSRecord
+			if diags := resp.TargetState.Get(ctx, &got); diags.HasError() {
+				t.Fatal(diags)
+			}
+			for _, check := range []struct {
+				attr      string
+				got, want interface{ String() string }
+			}{
+				{"zone", got.Zone, tt.want.Zone},
+				{"domain", got.Domain, tt.want.Domain},
+				{"type", got.Type, tt.want.Type},
+				{"ttl", got.TTL, tt.want.TTL},
+				{"ip_address", got.IPAddress, tt.want.IPAddress},
+				{"cname", got.CName, tt.want.CName},
+				{"preference", got.Preference, tt.want.Preference},
+				{"exchange", got.Exchange, tt.want.Exchange},
+			} {
+				if check.got.String() != check.want.String() {
+					t.Errorf("%s = %s, want %s", check.attr, check.got, check.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+	"github.com/pkg/errors"
+)
+
+// records of a type at a domain of a zone. The server answers for the closest
+// zone of the domain, records of another one are left out
+func readRecordSet(ctx context.Context, client model.DNSApiClient, zoneName string, domain string, recordType model.DNSRecordType) ([]model.DNSRecord, error) {
+	records, err := client.GetRecords(ctx, model.DNSRecordName(domain))
+	if err != nil {
+		return nil, err
+	}
+	set := []model.DNSRecord{}
+	for _, record := range records {
+		if record.Type == recordType && (record.Zone == "" || strings.EqualFold(record.Zone, zoneName)) {
+			set = append(set, record)
+		}
+	}
+	return set, nil
+}
+
+// bring the records of a set from current to desired, matching them by key:
+// new ones are added and changed ones updated before the others are deleted,
+// so that the set is never empty in between
+func reconcileRecordSet(ctx context.Context, client model.DNSApiClient, current []model.DNSRecord, desired []model.DNSRecord, changed func(current, desired model.DNSRecord) bool) error {
+	kept := make([]bool, len(current))
+	for _, record := range desired {
+		i := sameKeyIndex(current, record)
+		switch {
+		case i < 0:
+			if err := client.AddRecord(ctx, record); err != nil {
+				return errors.Wrapf(err, "adding %s record %s", record.Type, recordSetKey(record))
+			}
+		case changed(current[i], record):
+			kept[i] = true
+			if err := client.UpdateRecord(ctx, current[i], record); err != nil {
+				return errors.Wrapf(err, "updating %s record %s", record.Type, recordSetKey(record))
+			}
+		default:
+			kept[i] = true
+		}
+	}
+	for i, record := range current {
+		if kept[i] {
+			continue
+		}
+		if err := client.DeleteRecord(ctx, record); err != nil {
+			return errors.Wrapf(err, "deleting %s record %s", record.Type, recordSetKey(record))
+		}
+	}
+	return nil
+}
+
+// index of the record with the same key, names compared case-insensitively
+func sameKeyIndex(records []model.DNSRecord, record model.DNSRecord) int {
+	key := recordSetKey(record)
+	for i, other := range records {
+		if other.Type != record.Type {
+			continue
+		}
+		otherKey := recordSetKey(other)
+		if otherKey == key || (record.Type != model.REC_TXT && strings.EqualFold(otherKey, key)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// what tells the records of a set apart
+func recordSetKey(record model.DNSRecord) string {
+	switch record.Type {
+	case model.REC_NS:
+		return strings.TrimSuffix(record.NameServer, ".")
+	case model.REC_MX:
+		return strings.TrimSuffix(record.Exchange, ".")
+	case model.REC_TXT:
+		return record.Text
+	default:
+		return record.IPAddress
+	}
+}
@@ -0,0 +1,313 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckRecordZone(t *testing.T) {
+	t.Parallel()
+	zones := []model.DNSZone{
+		{Name: "example.com", Type: model.ZONE_PRIMARY},
+		{Name: "sub.example.com", Type: model.ZONE_SECONDARY},
+		{Name: "localhost", Type: model.ZONE_PRIMARY, Internal: true},
+	}
+	tests := []struct {
+		name      string
+		zone      string
+		domain    string
+		mustExist bool
+		wantError string
+	}{
+		{name: "named zone", zone: "example.com", domain: "www.example.com", mustExist: true},
+		{name: "inferred zone", domain: "www.example.com", mustExist: true},
+		{name: "closest zone is secondary", domain: "www.sub.example.com", wantError: "Read-only zone"},
+		{name: "internal zone", zone: "localhost", domain: "localhost", wantError: "Read-only zone"},
+		{name: "missing zone at plan", zone: "example.org", domain: "www.example.org"},
+		{name: "missing zone on create", zone: "example.org", domain: "www.example.org", mustExist: true, wantError: "Zone not found"},
+		{name: "no zone holds domain", domain: "www.example.org", mustExist: true, wantError: "Zone not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := model.NewMockDNSApiClient(t)
+			client.EXPECT().ListZones(mock.Anything).Return(zones, nil).Once()
+
+			tfRec := tfDNSRecord{Zone: types.StringValue(tt.zone), Domain: types.StringValue(tt.domain)}
+			if tt.zone == "" {
+				tfRec.Zone = types.StringNull()
+			}
+			var diags diag.Diagnostics
+			checkRecordZone(context.Background(), client, tfRec, tt.mustExist, &diags)
+
+			switch {
+			case tt.wantError == "" && diags.HasError():
+				t.Errorf("got unexpected errors: %v", diags.Errors())
+			case tt.wantError != "" && (len(diags.Errors()) != 1 || diags.Errors()[0].Summary() != tt.wantError):
+				t.Errorf("got errors %v, want one %q", diags.Errors(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestValidateRecordZone(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		zone    types.String
+		domain  string
+		wantErr bool
+	}{
+		{types.StringValue("example.com"), "www.example.com", false},
+		{types.StringValue("example.com"), "example.com", false},
+		{types.StringValue("Example.com."), "WWW.example.com", false},
+		{types.StringValue("bücher.example"), "www.xn--bcher-kva.example", false},
+		{types.StringValue("example.com"), "www.example.net", true},
+		{types.StringValue("example.com"), "wwwexample.com", true},
+		{types.StringNull(), "www.example.net", false},
+		{types.StringUnknown(), "www.example.net", false},
+	}
+
+	for _, tt := range tests {
+		var diags diag.Diagnostics
+		validateRecordZone(tfDNSRecord{Zone: tt.zone, Domain: types.StringValue(tt.domain)}, &diags)
+		if diags.HasError() != tt.wantErr {
+			t.Errorf("validateRecordZone(%s, %q) errors = %v, want error %t", tt.zone, tt.domain, diags.Errors(), tt.wantErr)
+		}
+	}
+}
+
+func TestRecordDomain(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		zone string
+		want string
+	}{
+		{"www", "example.com", "www.example.com"},
+		{"@", "example.com", "example.com"},
+		{"a.b", "example.com.", "a.b.example.com"},
+	}
+
+	for _, tt := range tests {
+		if got := recordDomain(tt.name, tt.zone); got != tt.want {
+			t.Errorf("recordDomain(%q, %q) = %q, want %q", tt.name, tt.zone, got, tt.want)
+		}
+	}
+}
+
+func TestRelativeName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		domain string
+		zone   string
+		want   string
+	}{
+		{"www.example.com", "example.com", "www"},
+		{"Example.COM.", "example.com", "@"},
+		{"a.b.example.com", "example.com.", "a.b"},
+		{"www.bücher.example", "xn--bcher-kva.example", "www"},
+	}
+
+	for _, tt := range tests {
+		if got := relativeName(tt.domain, tt.zone); got != tt.want {
+			t.Errorf("relativeName(%q, %q) = %q, want %q", tt.domain, tt.zone, got, tt.want)
+		}
+	}
+}
+
+func TestRecordFqdn(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{"WWW.Example.com.", "www.example.com"},
+		{"bücher.example", "xn--bcher-kva.example"},
+	}
+
+	for _, tt := range tests {
+		if got := recordFqdn(tt.domain); got != tt.want {
+			t.Errorf("recordFqdn(%q) = %q, want %q", tt.domain, got, tt.want)
+		}
+	}
+}
+
+func TestRecordImportID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		domain string
+		zone   string
+		want   string
+	}{
+		{"WWW.Example.com", "example.com", "example.com:WWW:A:192.0.2.1"},
+		{"example.com.", "example.com", "example.com:@:A:192.0.2.1"},
+		{"www.sub.example.com", "sub.example.com", "sub.example.com:www:A:192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		tfRec := nullRecordData()
+		tfRec.Type = types.StringValue(string(model.REC_A))
+		tfRec.Domain = types.StringValue(tt.domain)
+		tfRec.IPAddress = types.StringValue("192.0.2.1")
+		if got := recordImportID(tfRec, tt.zone); got != tt.want {
+			t.Errorf("recordImportID(%q, %q) = %q, want %q", tt.domain, tt.zone, got, tt.want)
+		}
+	}
+}
+
+func TestModel2tf_ProxyPasswordWO(t *testing.T) {
+	t.Parallel()
+	record := model.DNSRecord{Type: model.REC_FWD, Domain: "example.com", Forwarder: "192.0.2.53", ProxyPassword: "secret"}
+
+	var configured tfDNSRecord
+	model2tf(record, &configured)
+	if !configured.ProxyPassword.Equal(types.StringValue("secret")) {
+		t.Errorf("proxy_password = %s, want the server password", configured.ProxyPassword)
+	}
+
+	writeOnly := tfDNSRecord{ProxyPasswordWOVersion: types.Int64Value(1)}
+	model2tf(record, &writeOnly)
+	if !writeOnly.ProxyPassword.IsNull() {
+		t.Errorf("proxy_password = %s, want null with the write-only password", writeOnly.ProxyPassword)
+	}
+}
+
+func TestSetLogCtx_MasksProxyPassword(t *testing.T) {
+	t.Parallel()
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = setLogCtx(ctx, tfDNSRecord{
+		Type:          types.StringValue(string(model.REC_FWD)),
+		Domain:        types.StringValue("example.com"),
+		ProxyUsername: types.StringValue("proxy-user"),
+		ProxyPassword: types.StringValue("proxy-secret"),
+	}, "create")
+	tflog.Info(ctx, "create: start")
+
+	logged := output.String()
+	if strings.Contains(logged, "proxy-secret") {
+		t.Errorf("log = %s, want the proxy password masked", logged)
+	}
+	if !strings.Contains(logged, `"proxy_password":"***"`) || !strings.Contains(logged, "proxy-user") {
+		t.Errorf("log = %s, want the masked password and the proxy username", logged)
+	}
+}
+
+// state, plan and config of a record resource, the config being the plan
+func recordPlanRequest(t *testing.T, r *RecordResource, state *tfDNSRecord, plan tfDNSRecord) (resource.ModifyPlanRequest, *resource.ModifyPlanResponse) {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	req := resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema},
+	}
+	var diags diag.Diagnostics
+	if state != nil {
+		diags.Append(req.State.Set(ctx, state)...)
+	}
+	diags.Append(req.Plan.Set(ctx, &plan)...)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	req.Config = tfsdk.Config{Schema: schemaResp.Schema, Raw: req.Plan.Raw}
+	return req, &resource.ModifyPlanResponse{Plan: req.Plan}
+}
+
+// setting the zone of a record created without one replaces it only when the
+// record is in another zone
+func TestRecordZoneSet(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := model.NewFakeDNSApiClient()
+	for _, zone := range []string{"example.com", "sub.example.com"} {
+		if err := client.CreateZone(ctx, model.DNSZone{Name: zone, Type: model.ZONE_PRIMARY}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := &RecordResource{client: client, reqMutex: &sync.Mutex{}}
+
+	state := nullRecordData()
+	state.Type = types.StringValue(string(model.REC_A))
+	state.Domain = types.StringValue("www.sub.example.com")
+	state.Name = types.StringValue("www")
+	state.TTL = types.Int64Value(3600)
+	state.IPAddress = types.StringValue("192.0.2.1")
+
+	for zone, wantReplace := range map[string]bool{"sub.example.com": false, "Sub.Example.com.": false, "example.com": true} {
+		plan := state
+		plan.Zone = types.StringValue(zone)
+		req, resp := recordPlanRequest(t, r, &state, plan)
+
+		var modifierResp planmodifier.StringResponse
+		recordZoneModifier{}.PlanModifyString(ctx, planmodifier.StringRequest{
+			State: req.State, StateValue: state.Zone, PlanValue: plan.Zone, ConfigValue: plan.Zone,
+		}, &modifierResp)
+		r.ModifyPlan(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		if got := modifierResp.RequiresReplace || len(resp.RequiresReplace) > 0; got != wantReplace {
+			t.Errorf("setting zone %q: replace = %t, want %t", zone, got, wantReplace)
+		}
+	}
+}
+
+// the zone of the import ID is kept when the configuration leaves it unset
+func TestRecordImportThenPlan(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := model.NewFakeDNSApiClient()
+	if err := client.CreateZone(ctx, model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddRecord(ctx, model.DNSRecord{Type: model.REC_A, Domain: "www.example.com", IPAddress: "192.0.2.1", TTL: 3600}); err != nil {
+		t.Fatal(err)
+	}
+	r := &RecordResource{client: client, reqMutex: &sync.Mutex{}}
+
+	emptyReq, _ := recordPlanRequest(t, r, nil, nullRecordData())
+	importResp := &resource.ImportStateResponse{State: emptyReq.State}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "example.com:www:A:192.0.2.1"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatal(importResp.Diagnostics)
+	}
+	var imported tfDNSRecord
+	if diags := importResp.State.Get(ctx, &imported); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	// configuration with domain, without zone
+	var modifierResp planmodifier.StringResponse
+	recordZoneModifier{}.PlanModifyString(ctx, planmodifier.StringRequest{
+		State: importResp.State, StateValue: imported.Zone, PlanValue: types.StringUnknown(), ConfigValue: types.StringNull(),
+	}, &modifierResp)
+	if modifierResp.RequiresReplace || !modifierResp.PlanValue.Equal(types.StringValue("example.com")) {
+		t.Errorf("planned zone = %s, replace = %t, want the imported zone kept", modifierResp.PlanValue, modifierResp.RequiresReplace)
+	}
+
+	plan := imported
+	plan.Zone = modifierResp.PlanValue
+	req, resp := recordPlanRequest(t, r, &imported, plan)
+	r.ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() || len(resp.RequiresReplace) > 0 {
+		t.Errorf("plan after import: diagnostics = %v, replace = %v, want no replacement", resp.Diagnostics, resp.RequiresReplace)
+	}
+}
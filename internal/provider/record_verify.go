@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// verification defaults and polling interval
+const (
+	DEFAULT_VERIFY_TIMEOUT  = time.Minute
+	VERIFY_POLLING_INTERVAL = 2 * time.Second
+)
+
+type tfRecordVerify struct {
+	Enabled  types.Bool   `tfsdk:"enabled"`
+	Timeout  types.String `tfsdk:"timeout"`
+	Resolver types.String `tfsdk:"resolver"`
+}
+
+// verify { enabled = true ... } block of the record resource
+func verifyBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Wait after create and update until the record resolves, so dependent resources do not race ahead.",
+		Attributes: map[string]schema.Attribute{
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether to verify the record. Defaults to `true` when the block is set.",
+				Optional:            true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How long to wait for the record, e.g. `30s` or `5m`. Defaults to `%s`.", DEFAULT_VERIFY_TIMEOUT),
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"resolver": schema.StringAttribute{
+				MarkdownDescription: "Name server the record is queried from by the server DNS client, e.g. a secondary server address. " +
+					"Defaults to the server itself.",
+				Optional: true,
+				Validators: []validator.String{
+					hostAddressValidator{},
+				},
+			},
+		},
+	}
+}
+
+// poll the resolver until the record is answered or the verification times out
+func (r *RecordResource) verifyRecord(ctx context.Context, verify *tfRecordVerify, record model.DNSRecord) error {
+	if verify == nil || (!verify.Enabled.IsNull() && !verify.Enabled.ValueBool()) {
+		return nil
+	}
+
+	timeout := DEFAULT_VERIFY_TIMEOUT
+	if !verify.Timeout.IsNull() {
+		// checked by the validator
+		timeout, _ = time.ParseDuration(verify.Timeout.ValueString())
+	}
+	resolver := model.DNS_CLIENT_THIS_SERVER
+	if !verify.Resolver.IsNull() {
+		resolver = verify.Resolver.ValueString()
+	}
+	ctx = tflog.SetField(ctx, "resolver", resolver)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		answers, err := r.client.Resolve(ctx, resolver, string(record.Domain), record.Type)
+		if err == nil && isRecordAnswered(answers, record) {
+			return nil
+		}
+		tflog.Debug(ctx, "verify: record not resolved yet", map[string]interface{}{"error": err})
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("record not resolved by %s after %s: %w", resolver, timeout, err)
+			}
+			return fmt.Errorf("record not resolved by %s after %s", resolver, timeout)
+		case <-time.After(VERIFY_POLLING_INTERVAL):
+		}
+	}
+}
+
+// whether answers hold the record: one of its type for its domain, with the
+// same address for A/AAAA and target for CNAME
+func isRecordAnswered(answers []model.DNSResolvedRecord, record model.DNSRecord) bool {
+	sameName := func(a string, b string) bool {
+		return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+	}
+
+	for _, answer := range answers {
+		if answer.Type != string(record.Type) || !sameName(answer.Name, string(record.Domain)) {
+			continue
+		}
+		var rdata struct {
+			IPAddress string `json:"IPAddress"`
+			Domain    string `json:"Domain"`
+		}
+		_ = json.Unmarshal(answer.RData, &rdata)
+		switch record.Type {
+		case model.REC_A, model.REC_AAAA:
+			if net.ParseIP(rdata.IPAddress).Equal(net.ParseIP(record.IPAddress)) {
+				return true
+			}
+		case model.REC_CNAME:
+			if sameName(rdata.Domain, record.CName) {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
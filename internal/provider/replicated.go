@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+	"github.com/pkg/errors"
+)
+
+// dual-write mode: reads go to the primary server, changes are applied
+// to the primary then to every replica, in order.
+type replicatedClient struct {
+	model.DNSApiClient
+	replicas []replica
+}
+
+type replica struct {
+	url    string
+	client model.DNSApiClient
+}
+
+var _ model.DNSApiClient = replicatedClient{}
+
+// write applied on the primary server but failing on some replicas: the
+// change is made, so resources must save it to state rather than fail
+type replicaError struct {
+	failures []error
+}
+
+func (e replicaError) Error() string {
+	messages := make([]string, 0, len(e.failures))
+	for _, err := range e.failures {
+		messages = append(messages, err.Error())
+	}
+	return "applied on primary server but " + strings.Join(messages, "; ")
+}
+
+// a primary failure leaves replicas untouched, a replica failure does not
+// stop the write on the other replicas
+func (c replicatedClient) replicate(write func(client model.DNSApiClient) error) error {
+	if err := write(c.DNSApiClient); err != nil {
+		return err
+	}
+	var replicaErr replicaError
+	for _, r := range c.replicas {
+		if err := write(r.client); err != nil {
+			replicaErr.failures = append(replicaErr.failures, errors.Wrapf(err, "failed on replica %s", r.url))
+		}
+	}
+	if len(replicaErr.failures) > 0 {
+		return replicaErr
+	}
+	return nil
+}
+
+// report a write that failed on replicas only as a warning, and return the
+// other errors: the primary server holds the change, which must be saved to
+// state or the next apply fails on the object it no longer tracks
+func replicaWarning(err error, diags *diag.Diagnostics) error {
+	var replicaErr replicaError
+	if !errors.As(err, &replicaErr) {
+		return err
+	}
+	diags.AddWarning("Replicas out of sync",
+		fmt.Sprintf("%s\n\nThe change is saved to the state from the primary server. "+
+			"Reapply it on the failing replicas by hand, they are not compared with the primary one.", replicaErr))
+	return nil
+}
+
+func (c replicatedClient) AddRecord(ctx context.Context, record model.DNSRecord) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.AddRecord(ctx, record)
+	})
+}
+
+func (c replicatedClient) UpdateRecord(ctx context.Context, oldRecord model.DNSRecord, newRecord model.DNSRecord) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.UpdateRecord(ctx, oldRecord, newRecord)
+	})
+}
+
+func (c replicatedClient) DeleteRecord(ctx context.Context, record model.DNSRecord) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.DeleteRecord(ctx, record)
+	})
+}
+
+func (c replicatedClient) CreateZone(ctx context.Context, zone model.DNSZone) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.CreateZone(ctx, zone)
+	})
+}
+
+func (c replicatedClient) DeleteZone(ctx context.Context, zoneName string) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.DeleteZone(ctx, zoneName)
+	})
+}
+
+func (c replicatedClient) ResyncZone(ctx context.Context, zoneName string) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.ResyncZone(ctx, zoneName)
+	})
+}
+
+func (c replicatedClient) SetZoneOptions(ctx context.Context, zoneName string, options model.DNSZoneOptionsUpdate) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.SetZoneOptions(ctx, zoneName, options)
+	})
+}
+
+func (c replicatedClient) SignZone(ctx context.Context, zoneName string, signing model.DNSZoneSigning) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.SignZone(ctx, zoneName, signing)
+	})
+}
+
+func (c replicatedClient) UnsignZone(ctx context.Context, zoneName string) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.UnsignZone(ctx, zoneName)
+	})
+}
+
+func (c replicatedClient) UpdateDnsKeyTtl(ctx context.Context, zoneName string, ttl int64) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.UpdateDnsKeyTtl(ctx, zoneName, ttl)
+	})
+}
+
+func (c replicatedClient) UpdateDnssecKeyRollover(ctx context.Context, zoneName string, keyType string, rolloverDays int64) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.UpdateDnssecKeyRollover(ctx, zoneName, keyType, rolloverDays)
+	})
+}
+
+func (c replicatedClient) RolloverDnssecAlgorithm(ctx context.Context, zoneName string, signing model.DNSZoneSigning) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.RolloverDnssecAlgorithm(ctx, zoneName, signing)
+	})
+}
+
+func (c replicatedClient) UpdateDnssecNxProof(ctx context.Context, zoneName string, nxProof string, iterations int64, saltLength int64) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.UpdateDnssecNxProof(ctx, zoneName, nxProof, iterations, saltLength)
+	})
+}
+
+func (c replicatedClient) RemoveDhcpLease(ctx context.Context, scopeName string, clientIdentifier string) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.RemoveDhcpLease(ctx, scopeName, clientIdentifier)
+	})
+}
+
+func (c replicatedClient) ForceUpdateBlockLists(ctx context.Context) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.ForceUpdateBlockLists(ctx)
+	})
+}
+
+func (c replicatedClient) SetSettings(ctx context.Context, settings model.DNSSettingsUpdate) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.SetSettings(ctx, settings)
+	})
+}
+
+func (c replicatedClient) SetAppConfig(ctx context.Context, appName string, config string) error {
+	return c.replicate(func(client model.DNSApiClient) error {
+		return client.SetAppConfig(ctx, appName, config)
+	})
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestReplicatedClient(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	zone := model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY}
+	primary, broken, healthy := model.NewFakeDNSApiClient(), model.NewFakeDNSApiClient(), model.NewFakeDNSApiClient()
+	for _, client := range []*model.FakeDNSApiClient{primary, healthy} {
+		if err := client.CreateZone(ctx, zone); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := replicatedClient{DNSApiClient: primary, replicas: []replica{
+		{url: "https://broken.example.com", client: broken},
+		{url: "https://healthy.example.com", client: healthy},
+	}}
+
+	// the broken replica lacks the zone, the next one is still written
+	record := model.DNSRecord{Type: model.REC_A, Domain: "www.example.com", IPAddress: "192.0.2.1"}
+	var diags diag.Diagnostics
+	if err := replicaWarning(c.AddRecord(ctx, record), &diags); err != nil {
+		t.Fatalf("replicaWarning() = %v, want the replica failure as a warning", err)
+	}
+	if diags.HasError() || diags.WarningsCount() != 1 || !strings.Contains(diags[0].Detail(), "broken.example.com") {
+		t.Errorf("diagnostics = %v, want a warning about the broken replica", diags)
+	}
+	if records, _ := healthy.GetRecords(ctx, "www.example.com"); len(records) != 1 {
+		t.Errorf("healthy replica records = %+v, want the new record", records)
+	}
+
+	// a primary failure is an error, replicas are not written
+	diags = diag.Diagnostics{}
+	if err := replicaWarning(c.AddRecord(ctx, record), &diags); err == nil || strings.Contains(err.Error(), "replica") || len(diags) != 0 {
+		t.Errorf("replicaWarning() = %v, diagnostics = %v, want the primary error only", err, diags)
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/kevynb/terraform-provider-technitium/internal/client"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// API requests taking longer are reported as slow
+const SLOW_REQUEST_THRESHOLD = 5 * time.Second
+
+// failed and slow API requests, by endpoint, since they were last reported
+type requestStats struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointStats
+}
+
+type endpointStats struct {
+	failed     int // transport errors, retried on the next endpoint if any
+	slow       int
+	maxLatency time.Duration
+}
+
+func newRequestStats() *requestStats {
+	return &requestStats{endpoints: map[string]*endpointStats{}}
+}
+
+// client.MetricsHook counting every exchange with the API
+func (s *requestStats) record(ctx context.Context, metrics client.RequestMetrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats, ok := s.endpoints[metrics.Endpoint]
+	if !ok {
+		stats = &endpointStats{}
+		s.endpoints[metrics.Endpoint] = stats
+	}
+	if metrics.Err != nil {
+		stats.failed++
+	}
+	if metrics.Duration >= SLOW_REQUEST_THRESHOLD {
+		stats.slow++
+	}
+	stats.maxLatency = max(stats.maxLatency, metrics.Duration)
+}
+
+// one warning per endpoint with failed or slow requests, then start over so
+// that they are only reported once
+func (s *requestStats) report(diags *diag.Diagnostics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpoints := make([]string, 0, len(s.endpoints))
+	for endpoint := range s.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	for _, endpoint := range endpoints {
+		stats := s.endpoints[endpoint]
+		if stats.failed == 0 && stats.slow == 0 {
+			continue
+		}
+		diags.AddWarning("Unreliable Technitium API",
+			fmt.Sprintf("%d requests failed, %d took over %s, max latency %s against %s. Set debug_http to log every request.",
+				stats.failed, stats.slow, SLOW_REQUEST_THRESHOLD, stats.maxLatency.Round(time.Millisecond), endpoint))
+	}
+	clear(s.endpoints)
+}
+
+// outermost API client, giving access to the request stats of the provider
+type statsClient struct {
+	model.DNSApiClient
+	stats *requestStats
+}
+
+var _ model.DNSApiClient = statsClient{}
+
+// report the failed and slow requests of the provider since the last report,
+// as warnings of the operation
+func reportRequestStats(apiClient model.DNSApiClient, diags *diag.Diagnostics) {
+	if c, ok := apiClient.(statsClient); ok {
+		c.stats.report(diags)
+	}
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/kevynb/terraform-provider-technitium/internal/client"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestRequestStats(t *testing.T) {
+	t.Parallel()
+	stats := newRequestStats()
+	apiClient := statsClient{DNSApiClient: model.NewFakeDNSApiClient(), stats: stats}
+	ctx := context.Background()
+
+	stats.record(ctx, client.RequestMetrics{Endpoint: "https://dns1", Duration: 8 * time.Second})
+	stats.record(ctx, client.RequestMetrics{Endpoint: "https://dns1", Duration: time.Second, Err: errors.New("connection refused")})
+	stats.record(ctx, client.RequestMetrics{Endpoint: "https://dns2", Duration: time.Second, Retries: 1})
+
+	var diags diag.Diagnostics
+	reportRequestStats(apiClient, &diags)
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("got warnings %v, want one for the unreliable endpoint", diags.Warnings())
+	}
+	want := "1 requests failed, 1 took over 5s, max latency 8s against https://dns1"
+	if got := diags.Warnings()[0].Detail(); !strings.Contains(got, want) {
+		t.Errorf("got %q, want it to contain %q", got, want)
+	}
+
+	// reported once
+	diags = nil
+	reportRequestStats(apiClient, &diags)
+	if diags.WarningsCount() != 0 {
+		t.Errorf("got warnings %v after they were reported", diags.Warnings())
+	}
+}
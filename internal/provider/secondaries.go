@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// how long to wait for secondaries by default, zone transfers take longer
+// than records resolving on the server itself
+const DEFAULT_SECONDARIES_TIMEOUT = 5 * time.Minute
+
+type tfWaitForSecondaries struct {
+	Enabled     types.Bool     `tfsdk:"enabled"`
+	Timeout     types.String   `tfsdk:"timeout"`
+	NameServers []types.String `tfsdk:"name_servers"`
+}
+
+// wait_for_secondaries { enabled = true ... } block of the record and zone
+// resources
+func waitForSecondariesBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Wait after create and update until the secondary name servers serve the change, " +
+			"i.e. answer the zone SOA with the serial of the server, so dependent steps do not run before it is visible everywhere.",
+		Attributes: map[string]schema.Attribute{
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait for the secondaries. Defaults to `true` when the block is set.",
+				Optional:            true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How long to wait for the secondaries, e.g. `30s` or `5m`. Defaults to `%s`.", DEFAULT_SECONDARIES_TIMEOUT),
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"name_servers": schema.ListAttribute{
+				MarkdownDescription: "Secondary name servers to wait for, queried by the server DNS client. " +
+					"Defaults to the name servers of the zone NS records, except the primary one of its SOA record.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(hostAddressValidator{}),
+				},
+			},
+		},
+	}
+}
+
+// SOA RDATA as answered by the server DNS client
+type soaRData struct {
+	PrimaryNameServer string `json:"PrimaryNameServer"`
+	Serial            uint32 `json:"Serial"`
+}
+
+// poll the secondaries until they all serve the current serial of the zone,
+// or the wait times out
+func waitForSecondaries(ctx context.Context, client model.DNSApiClient, wait *tfWaitForSecondaries, zoneName string) error {
+	if !waitEnabled(wait) {
+		return nil
+	}
+
+	timeout := DEFAULT_SECONDARIES_TIMEOUT
+	if !wait.Timeout.IsNull() {
+		// checked by the validator
+		timeout, _ = time.ParseDuration(wait.Timeout.ValueString())
+	}
+	ctx = tflog.SetField(ctx, "zone", zoneName)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	primary, err := resolveSOA(ctx, client, model.DNS_CLIENT_THIS_SERVER, zoneName)
+	if err != nil {
+		return fmt.Errorf("reading the SOA serial of zone %s: %w", zoneName, err)
+	}
+	pending := tfStrings2model(wait.NameServers)
+	if len(pending) == 0 {
+		if pending, err = secondaryNameServers(ctx, client, zoneName, primary.PrimaryNameServer); err != nil {
+			return fmt.Errorf("reading the name servers of zone %s: %w", zoneName, err)
+		}
+	}
+
+	for {
+		var lastErr error
+		pending = slices.DeleteFunc(pending, func(nameServer string) bool {
+			soa, err := resolveSOA(ctx, client, nameServer, zoneName)
+			if err != nil {
+				lastErr = err
+				return false
+			}
+			return serialAtLeast(soa.Serial, primary.Serial)
+		})
+		if len(pending) == 0 {
+			return nil
+		}
+		tflog.Debug(ctx, "wait for secondaries: serial not served yet", map[string]interface{}{
+			"serial": primary.Serial, "pending": pending, "error": lastErr,
+		})
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fmt.Errorf("serial %d of zone %s not served by %s after %s: %w",
+					primary.Serial, zoneName, strings.Join(pending, ", "), timeout, lastErr)
+			}
+			return fmt.Errorf("serial %d of zone %s not served by %s after %s",
+				primary.Serial, zoneName, strings.Join(pending, ", "), timeout)
+		case <-time.After(VERIFY_POLLING_INTERVAL):
+		}
+	}
+}
+
+// wait for the secondaries of the zone of a record
+func (r *RecordResource) waitForSecondaries(ctx context.Context, tfRec tfDNSRecord) error {
+	if !waitEnabled(tfRec.WaitForSecondaries) {
+		return nil
+	}
+	zones, err := r.client.ListZones(ctx)
+	if err != nil {
+		return fmt.Errorf("reading DNS zones: %w", err)
+	}
+	zone, found := recordZone(zones, domainToASCII(tfRec.Zone.ValueString()), domainToASCII(tfRec.Domain.ValueString()))
+	if !found {
+		return fmt.Errorf("no zone holds %s", tfRec.Domain.ValueString())
+	}
+	return waitForSecondaries(ctx, r.client, tfRec.WaitForSecondaries, zone.Name)
+}
+
+// the block is set and not disabled
+func waitEnabled(wait *tfWaitForSecondaries) bool {
+	return wait != nil && (wait.Enabled.IsNull() || wait.Enabled.ValueBool())
+}
+
+// SOA of a zone as answered by a name server
+func resolveSOA(ctx context.Context, client model.DNSApiClient, server string, zoneName string) (soaRData, error) {
+	answers, err := client.Resolve(ctx, server, zoneName, model.REC_SOA)
+	if err != nil {
+		return soaRData{}, err
+	}
+	for _, answer := range answers {
+		if answer.Type != string(model.REC_SOA) || !sameHostName(answer.Name, zoneName) {
+			continue
+		}
+		var soa soaRData
+		if err := json.Unmarshal(answer.RData, &soa); err != nil {
+			return soaRData{}, err
+		}
+		return soa, nil
+	}
+	return soaRData{}, fmt.Errorf("no SOA record for %s", zoneName)
+}
+
+// name servers of the zone NS records, but the primary one
+func secondaryNameServers(ctx context.Context, client model.DNSApiClient, zoneName string, primary string) ([]string, error) {
+	records, err := readRecordSet(ctx, client, zoneName, zoneName, model.REC_NS)
+	if err != nil {
+		return nil, err
+	}
+	nameServers := []string{}
+	for _, record := range records {
+		if !sameHostName(record.NameServer, primary) {
+			nameServers = append(nameServers, strings.TrimSuffix(record.NameServer, "."))
+		}
+	}
+	return nameServers, nil
+}
+
+// serial arithmetic of RFC 1982: serials wrap around
+func serialAtLeast(serial uint32, reference uint32) bool {
+	return int32(serial-reference) >= 0
+}
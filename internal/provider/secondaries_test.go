@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// fake answering SOA queries with the serial of each server
+type soaClient struct {
+	*model.FakeDNSApiClient
+	serials map[string]uint32
+}
+
+func (c soaClient) Resolve(ctx context.Context, server string, domain string, recordType model.DNSRecordType) ([]model.DNSResolvedRecord, error) {
+	serial, ok := c.serials[server]
+	if !ok {
+		return nil, fmt.Errorf("no answer from %s", server)
+	}
+	rdata := fmt.Sprintf(`{"PrimaryNameServer":"ns1.example.com","Serial":%d}`, serial)
+	return []model.DNSResolvedRecord{{Name: domain, Type: string(model.REC_SOA), RData: []byte(rdata)}}, nil
+}
+
+func TestWaitForSecondaries(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fake := model.NewFakeDNSApiClient()
+	if err := fake.CreateZone(ctx, model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY}); err != nil {
+		t.Fatal(err)
+	}
+	for _, nameServer := range []string{"ns1.example.com", "ns2.example.com", "ns3.example.net"} {
+		if err := fake.AddRecord(ctx, model.DNSRecord{Type: model.REC_NS, Domain: "example.com", NameServer: nameServer}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wait := &tfWaitForSecondaries{Enabled: types.BoolNull(), Timeout: types.StringValue("10ms")}
+
+	// the primary ns1 is not queried, the serial of ns3 wrapped around
+	client := soaClient{fake, map[string]uint32{model.DNS_CLIENT_THIS_SERVER: 4294967295, "ns2.example.com": 4294967295, "ns3.example.net": 2}}
+	if err := waitForSecondaries(ctx, client, wait, "example.com"); err != nil {
+		t.Errorf("waitForSecondaries() = %v, want secondaries up to date", err)
+	}
+
+	client.serials["ns2.example.com"] = 4294967290
+	err := waitForSecondaries(ctx, client, wait, "example.com")
+	if err == nil || !strings.Contains(err.Error(), "ns2.example.com") || strings.Contains(err.Error(), "ns3") {
+		t.Errorf("waitForSecondaries() = %v, want ns2.example.com late", err)
+	}
+
+	// only the configured name servers are waited for
+	wait.NameServers = []types.String{types.StringValue("192.0.2.53")}
+	client.serials["192.0.2.53"] = 4294967295
+	if err := waitForSecondaries(ctx, client, wait, "example.com"); err != nil {
+		t.Errorf("waitForSecondaries() = %v, want 192.0.2.53 up to date", err)
+	}
+
+	wait.Enabled = types.BoolValue(false)
+	if err := waitForSecondaries(ctx, soaClient{fake, nil}, wait, "example.com"); err != nil {
+		t.Errorf("waitForSecondaries() = %v, want no wait when disabled", err)
+	}
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// oldest Technitium DNS Server release with all the API parameters the
+// provider relies on: its changelog lists catalog zones, zone query access,
+// the SOA serial date scheme, ZONEMD validation and forwarder priority. Zone
+// dynamic update security policies are older, from 10.0
+const MIN_SERVER_VERSION = "12.0"
+
+// API parameter added after MIN_SERVER_VERSION
+type serverFeature struct {
+	attribute string // configuration attribute setting it
+	version   string // first server release supporting it
+}
+
+// TXT records split in several character-strings: "Version 13.0" of the
+// server CHANGELOG.md
+var featureSplitText = serverFeature{attribute: "split_text", version: "13.0"}
+
+// whether the server supports a feature, assumed when its version is unknown
+func serverSupports(ctx context.Context, client model.DNSApiClient, feature serverFeature) bool {
+	version, err := client.GetServerVersion(ctx)
+	if err != nil || version == "" {
+		return true
+	}
+	return compareVersions(version, feature.version) >= 0
+}
+
+// whether a configured value can be sent to the server: a parameter the
+// server does not know is reported and left out, rather than having the
+// whole request rejected
+func gateFeature(ctx context.Context, client model.DNSApiClient, feature serverFeature, value attr.Value, diags *diag.Diagnostics) bool {
+	if value.IsNull() || value.IsUnknown() || serverSupports(ctx, client, feature) {
+		return true
+	}
+	version, _ := client.GetServerVersion(ctx)
+	diags.AddAttributeWarning(path.Root(feature.attribute), "Parameter not supported by the server",
+		fmt.Sprintf("`%s` needs Technitium DNS Server %s or later, the server runs %s: it is not sent, "+
+			"and has no effect until the server is upgraded.", feature.attribute, feature.version, version))
+	return false
+}
+
+// probe the server version and fail when it is older than MIN_SERVER_VERSION.
+// A failed probe is only a warning: the server may be unreachable at plan
+// time, and validate_credentials is there to make that an error
+func checkServerVersion(ctx context.Context, client model.DNSApiClient, diags *diag.Diagnostics) string {
+	version, err := client.GetServerVersion(ctx)
+	if err != nil {
+		diags.AddWarning("Cannot detect Technitium server version",
+			fmt.Sprintf("Reading the server version failed, assuming it is %s or later: %s", MIN_SERVER_VERSION, err))
+		return ""
+	}
+
+	tflog.Info(ctx, "technitium server version", map[string]interface{}{"version": version})
+	if compareVersions(version, MIN_SERVER_VERSION) < 0 {
+		diags.AddError("Unsupported Technitium server version",
+			fmt.Sprintf("The server runs Technitium DNS Server %s, this provider needs version %s or later: "+
+				"upgrade the server, or use an older release of the provider.", version, MIN_SERVER_VERSION))
+	}
+	return version
+}
+
+// compare dotted versions like 13.6.0 numerically: -1, 0 or 1. Missing
+// components count as 0, and suffixes like -beta are ignored
+func compareVersions(version1 string, version2 string) int {
+	parts1, parts2 := strings.Split(version1, "."), strings.Split(version2, ".")
+	for i := 0; i < len(parts1) || i < len(parts2); i++ {
+		n1, n2 := versionPart(parts1, i), versionPart(parts2, i)
+		switch {
+		case n1 < n2:
+			return -1
+		case n1 > n2:
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	digits := strings.TrimLeft(parts[i], " v")
+	if end := strings.IndexFunc(digits, func(c rune) bool { return c < '0' || c > '9' }); end >= 0 {
+		digits = digits[:end]
+	}
+	n, _ := strconv.Atoi(digits)
+	return n
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		version1 string
+		version2 string
+		want     int
+	}{
+		{"13.6.0", "12.0", 1},
+		{"12.0", "12.0.0", 0},
+		{"11.5.3", "12.0", -1},
+		{"12.10", "12.9", 1},
+		{"14.0-beta", "14.0", 0},
+		{"", "12.0", -1},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.version1, tt.version2); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.version1, tt.version2, got, tt.want)
+		}
+	}
+}
+
+func TestGateFeature(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		version   string
+		value     types.Bool
+		want      bool
+		wantWarns int
+	}{
+		{"13.6", types.BoolValue(true), true, 0},
+		{"12.0", types.BoolValue(true), false, 1},
+		{"12.0", types.BoolNull(), true, 0},
+		{"", types.BoolValue(true), true, 0},
+	}
+
+	for _, tt := range tests {
+		client := model.NewFakeDNSApiClient()
+		client.ServerVersion = tt.version
+		var diags diag.Diagnostics
+		got := gateFeature(context.Background(), client, featureSplitText, tt.value, &diags)
+		if got != tt.want || diags.WarningsCount() != tt.wantWarns {
+			t.Errorf("gateFeature(%q, %s) = %t with %d warnings, want %t with %d",
+				tt.version, tt.value, got, diags.WarningsCount(), tt.want, tt.wantWarns)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SplitHorizonRecordDataFunction{}
+
+// SplitHorizonRecordDataFunction builds record_data for Split Horizon APP records
+type SplitHorizonRecordDataFunction struct{}
+
+func NewSplitHorizonRecordDataFunction() function.Function {
+	return &SplitHorizonRecordDataFunction{}
+}
+
+func (f *SplitHorizonRecordDataFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "split_horizon_record_data"
+}
+
+func (f *SplitHorizonRecordDataFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build record_data for Split Horizon APP records",
+		MarkdownDescription: "Builds the JSON `record_data` expected by the Split Horizon app (`SplitHorizon.SimpleAddress` or `SplitHorizon.SimpleCNAME` class paths) " +
+			"from a map of network (name or CIDR) to answers. Keys are sorted, so the result is stable across runs.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "networks",
+				MarkdownDescription: "Map of network name or CIDR to the list of addresses (or domain names) to answer with, e.g. `{ private = [\"192.168.1.10\"] }`.",
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SplitHorizonRecordDataFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var networks map[string][]string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &networks))
+	if resp.Error != nil {
+		return
+	}
+
+	for network, addresses := range networks {
+		if addresses == nil {
+			networks[network] = []string{}
+		}
+	}
+
+	// map keys are sorted by the encoder
+	data, err := json.Marshal(networks)
+	if err != nil {
+		resp.Error = function.NewFuncError("Cannot encode record data: " + err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, string(data)))
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// max length of a single DNS character-string, in bytes
+const TXT_CHUNK_MAX = 255
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SplitTxtFunction{}
+
+// SplitTxtFunction splits long TXT values into character-strings
+type SplitTxtFunction struct{}
+
+func NewSplitTxtFunction() function.Function {
+	return &SplitTxtFunction{}
+}
+
+func (f *SplitTxtFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "split_txt"
+}
+
+func (f *SplitTxtFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split a long TXT value into character-strings",
+		MarkdownDescription: "Splits a long value (e.g. a DKIM key) into chunks of at most `size` bytes, joined by new lines, " +
+			"to be used as `text` of a TXT record with `split_text = true`. Chunks never cut a multi-byte character in half.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "Text to split.",
+			},
+			function.Int64Parameter{
+				Name:                "size",
+				MarkdownDescription: "Maximum chunk size in bytes, between 1 and 255.",
+				Validators: []function.Int64ParameterValidator{
+					int64validator.Between(1, TXT_CHUNK_MAX),
+				},
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SplitTxtFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	var size int64
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value, &size))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, strings.Join(splitTxt(value, int(size)), "\n")))
+}
+
+// split into chunks of at most size bytes, on rune boundaries
+func splitTxt(value string, size int) []string {
+	var chunks []string
+	for len(value) > size {
+		cut := size
+		for cut > 0 && !utf8.RuneStart(value[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			// rune longer than size, cannot do better
+			cut = size
+		}
+		chunks = append(chunks, value[:cut])
+		value = value[cut:]
+	}
+	return append(chunks, value)
+}
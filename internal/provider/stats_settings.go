@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &StatsSettingsResource{}
+	_ resource.ResourceWithConfigure   = &StatsSettingsResource{}
+	_ resource.ResourceWithImportState = &StatsSettingsResource{}
+)
+
+// ID of the stats settings, there is one set per server
+const STATS_SETTINGS_ID = "stats"
+
+type tfStatsSettings struct {
+	ID                  types.String   `tfsdk:"id"`
+	EnableInMemoryStats types.Bool     `tfsdk:"enable_in_memory_stats"`
+	MaxStatFileDays     types.Int64    `tfsdk:"max_stat_file_days"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+// StatsSettingsResource manages how the DNS server keeps query stats
+type StatsSettingsResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func StatsSettingsResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &StatsSettingsResource{reqMutex: m}
+	}
+}
+
+func (r *StatsSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_stats_settings"
+}
+
+func (r *StatsSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		MarkdownDescription: "Manages how the DNS server keeps the query stats of its dashboard, e.g. to retain none on disk. " +
+			"There is a single set of settings per server: settings not configured are left as they are, " +
+			"and destroying the resource leaves the server settings unchanged.",
+		Attributes: map[string]rschema.Attribute{
+			"id": rschema.StringAttribute{
+				MarkdownDescription: "Always `" + STATS_SETTINGS_ID + "`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enable_in_memory_stats": rschema.BoolAttribute{
+				MarkdownDescription: "Keep stats in memory only, for the last hour, instead of saving them to stat files. " +
+					"Stat files saved before are not deleted.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_stat_file_days": rschema.Int64Attribute{
+				MarkdownDescription: "Days after which stat files are deleted, `0` to keep them forever.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]rschema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *StatsSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *StatsSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfStatsSettings
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	planData = r.apply(ctx, planData, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *StatsSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateData tfStatsSettings
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	tflog.Info(ctx, "read: start")
+	defer tflog.Info(ctx, "read: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	settings, err := r.client.GetSettings(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading server settings: %s", err))
+		return
+	}
+
+	stateData = statsSettings2tf(stateData, settings)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func (r *StatsSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData tfStatsSettings
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "update")
+	tflog.Info(ctx, "update: start")
+	defer tflog.Info(ctx, "update: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	planData = r.apply(ctx, planData, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+// the settings stay on the server
+func (r *StatsSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "delete: nothing to do, stats settings are left as they are")
+}
+
+// terraform import technitium_stats_settings.example stats
+func (r *StatsSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = tflog.SetField(ctx, "operation", "import")
+	tflog.Info(ctx, "import: start")
+	defer tflog.Info(ctx, "import: end")
+
+	if req.ID != STATS_SETTINGS_ID {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected '%s', got '%s'", STATS_SETTINGS_ID, req.ID))
+		return
+	}
+
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
+
+	settings, err := r.client.GetSettings(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading server settings: %s", err))
+		return
+	}
+
+	stateData := statsSettings2tf(tfStatsSettings{Timeouts: nullTimeouts()}, settings)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+// save the planned settings and read them back for the ones not configured
+func (r *StatsSettingsResource) apply(ctx context.Context, plan tfStatsSettings, diags *diag.Diagnostics) tfStatsSettings {
+	update := model.DNSSettingsUpdate{}
+	if !plan.EnableInMemoryStats.IsUnknown() {
+		update.EnableInMemoryStats = plan.EnableInMemoryStats.ValueBoolPointer()
+	}
+	if !plan.MaxStatFileDays.IsUnknown() {
+		update.MaxStatFileDays = plan.MaxStatFileDays.ValueInt64Pointer()
+	}
+	if err := r.client.SetSettings(ctx, update); err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Saving server settings: %s", err))
+		return plan
+	}
+
+	settings, err := r.client.GetSettings(ctx)
+	if err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Reading server settings: %s", err))
+		return plan
+	}
+	return statsSettings2tf(plan, settings)
+}
+
+func statsSettings2tf(prior tfStatsSettings, settings model.DNSSettings) tfStatsSettings {
+	result := prior
+	result.ID = types.StringValue(STATS_SETTINGS_ID)
+	result.EnableInMemoryStats = types.BoolValue(settings.EnableInMemoryStats)
+	result.MaxStatFileDays = types.Int64Value(settings.MaxStatFileDays)
+	return result
+}
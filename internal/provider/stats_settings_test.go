@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestStatsSettingsApply(t *testing.T) {
+	t.Parallel()
+	client := model.NewFakeDNSApiClient()
+	r := &StatsSettingsResource{client: client}
+
+	var diags diag.Diagnostics
+	r.apply(context.Background(), tfStatsSettings{EnableInMemoryStats: types.BoolValue(false), MaxStatFileDays: types.Int64Value(30)}, &diags)
+
+	// no stats retained: only max_stat_file_days is left as is
+	got := r.apply(context.Background(), tfStatsSettings{EnableInMemoryStats: types.BoolValue(true), MaxStatFileDays: types.Int64Unknown()}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got.ID.ValueString() != STATS_SETTINGS_ID || !got.EnableInMemoryStats.ValueBool() || got.MaxStatFileDays.ValueInt64() != 30 {
+		t.Errorf("settings = %+v, want in-memory stats and 30 days of stat files", got)
+	}
+}
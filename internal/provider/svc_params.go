@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// separator between keys and values in technitium svcParams
+const SVC_PARAMS_SEP = "|"
+
+// SvcParamKey numbers from RFC 9460 registry, used to order parameters
+var svcParamKeyNumbers = map[string]int{
+	"mandatory":       0,
+	"alpn":            1,
+	"no-default-alpn": 2,
+	"port":            3,
+	"ipv4hint":        4,
+	"ech":             5,
+	"ipv6hint":        6,
+	"dohpath":         7,
+	"ohttp":           8,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SvcParamsFunction{}
+
+// SvcParamsFunction builds svc_params string for SVCB/HTTPS records
+type SvcParamsFunction struct{}
+
+func NewSvcParamsFunction() function.Function {
+	return &SvcParamsFunction{}
+}
+
+func (f *SvcParamsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "svc_params"
+}
+
+func (f *SvcParamsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build svc_params for SVCB/HTTPS records",
+		MarkdownDescription: "Converts a map of SVCB parameters into the `key|value|key|value` string expected by `svc_params`. " +
+			"Parameters are ordered by their registered key number (`mandatory`, `alpn`, `port`, `ipv4hint`...), so the result is stable across runs.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "params",
+				MarkdownDescription: "Map of parameter name to value, e.g. `{ alpn = \"h2,h3\", ipv4hint = \"1.2.3.4\" }`.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SvcParamsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var params map[string]string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &params))
+	if resp.Error != nil {
+		return
+	}
+
+	keys := make([]string, 0, len(params))
+	for key, value := range params {
+		if key == "" || strings.Contains(key, SVC_PARAMS_SEP) || strings.Contains(value, SVC_PARAMS_SEP) {
+			resp.Error = function.NewArgumentFuncError(0,
+				fmt.Sprintf("Parameter names must be non-empty and neither names nor values may contain '%s', got: %s", SVC_PARAMS_SEP, key))
+			return
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ni, nj := svcParamKeyNumber(keys[i]), svcParamKeyNumber(keys[j])
+		if ni != nj {
+			return ni < nj
+		}
+		return keys[i] < keys[j]
+	})
+
+	parts := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		parts = append(parts, key, params[key])
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, strings.Join(parts, SVC_PARAMS_SEP)))
+}
+
+// registered key number, generic "keyNNNNN" form, or past the end for unknown keys
+func svcParamKeyNumber(key string) int {
+	if n, ok := svcParamKeyNumbers[key]; ok {
+		return n
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(key, "key")); err == nil && strings.HasPrefix(key, "key") {
+		return n
+	}
+	return 1 << 16
+}
@@ -0,0 +1,73 @@
+package provider
+
+// remove what interrupted acceptance runs left on the test server:
+// TECHNITIUM_API_URL=... TECHNITIUM_API_TOKEN=... go test ./internal/provider -v -sweep=all
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/kevynb/terraform-provider-technitium/internal/client"
+)
+
+// prefix of zones created by acceptance tests
+const TEST_ZONE_PREFIX = "tfacc"
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("technitium_zone", &resource.Sweeper{
+		Name: "technitium_zone",
+		F:    sweepZones,
+	})
+}
+
+// API client for sweepers, configured from the provider environment variables
+func sweeperClient() (*client.Client, error) {
+	apiURL := os.Getenv("TECHNITIUM_API_URL")
+	if apiURL == "" {
+		return nil, fmt.Errorf("TECHNITIUM_API_URL must be set for sweepers")
+	}
+	return client.NewClient(client.Config{
+		APIURLs:  []string{apiURL},
+		Token:    os.Getenv("TECHNITIUM_API_TOKEN"),
+		Username: os.Getenv("TECHNITIUM_USERNAME"),
+		Password: os.Getenv("TECHNITIUM_PASSWORD"),
+		Version:  "sweeper",
+	})
+}
+
+// delete tfacc* zones, their records go with them; the region is not used
+func sweepZones(_ string) error {
+	ctx := context.Background()
+	c, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+
+	zones, err := c.ListZones(ctx)
+	if err != nil {
+		return fmt.Errorf("listing zones: %w", err)
+	}
+	var failed []string
+	for _, zone := range zones {
+		if !strings.HasPrefix(zone.Name, TEST_ZONE_PREFIX) {
+			continue
+		}
+		if err := c.DeleteZone(ctx, zone.Name); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", zone.Name, err))
+			continue
+		}
+		fmt.Printf("[INFO] sweeper: deleted zone %s\n", zone.Name)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("deleting zones: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// operation timeouts when not set in the resource timeouts block
+const (
+	DEFAULT_CREATE_TIMEOUT = 5 * time.Minute
+	DEFAULT_READ_TIMEOUT   = 2 * time.Minute
+	DEFAULT_UPDATE_TIMEOUT = 5 * time.Minute
+	DEFAULT_DELETE_TIMEOUT = 5 * time.Minute
+)
+
+// timeouts { create = "10m" ... } block of record and zone resources
+func timeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Read:   true,
+		Update: true,
+		Delete: true,
+	})
+}
+
+// for state built from scratch (import), as the zero value has no attribute types
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// stats_type values
+const (
+	TOP_BLOCKED = "blocked"
+	TOP_QUERIED = "queried"
+)
+
+const DEFAULT_TOP_LIMIT = 100
+
+var _ datasource.DataSourceWithConfigure = &TopDomainsDataSource{}
+
+type tfTopDomains struct {
+	StatsType types.String  `tfsdk:"stats_type"`
+	Period    types.String  `tfsdk:"period"`
+	Start     types.String  `tfsdk:"start"`
+	End       types.String  `tfsdk:"end"`
+	Limit     types.Int64   `tfsdk:"limit"`
+	Domains   []tfTopDomain `tfsdk:"domains"`
+	Names     types.List    `tfsdk:"names"`
+}
+
+type tfTopDomain struct {
+	Name types.String `tfsdk:"name"`
+	Hits types.Int64  `tfsdk:"hits"`
+}
+
+// TopDomainsDataSource lists the most blocked or queried domains from dashboard stats
+type TopDomainsDataSource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func TopDomainsDataSourceFactory(m *sync.Mutex) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &TopDomainsDataSource{reqMutex: m}
+	}
+}
+
+func (d *TopDomainsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_top_domains"
+}
+
+func (d *TopDomainsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Top blocked or queried domains over a time range, from the dashboard stats. Useful to generate allow-list entries from real traffic.",
+		Attributes: map[string]schema.Attribute{
+			"stats_type": schema.StringAttribute{
+				MarkdownDescription: "Domains to list: `blocked` (default) or `queried`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(TOP_BLOCKED, TOP_QUERIED),
+				},
+			},
+			"period": schema.StringAttribute{
+				MarkdownDescription: "Time range of the stats. Valid values are `LastHour`, `LastDay` (default), `LastWeek`, `LastMonth`, `LastYear`, `Custom`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(model.STATS_LAST_HOUR, model.STATS_LAST_DAY, model.STATS_LAST_WEEK,
+						model.STATS_LAST_MONTH, model.STATS_LAST_YEAR, model.STATS_CUSTOM),
+				},
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "Start of the `Custom` period, RFC 3339 timestamp.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("end")),
+				},
+			},
+			"end": schema.StringAttribute{
+				MarkdownDescription: "End of the `Custom` period, RFC 3339 timestamp.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("start")),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of domains, %d by default.", DEFAULT_TOP_LIMIT),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"domains": schema.ListNestedAttribute{
+				MarkdownDescription: "Domains with their hit count, most hit first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Domain name.",
+							Computed:            true,
+						},
+						"hits": schema.Int64Attribute{
+							MarkdownDescription: "Number of blocked or received queries.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "Domain names only, most hit first.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *TopDomainsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TopDomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config tfTopDomains
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	statsType := model.STATS_TOP_BLOCKED_DOMAINS
+	if config.StatsType.ValueString() == TOP_QUERIED {
+		statsType = model.STATS_TOP_DOMAINS
+	}
+	period := model.DNSStatsPeriod{
+		Type:  model.STATS_LAST_DAY,
+		Start: config.Start.ValueString(),
+		End:   config.End.ValueString(),
+	}
+	if !config.Period.IsNull() {
+		period.Type = config.Period.ValueString()
+	}
+	if period.Type == model.STATS_CUSTOM {
+		for attrName, value := range map[string]string{"start": period.Start, "end": period.End} {
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root(attrName), "Invalid Custom period",
+					fmt.Sprintf("start and end must be RFC 3339 timestamps for Custom period: %s", err))
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	limit := int64(DEFAULT_TOP_LIMIT)
+	if !config.Limit.IsNull() {
+		limit = config.Limit.ValueInt64()
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "stats_type", statsType)
+	tflog.Info(ctx, "read top domains: start")
+	defer tflog.Info(ctx, "read top domains: end")
+	d.reqMutex.Lock()
+	defer d.reqMutex.Unlock()
+
+	topDomains, err := d.client.GetTopDomains(ctx, statsType, period, limit)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading top domains: query failed: %s", err))
+		return
+	}
+
+	config.Domains = make([]tfTopDomain, len(topDomains))
+	names := make([]string, len(topDomains))
+	for i, topDomain := range topDomains {
+		config.Domains[i] = tfTopDomain{
+			Name: types.StringValue(topDomain.Name),
+			Hits: types.Int64Value(topDomain.Hits),
+		}
+		names[i] = topDomain.Name
+	}
+	namesList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	config.Names = namesList
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
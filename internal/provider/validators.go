@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	_ validator.String = httpURLValidator{}
+	_ validator.String = hostAddressValidator{}
+	_ validator.String = networkACLEntryValidator{}
+	_ validator.String = networkAddressValidator{}
+	_ validator.String = ipAddressValidator{}
+	_ validator.String = durationValidator{}
+)
+
+// httpURLValidator checks the value is an absolute http(s) URL
+type httpURLValidator struct{}
+
+func (v httpURLValidator) Description(ctx context.Context) string {
+	return "value must be an absolute http or https URL"
+}
+
+func (v httpURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v httpURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid URL",
+			fmt.Sprintf("%q is not an absolute http or https URL", value))
+	}
+}
+
+// hostAddressValidator checks the value is an IP address or a host name
+type hostAddressValidator struct{}
+
+func (v hostAddressValidator) Description(ctx context.Context) string {
+	return "value must be an IP address or a host name"
+}
+
+func (v hostAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if net.ParseIP(value) != nil || isHostName(value) {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid address",
+		fmt.Sprintf("%q is neither an IP address nor a host name", value))
+}
+
+// ipAddressValidator checks the value is an IPv4 or IPv6 address
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(ctx context.Context) string {
+	return "value must be an IP address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if net.ParseIP(value) == nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP address",
+			fmt.Sprintf("%q is not an IP address", value))
+	}
+}
+
+// networkACLEntryValidator checks the value is an IP address or network,
+// optionally prefixed with ! to deny it
+type networkACLEntryValidator struct{}
+
+func (v networkACLEntryValidator) Description(ctx context.Context) string {
+	return "value must be an IP address or network, prefixed with ! to deny it"
+}
+
+func (v networkACLEntryValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v networkACLEntryValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if isNetworkAddress(strings.TrimPrefix(value, "!")) {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid network ACL entry",
+		fmt.Sprintf("%q is neither an IP address nor a network address, optionally prefixed with !", value))
+}
+
+// networkAddressValidator checks the value is an IP address or network
+type networkAddressValidator struct{}
+
+func (v networkAddressValidator) Description(ctx context.Context) string {
+	return "value must be an IP address or network"
+}
+
+func (v networkAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v networkAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if !isNetworkAddress(value) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid network address",
+			fmt.Sprintf("%q is neither an IP address nor a network address", value))
+	}
+}
+
+// durationValidator checks the value is a positive Go duration, e.g. 30s or 5m
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration, e.g. 30s or 5m"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if duration, err := time.ParseDuration(value); err != nil || duration <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration",
+			fmt.Sprintf("%q is not a positive duration like 30s or 5m", value))
+	}
+}
+
+// 16-bit unsigned API fields, e.g. ports and priorities, that would
+// otherwise be truncated when converted to the API model
+func uint16Validator() validator.Int64 {
+	return int64validator.Between(0, math.MaxUint16)
+}
+
+// RFC 1123 host name, an optional trailing dot is allowed
+func isHostName(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
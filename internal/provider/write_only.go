@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// write-only proxy password attributes, alternative to proxy_password
+const (
+	PROXY_PASSWORD_WO         = "proxy_password_wo"
+	PROXY_PASSWORD_WO_VERSION = "proxy_password_wo_version"
+)
+
+// write-only value, never in the plan or state: terraform only sends it with
+// the configuration, and its version tells when it changes
+func proxyPasswordWOAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Write-only alternative to `proxy_password`, kept out of the plan and state. Requires Terraform 1.11 or later. " +
+			"Change `" + PROXY_PASSWORD_WO_VERSION + "` to send a new password.",
+		Optional:  true,
+		Sensitive: true,
+		WriteOnly: true,
+		Validators: []validator.String{
+			stringvalidator.ConflictsWith(path.MatchRoot("proxy_password")),
+			stringvalidator.AlsoRequires(path.MatchRoot(PROXY_PASSWORD_WO_VERSION)),
+		},
+	}
+}
+
+func proxyPasswordWOVersionAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: "Version of `" + PROXY_PASSWORD_WO + "`, to increase for the new password to be sent.",
+		Optional:            true,
+		Validators: []validator.Int64{
+			int64validator.AlsoRequires(path.MatchRoot(PROXY_PASSWORD_WO)),
+		},
+	}
+}
+
+// write-only attribute of the configuration, null when not set
+func configWriteOnly(ctx context.Context, config tfsdk.Config, name string, diags *diag.Diagnostics) types.String {
+	var value types.String
+	diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+	return value
+}
+
+// proxy password to send, the write-only one when configured
+func proxyPassword(ctx context.Context, config tfsdk.Config, password string, diags *diag.Diagnostics) string {
+	if passwordWO := configWriteOnly(ctx, config, PROXY_PASSWORD_WO, diags); !passwordWO.IsNull() {
+		return passwordWO.ValueString()
+	}
+	return password
+}
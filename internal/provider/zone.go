@@ -3,15 +3,24 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -28,23 +37,41 @@ var (
 )
 
 type tfDNSZone struct {
-	Name                       types.String `tfsdk:"name"`
-	Type                       types.String `tfsdk:"type"`
-	Catalog                    types.String `tfsdk:"catalog"`
-	UseSoaSerialDateScheme     types.Bool   `tfsdk:"use_soa_serial_date_scheme"`
-	PrimaryNameServerAddresses types.String `tfsdk:"primary_name_server_addresses"`
-	ZoneTransferProtocol       types.String `tfsdk:"zone_transfer_protocol"`
-	TsigKeyName                types.String `tfsdk:"tsig_key_name"`
-	ValidateZone               types.Bool   `tfsdk:"validate_zone"`
-	InitializeForwarder        types.Bool   `tfsdk:"initialize_forwarder"`
-	Protocol                   types.String `tfsdk:"protocol"`
-	Forwarder                  types.String `tfsdk:"forwarder"`
-	DnssecValidation           types.Bool   `tfsdk:"dnssec_validation"`
-	ProxyType                  types.String `tfsdk:"proxy_type"`
-	ProxyAddress               types.String `tfsdk:"proxy_address"`
-	ProxyPort                  types.Int64  `tfsdk:"proxy_port"`
-	ProxyUsername              types.String `tfsdk:"proxy_username"`
-	ProxyPassword              types.String `tfsdk:"proxy_password"`
+	Name                       types.String   `tfsdk:"name"`
+	Type                       types.String   `tfsdk:"type"`
+	Catalog                    types.String   `tfsdk:"catalog"`
+	UseSoaSerialDateScheme     types.Bool     `tfsdk:"use_soa_serial_date_scheme"`
+	PrimaryNameServerAddresses types.String   `tfsdk:"primary_name_server_addresses"`
+	ZoneTransferProtocol       types.String   `tfsdk:"zone_transfer_protocol"`
+	TsigKeyName                types.String   `tfsdk:"tsig_key_name"`
+	ValidateZone               types.Bool     `tfsdk:"validate_zone"`
+	InitializeForwarder        types.Bool     `tfsdk:"initialize_forwarder"`
+	Protocol                   types.String   `tfsdk:"protocol"`
+	Forwarder                  types.String   `tfsdk:"forwarder"`
+	DnssecValidation           types.Bool     `tfsdk:"dnssec_validation"`
+	ProxyType                  types.String   `tfsdk:"proxy_type"`
+	ProxyAddress               types.String   `tfsdk:"proxy_address"`
+	ProxyPort                  types.Int64    `tfsdk:"proxy_port"`
+	ProxyUsername              types.String   `tfsdk:"proxy_username"`
+	ProxyPassword              types.String   `tfsdk:"proxy_password"`
+	ProxyPasswordWO            types.String   `tfsdk:"proxy_password_wo"`
+	ProxyPasswordWOVersion     types.Int64    `tfsdk:"proxy_password_wo_version"`
+	QueryAccess                types.String   `tfsdk:"query_access"`
+	QueryAccessNetworkACL      types.List     `tfsdk:"query_access_network_acl"`
+	DynamicUpdate              types.String   `tfsdk:"dynamic_update"`
+	DynamicUpdateNetworkACL    types.List     `tfsdk:"dynamic_update_network_acl"`
+	UpdateSecurityPolicies     types.List     `tfsdk:"update_security_policies"`
+	Notify                     types.String   `tfsdk:"notify"`
+	NotifyNameServers          types.List     `tfsdk:"notify_name_servers"`
+	ZoneTransfer               types.String   `tfsdk:"zone_transfer"`
+	ZoneTransferNameServers    types.List     `tfsdk:"zone_transfer_name_servers"`
+	DNSSecStatus               types.String   `tfsdk:"dnssec_status"`
+	SOASerial                  types.Int64    `tfsdk:"soa_serial"`
+	LastModified               types.String   `tfsdk:"last_modified"`
+	Disabled                   types.Bool     `tfsdk:"disabled"`
+	Timeouts                   timeouts.Value `tfsdk:"timeouts"`
+
+	WaitForSecondaries *tfWaitForSecondaries `tfsdk:"wait_for_secondaries"`
 }
 
 // ZoneResource defines the implementation of Technitium DNS zones
@@ -68,15 +95,20 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		MarkdownDescription: "Manages a DNS zone in Technitium DNS Server.",
 		Attributes: map[string]rschema.Attribute{
 			"name": rschema.StringAttribute{
-				MarkdownDescription: "The domain name for the DNS zone.",
+				MarkdownDescription: "The domain name for the DNS zone. Internationalized names can be given in Unicode or punycode form.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceIfOtherDomain(),
 				},
 			},
 			"type": rschema.StringAttribute{
 				MarkdownDescription: "The type of zone to create. Valid values are `Primary`, `Secondary`, `Stub`, `Forwarder`, `SecondaryForwarder`, `Catalog`, `SecondaryCatalog`.",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(model.ZONE_PRIMARY), string(model.ZONE_SECONDARY), string(model.ZONE_STUB),
+						string(model.ZONE_FORWARDER), string(model.ZONE_SECONDARYFORWARDER),
+						string(model.ZONE_CATALOG), string(model.ZONE_SECONDARYCATALOG)),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -90,19 +122,22 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 			},
 			"primary_name_server_addresses": rschema.StringAttribute{
-				MarkdownDescription: "List of comma separated IP addresses or domain names of the primary name server. Required for `Secondary`, `SecondaryForwarder`, and `SecondaryCatalog` zones.",
+				MarkdownDescription: "List of comma separated IP addresses or domain names of the primary name server. Required for `SecondaryForwarder` and `SecondaryCatalog` zones; `Secondary` and `Stub` zones use the name servers of the zone NS records when not set.",
 				Optional:            true,
 			},
 			"zone_transfer_protocol": rschema.StringAttribute{
 				MarkdownDescription: "The zone transfer protocol to be used by `Secondary`, `SecondaryForwarder`, and `SecondaryCatalog` zones. Valid values are `Tcp`, `Tls`, `Quic`.",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Tcp", "Tls", "Quic"),
+				},
 			},
 			"tsig_key_name": rschema.StringAttribute{
 				MarkdownDescription: "The TSIG key name to be used by `Secondary`, `SecondaryForwarder`, and `SecondaryCatalog` zones.",
 				Optional:            true,
 			},
 			"validate_zone": rschema.BoolAttribute{
-				MarkdownDescription: "Set to `true` to enable ZONEMD validation. Valid only for `Secondary` zones.",
+				MarkdownDescription: "Set to `true` to enable ZONEMD validation. Valid only for `Secondary` zones. Changed in place.",
 				Optional:            true,
 			},
 			"initialize_forwarder": rschema.BoolAttribute{
@@ -110,46 +145,180 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 			},
 			"protocol": rschema.StringAttribute{
-				MarkdownDescription: "The DNS transport protocol to be used by the Conditional Forwarder zone. Valid values are `Udp`, `Tcp`, `Tls`, `Https`, `Quic`.",
+				MarkdownDescription: "The DNS transport protocol to be used by the Conditional Forwarder zone. Valid values are `Udp`, `Tcp`, `Tls`, `Https`, `Quic`. Changed in place on the FWD record at the zone apex.",
 				Optional:            true,
 				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Udp", "Tcp", "Tls", "Https", "Quic"),
+				},
 			},
 			"forwarder": rschema.StringAttribute{
-				MarkdownDescription: "The address of the DNS server to be used as a forwarder. Required for Conditional Forwarder zones.",
+				MarkdownDescription: "The address of the DNS server to be used as a forwarder. Required for Conditional Forwarder zones. Changed in place on the FWD record at the zone apex.",
 				Optional:            true,
 				Computed:            true,
 			},
 			"dnssec_validation": rschema.BoolAttribute{
-				MarkdownDescription: "Set to `true` to enable DNSSEC validation. Valid for Conditional Forwarder zones.",
+				MarkdownDescription: "Set to `true` to enable DNSSEC validation. Valid for Conditional Forwarder zones. Changed in place on the FWD record at the zone apex.",
 				Optional:            true,
 				Computed:            true,
 			},
 			"proxy_type": rschema.StringAttribute{
-				MarkdownDescription: "The type of proxy to be used for conditional forwarding. Valid values are `NoProxy`, `DefaultProxy`, `Http`, `Socks5`.",
+				MarkdownDescription: "The type of proxy to be used for conditional forwarding. Valid values are `NoProxy`, `DefaultProxy`, `Http`, `Socks5`. Changed in place on the FWD record at the zone apex.",
 				Optional:            true,
 				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("NoProxy", "DefaultProxy", "Http", "Socks5"),
+				},
 			},
 			"proxy_address": rschema.StringAttribute{
-				MarkdownDescription: "The proxy server address.",
+				MarkdownDescription: "The proxy server address. Changed in place on the FWD record at the zone apex.",
 				Optional:            true,
 				Computed:            true,
 			},
 			"proxy_port": rschema.Int64Attribute{
-				MarkdownDescription: "The proxy server port.",
+				MarkdownDescription: "The proxy server port. Changed in place on the FWD record at the zone apex.",
 				Optional:            true,
 				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
 			},
 			"proxy_username": rschema.StringAttribute{
-				MarkdownDescription: "The proxy server username.",
+				MarkdownDescription: "The proxy server username. Changed in place on the FWD record at the zone apex.",
 				Optional:            true,
 				Computed:            true,
 			},
 			"proxy_password": rschema.StringAttribute{
-				MarkdownDescription: "The proxy server password.",
+				MarkdownDescription: "The proxy server password. Changed in place on the FWD record at the zone apex.",
 				Optional:            true,
 				Computed:            true,
 				Sensitive:           true,
 			},
+			PROXY_PASSWORD_WO:         proxyPasswordWOAttribute(),
+			PROXY_PASSWORD_WO_VERSION: proxyPasswordWOVersionAttribute(),
+			"query_access": rschema.StringAttribute{
+				MarkdownDescription: "Who may query the zone. Valid values are `Deny`, `Allow`, `AllowOnlyPrivateNetworks`, `AllowOnlyZoneNameServers`, " +
+					"`UseSpecifiedNetworkACL`, `AllowZoneNameServersAndUseSpecifiedNetworkACL`. Changed in place.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(model.ZONE_ACCESS_DENY, model.ZONE_ACCESS_ALLOW, model.ZONE_ACCESS_ALLOW_ONLY_PRIVATE_NETWORKS,
+						model.ZONE_ACCESS_ALLOW_ONLY_ZONE_NAME_SERVERS, model.ZONE_ACCESS_USE_SPECIFIED_NETWORK_ACL,
+						model.ZONE_ACCESS_ALLOW_ZONE_NAME_SERVERS_AND_USE_ACL),
+				},
+			},
+			"query_access_network_acl": rschema.ListAttribute{
+				MarkdownDescription: "Networks allowed to query the zone when `query_access` uses the specified network ACL, " +
+					"e.g. `192.168.0.0/16`; prefix an entry with `!` to deny it. Entries are matched in order. Changed in place.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(networkACLEntryValidator{}),
+				},
+			},
+			"dynamic_update": rschema.StringAttribute{
+				MarkdownDescription: "Who may send dynamic updates (RFC 2136) to the zone. Valid values are `Deny`, `Allow`, `AllowOnlyZoneNameServers`, " +
+					"`UseSpecifiedNetworkACL`, `AllowZoneNameServersAndUseSpecifiedNetworkACL`. Changed in place.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(model.ZONE_ACCESS_DENY, model.ZONE_ACCESS_ALLOW, model.ZONE_ACCESS_ALLOW_ONLY_ZONE_NAME_SERVERS,
+						model.ZONE_ACCESS_USE_SPECIFIED_NETWORK_ACL, model.ZONE_ACCESS_ALLOW_ZONE_NAME_SERVERS_AND_USE_ACL),
+				},
+			},
+			"dynamic_update_network_acl": rschema.ListAttribute{
+				MarkdownDescription: "Networks allowed to send dynamic updates when `dynamic_update` uses the specified network ACL; " +
+					"prefix an entry with `!` to deny it. Changed in place.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(networkACLEntryValidator{}),
+				},
+			},
+			"update_security_policies": rschema.ListNestedAttribute{
+				MarkdownDescription: "Restricts dynamic updates signed with a TSIG key to some names and record types. Changed in place.",
+				Optional:            true,
+				NestedObject: rschema.NestedAttributeObject{
+					Attributes: map[string]rschema.Attribute{
+						"tsig_key_name": rschema.StringAttribute{
+							MarkdownDescription: "Name of the TSIG key updates are signed with.",
+							Required:            true,
+						},
+						"domain": rschema.StringAttribute{
+							MarkdownDescription: "Domain name the key may update, `*.example.com` for all its subdomains.",
+							Required:            true,
+						},
+						"allowed_types": rschema.ListAttribute{
+							MarkdownDescription: "Record types the key may update, `ANY` for all.",
+							ElementType:         types.StringType,
+							Required:            true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"notify": rschema.StringAttribute{
+				MarkdownDescription: "Name servers sent NOTIFY on zone changes. Valid values are `None`, `ZoneNameServers`, `SpecifiedNameServers`, " +
+					"`BothZoneAndSpecifiedNameServers`, `SeparateNameServersForCatalogAndMemberZones`. Changed in place.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(model.ZONE_NOTIFY_NONE, model.ZONE_NOTIFY_ZONE_NAME_SERVERS, model.ZONE_NOTIFY_SPECIFIED_NAME_SERVERS,
+						model.ZONE_NOTIFY_BOTH_ZONE_AND_SPECIFIED, model.ZONE_NOTIFY_SEPARATE_FOR_CATALOG_AND_ZONES),
+				},
+			},
+			"notify_name_servers": rschema.ListAttribute{
+				MarkdownDescription: "IP addresses of the name servers sent NOTIFY when `notify` uses specified name servers, " +
+					"e.g. secondaries not listed in the zone NS records. Changed in place.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(ipAddressValidator{}),
+				},
+			},
+			"zone_transfer": rschema.StringAttribute{
+				MarkdownDescription: "Who may transfer the zone (AXFR/IXFR) from this server. Valid values are `Deny`, `Allow`, `AllowOnlyZoneNameServers`, " +
+					"`UseSpecifiedNetworkACL`, `AllowZoneNameServersAndUseSpecifiedNetworkACL`. Changed in place.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(model.ZONE_ACCESS_DENY, model.ZONE_ACCESS_ALLOW, model.ZONE_ACCESS_ALLOW_ONLY_ZONE_NAME_SERVERS,
+						model.ZONE_ACCESS_USE_SPECIFIED_NETWORK_ACL, model.ZONE_ACCESS_ALLOW_ZONE_NAME_SERVERS_AND_USE_ACL),
+				},
+			},
+			"zone_transfer_name_servers": rschema.ListAttribute{
+				MarkdownDescription: "IP addresses (or networks) of the secondaries allowed to transfer the zone when `zone_transfer` uses the specified network ACL; " +
+					"prefix an entry with `!` to deny it. Changed in place.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(networkACLEntryValidator{}),
+				},
+			},
+			"dnssec_status": rschema.StringAttribute{
+				MarkdownDescription: "The DNSSEC status of the zone, e.g. `Unsigned` or `SignedWithNSEC`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"soa_serial": rschema.Int64Attribute{
+				MarkdownDescription: "The SOA serial of the zone, changing with its records.",
+				Computed:            true,
+			},
+			"last_modified": rschema.StringAttribute{
+				MarkdownDescription: "When the zone was last modified.",
+				Computed:            true,
+			},
+			"disabled": rschema.BoolAttribute{
+				MarkdownDescription: "Whether the zone is disabled on the server.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]rschema.Block{
+			"wait_for_secondaries": waitForSecondariesBlock(),
+			"timeouts":             timeoutsBlock(ctx),
 		},
 	}
 }
@@ -184,60 +353,54 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 	defer tflog.Info(ctx, "create: end")
 	r.reqMutex.Lock()
 	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if r.isInternalZone(ctx, domainToASCII(planData.Name.ValueString()), &resp.Diagnostics) {
+		return
+	}
 
 	apiZone := tfZone2model(planData)
+	apiZone.ProxyPassword = proxyPassword(ctx, req.Config, apiZone.ProxyPassword, &resp.Diagnostics)
 
-	err := r.client.CreateZone(ctx, apiZone)
+	err := replicaWarning(r.client.CreateZone(ctx, apiZone), &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error",
 			fmt.Sprintf("Unable to create zone: %s", err))
 		return
 	}
 
+	err = replicaWarning(r.client.SetZoneOptions(ctx, apiZone.Name, tfZoneOptions(planData)), &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to set zone options: %s", err))
+		return
+	}
+
 	// Read back the zone to get computed values
-	zoneName := planData.Name.ValueString()
-	zones, err := r.client.ListZones(ctx)
+	zone, err := r.readZone(ctx, domainToASCII(planData.Name.ValueString()))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error",
 			fmt.Sprintf("Unable to read zone after create: %s", err))
 		return
 	}
-
-	for _, zone := range zones {
-		if zone.Name == zoneName {
-			// For Forwarder zones, fetch the FWD record to get forwarder configuration
-			if zone.Type == model.ZONE_FORWARDER || zone.Type == model.ZONE_SECONDARYFORWARDER {
-				records, err := r.client.GetZoneRecords(ctx, zoneName)
-				if err != nil {
-					tflog.Warn(ctx, fmt.Sprintf("Failed to fetch zone records for forwarder config: %s", err))
-				} else {
-					for _, record := range records {
-						if record.Type == model.REC_FWD {
-							zone.Forwarder = record.Forwarder
-							zone.Protocol = record.Protocol
-							if record.DnssecValidation {
-								v := true
-								zone.DnssecValidation = &v
-							}
-							zone.ProxyType = record.ProxyType
-							zone.ProxyAddress = record.ProxyAddress
-							if record.ProxyPort > 0 {
-								v := int64(record.ProxyPort)
-								zone.ProxyPort = &v
-							}
-							zone.ProxyUsername = record.ProxyUsername
-							zone.ProxyPassword = record.ProxyPassword
-							break
-						}
-					}
-				}
-			}
-			planData = modelZone2tf(zone)
-			break
-		}
+	if zone != nil {
+		planData = mergeZoneState(planData, modelZone2tf(*zone))
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+
+	// the zone is saved either way, secondaries not updated in time taint it
+	if err := waitForSecondaries(ctx, r.client, planData.WaitForSecondaries, domainToASCII(planData.Name.ValueString())); err != nil {
+		resp.Diagnostics.AddError("Secondaries not updated", err.Error())
+	}
 }
 
 func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -252,53 +415,32 @@ func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	defer tflog.Info(ctx, "read: end")
 	r.reqMutex.Lock()
 	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
 
-	// Get all zones and find the matching one
-	zones, err := r.client.ListZones(ctx)
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	zone, err := r.readZone(ctx, domainToASCII(stateData.Name.ValueString()))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error",
 			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
 		return
 	}
-
-	zoneName := stateData.Name.ValueString()
-	for _, zone := range zones {
-		if zone.Name == zoneName {
-			// For Forwarder zones, fetch the FWD record to get forwarder configuration
-			if zone.Type == model.ZONE_FORWARDER || zone.Type == model.ZONE_SECONDARYFORWARDER {
-				records, err := r.client.GetZoneRecords(ctx, zoneName)
-				if err != nil {
-					tflog.Warn(ctx, fmt.Sprintf("Failed to fetch zone records for forwarder config: %s", err))
-				} else {
-					for _, record := range records {
-						if record.Type == model.REC_FWD {
-							zone.Forwarder = record.Forwarder
-							zone.Protocol = record.Protocol
-							if record.DnssecValidation {
-								v := true
-								zone.DnssecValidation = &v
-							}
-							zone.ProxyType = record.ProxyType
-							zone.ProxyAddress = record.ProxyAddress
-							if record.ProxyPort > 0 {
-								v := int64(record.ProxyPort)
-								zone.ProxyPort = &v
-							}
-							zone.ProxyUsername = record.ProxyUsername
-							zone.ProxyPassword = record.ProxyPassword
-							break
-						}
-					}
-				}
-			}
-			stateData = modelZone2tf(zone)
-			resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
-			return
-		}
+	if zone == nil {
+		// Zone not found, remove from state
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
-	// Zone not found, remove from state
-	resp.State.RemoveResource(ctx)
+	// settings read from zone options are only tracked if present in the
+	// state already (configured or imported), create-only ones are kept as is
+	stateData = mergeZoneState(stateData, modelZone2tf(*zone))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
 }
 
 func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -313,75 +455,78 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	defer tflog.Info(ctx, "update: end")
 	r.reqMutex.Lock()
 	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	// For now, zones are immutable - delete and recreate
 	var stateData tfDNSZone
 	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Delete old zone
-	err := r.client.DeleteZone(ctx, stateData.Name.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error",
-			fmt.Sprintf("Unable to delete old zone: %s", err))
+	if r.isInternalZone(ctx, domainToASCII(stateData.Name.ValueString()), &resp.Diagnostics) {
 		return
 	}
 
-	// Create new zone
-	apiZone := tfZone2model(planData)
-	err = r.client.CreateZone(ctx, apiZone)
+	// settings only given at creation cannot be changed: delete and recreate,
+	// but the forwarder settings held by the FWD record of Forwarder zones
+	if createSettingsChanged(planData, stateData) {
+		err := replicaWarning(r.client.DeleteZone(ctx, domainToASCII(stateData.Name.ValueString())), &resp.Diagnostics)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Unable to delete old zone: %s", err))
+			return
+		}
+
+		apiZone := tfZone2model(planData)
+		apiZone.ProxyPassword = proxyPassword(ctx, req.Config, apiZone.ProxyPassword, &resp.Diagnostics)
+		err = replicaWarning(r.client.CreateZone(ctx, apiZone), &resp.Diagnostics)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Unable to create new zone: %s", err))
+			return
+		}
+	} else if planData.Type.ValueString() == string(model.ZONE_FORWARDER) && forwarderSettingsChanged(planData, stateData) {
+		apiZone := tfZone2model(planData)
+		apiZone.ProxyPassword = proxyPassword(ctx, req.Config, apiZone.ProxyPassword, &resp.Diagnostics)
+		if err := updateForwarderRecord(ctx, r.client, apiZone, &resp.Diagnostics); err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Unable to update the forwarder of the zone: %s", err))
+			return
+		}
+	}
+
+	err := replicaWarning(r.client.SetZoneOptions(ctx, domainToASCII(planData.Name.ValueString()), tfZoneOptions(planData)), &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error",
-			fmt.Sprintf("Unable to create new zone: %s", err))
+			fmt.Sprintf("Unable to set zone options: %s", err))
 		return
 	}
 
 	// Read back the zone to get computed values
-	zoneName := planData.Name.ValueString()
-	zones, err := r.client.ListZones(ctx)
+	zone, err := r.readZone(ctx, domainToASCII(planData.Name.ValueString()))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error",
 			fmt.Sprintf("Unable to read zone after update: %s", err))
 		return
 	}
-
-	for _, zone := range zones {
-		if zone.Name == zoneName {
-			// For Forwarder zones, fetch the FWD record to get forwarder configuration
-			if zone.Type == model.ZONE_FORWARDER || zone.Type == model.ZONE_SECONDARYFORWARDER {
-				records, err := r.client.GetZoneRecords(ctx, zoneName)
-				if err != nil {
-					tflog.Warn(ctx, fmt.Sprintf("Failed to fetch zone records for forwarder config: %s", err))
-				} else {
-					for _, record := range records {
-						if record.Type == model.REC_FWD {
-							zone.Forwarder = record.Forwarder
-							zone.Protocol = record.Protocol
-							if record.DnssecValidation {
-								v := true
-								zone.DnssecValidation = &v
-							}
-							zone.ProxyType = record.ProxyType
-							zone.ProxyAddress = record.ProxyAddress
-							if record.ProxyPort > 0 {
-								v := int64(record.ProxyPort)
-								zone.ProxyPort = &v
-							}
-							zone.ProxyUsername = record.ProxyUsername
-							zone.ProxyPassword = record.ProxyPassword
-							break
-						}
-					}
-				}
-			}
-			planData = modelZone2tf(zone)
-			break
-		}
+	if zone != nil {
+		planData = mergeZoneState(planData, modelZone2tf(*zone))
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+
+	// the zone is saved either way, secondaries not updated in time taint it
+	if err := waitForSecondaries(ctx, r.client, planData.WaitForSecondaries, domainToASCII(planData.Name.ValueString())); err != nil {
+		resp.Diagnostics.AddError("Secondaries not updated", err.Error())
+	}
 }
 
 func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -396,8 +541,21 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	defer tflog.Info(ctx, "delete: end")
 	r.reqMutex.Lock()
 	defer r.reqMutex.Unlock()
+	defer reportRequestStats(r.client, &resp.Diagnostics)
 
-	err := r.client.DeleteZone(ctx, stateData.Name.ValueString())
+	deleteTimeout, diags := stateData.Timeouts.Delete(ctx, DEFAULT_DELETE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if r.isInternalZone(ctx, domainToASCII(stateData.Name.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
+	err := replicaWarning(r.client.DeleteZone(ctx, domainToASCII(stateData.Name.ValueString())), &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error",
 			fmt.Sprintf("Deleting DNS zone failed: %s", err))
@@ -405,15 +563,126 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+// internal zones (localhost, built-in reverse zones etc) are owned by the server:
+// report an error (and return true) if the zone is one of them, or if lookup failed
+func (r *ZoneResource) isInternalZone(ctx context.Context, zoneName string, diags *diag.Diagnostics) bool {
+	zones, err := r.client.ListZones(ctx)
+	if err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
+		return true
+	}
+
+	for _, zone := range zones {
+		if zone.Name == zoneName && zone.Internal {
+			diags.AddError("Internal zone",
+				fmt.Sprintf("Zone '%s' is an internal zone of the DNS server and cannot be "+
+					"created, replaced or deleted by terraform", zoneName))
+			return true
+		}
+	}
+
+	return false
+}
+
 // terraform import technitium_zone.example example.com
 func (r *ZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	zoneName := req.ID
+	zoneName := domainToASCII(req.ID)
+
+	ctx = tflog.SetField(ctx, "operation", "import")
+	ctx = tflog.SetField(ctx, "name", zoneName)
+	tflog.Info(ctx, "import: start")
+	defer tflog.Info(ctx, "import: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
 
-	// Set the zone name in the state
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), zoneName)...)
+	// Look the zone up to get its real type and settings
+	zone, err := r.readZone(ctx, zoneName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
+		return
+	}
+	if zone == nil {
+		resp.Diagnostics.AddError("Zone not found",
+			fmt.Sprintf("Zone with name '%s' not found", zoneName))
+		return
+	}
 
-	// Set a default type since it's required - this will be updated by Read()
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), "Primary")...)
+	stateData := modelZone2tf(*zone)
+	// the name as imported, e.g. the Unicode form of an IDN zone
+	stateData.Name = types.StringValue(req.ID)
+	stateData.Timeouts = nullTimeouts()
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+// read zone from the server: zone list entry completed with zone options and,
+// for forwarder zones, the FWD record settings; nil if there is no such zone
+func (r *ZoneResource) readZone(ctx context.Context, zoneName string) (*model.DNSZone, error) {
+	zones, err := r.client.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, zone := range zones {
+		if !sameDomainName(zone.Name, zoneName) {
+			continue
+		}
+
+		options, err := r.client.GetZoneOptions(ctx, zoneName)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Failed to fetch zone options: %s", err))
+		} else {
+			zone.Catalog = options.Catalog
+			zone.PrimaryNameServerAddresses = strings.Join(options.PrimaryNameServerAddresses, ",")
+			zone.ZoneTransferProtocol = options.PrimaryZoneTransferProtocol
+			zone.TsigKeyName = options.PrimaryZoneTransferTsigKeyName
+			zone.UseSoaSerialDateScheme = options.UseSoaSerialDateScheme
+			zone.ValidateZone = options.ValidateZone
+			zone.QueryAccess = options.QueryAccess
+			zone.QueryAccessNetworkACL = append([]string{}, options.QueryAccessNetworkACL...)
+			zone.Update = options.Update
+			zone.UpdateNetworkACL = append([]string{}, options.UpdateNetworkACL...)
+			zone.UpdateSecurityPolicies = append([]model.DNSZoneUpdatePolicy{}, options.UpdateSecurityPolicies...)
+			zone.Notify = options.Notify
+			zone.NotifyNameServers = append([]string{}, options.NotifyNameServers...)
+			zone.ZoneTransfer = options.ZoneTransfer
+			zone.ZoneTransferNetworkACL = append([]string{}, options.ZoneTransferNetworkACL...)
+		}
+
+		// For Forwarder zones, fetch the FWD record to get forwarder configuration
+		if zone.Type == model.ZONE_FORWARDER || zone.Type == model.ZONE_SECONDARYFORWARDER {
+			records, err := r.client.GetZoneRecords(ctx, zoneName)
+			if err != nil {
+				tflog.Warn(ctx, fmt.Sprintf("Failed to fetch zone records for forwarder config: %s", err))
+			} else {
+				for _, record := range records {
+					if record.Type == model.REC_FWD {
+						zone.Forwarder = record.Forwarder
+						zone.Protocol = record.Protocol
+						dnssecValidation := record.DnssecValidation
+						zone.DnssecValidation = &dnssecValidation
+						zone.ProxyType = record.ProxyType
+						zone.ProxyAddress = record.ProxyAddress
+						if record.ProxyPort > 0 {
+							v := int64(record.ProxyPort)
+							zone.ProxyPort = &v
+						}
+						zone.ProxyUsername = record.ProxyUsername
+						zone.ProxyPassword = record.ProxyPassword
+						break
+					}
+				}
+			}
+		}
+
+		return &zone, nil
+	}
+
+	return nil, nil
 }
 
 // ZoneDataSource defines the data source implementation
@@ -517,8 +786,9 @@ func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	zoneName := config.Name.ValueString()
 	for _, zone := range zones {
-		if zone.Name == zoneName {
+		if sameDomainName(zone.Name, zoneName) {
 			result := modelZone2tfDataSource(zone)
+			result.Name = config.Name
 			resp.Diagnostics.Append(resp.State.Set(ctx, &result)...)
 			return
 		}
@@ -544,6 +814,7 @@ type tfDNSZoneDataSource struct {
 // Helper functions
 
 func setZoneLogCtx(ctx context.Context, tfZone tfDNSZone, op string) context.Context {
+	ctx = maskSensitiveLogFields(ctx)
 	logAttributes := map[string]interface{}{
 		"operation": op,
 		"name":      tfZone.Name.ValueString(),
@@ -561,7 +832,7 @@ func setZoneLogCtx(ctx context.Context, tfZone tfDNSZone, op string) context.Con
 
 func tfZone2model(tfData tfDNSZone) model.DNSZone {
 	zone := model.DNSZone{
-		Name: tfData.Name.ValueString(),
+		Name: domainToASCII(tfData.Name.ValueString()),
 		Type: model.DNSZoneType(tfData.Type.ValueString()),
 	}
 
@@ -621,8 +892,12 @@ func tfZone2model(tfData tfDNSZone) model.DNSZone {
 
 func modelZone2tf(apiData model.DNSZone) tfDNSZone {
 	result := tfDNSZone{
-		Name: types.StringValue(apiData.Name),
-		Type: types.StringValue(string(apiData.Type)),
+		Name:         types.StringValue(apiData.Name),
+		Type:         types.StringValue(string(apiData.Type)),
+		DNSSecStatus: types.StringValue(apiData.DNSSecStatus),
+		SOASerial:    types.Int64Value(int64(apiData.SOASerial)),
+		LastModified: types.StringValue(apiData.LastModified),
+		Disabled:     types.BoolValue(apiData.Disabled),
 	}
 
 	// Populate optional fields if they have values
@@ -671,10 +946,276 @@ func modelZone2tf(apiData model.DNSZone) tfDNSZone {
 	if apiData.ProxyPassword != "" {
 		result.ProxyPassword = types.StringValue(apiData.ProxyPassword)
 	}
+	if apiData.QueryAccess != "" {
+		result.QueryAccess = types.StringValue(apiData.QueryAccess)
+	}
+	result.QueryAccessNetworkACL = stringListValue(apiData.QueryAccessNetworkACL)
+	if apiData.Update != "" {
+		result.DynamicUpdate = types.StringValue(apiData.Update)
+	}
+	result.DynamicUpdateNetworkACL = stringListValue(apiData.UpdateNetworkACL)
+	result.UpdateSecurityPolicies = updatePoliciesValue(apiData.UpdateSecurityPolicies)
+	if apiData.Notify != "" {
+		result.Notify = types.StringValue(apiData.Notify)
+	}
+	result.NotifyNameServers = stringListValue(apiData.NotifyNameServers)
+	if apiData.ZoneTransfer != "" {
+		result.ZoneTransfer = types.StringValue(apiData.ZoneTransfer)
+	}
+	result.ZoneTransferNameServers = stringListValue(apiData.ZoneTransferNetworkACL)
+
+	return result
+}
+
+var updatePolicyType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"tsig_key_name": types.StringType,
+	"domain":        types.StringType,
+	"allowed_types": types.ListType{ElemType: types.StringType},
+}}
+
+// list of read update security policies, null if they were not read
+func updatePoliciesValue(policies []model.DNSZoneUpdatePolicy) types.List {
+	if policies == nil {
+		return types.ListNull(updatePolicyType)
+	}
+	elements := make([]attr.Value, 0, len(policies))
+	for _, policy := range policies {
+		elements = append(elements, types.ObjectValueMust(updatePolicyType.AttrTypes, map[string]attr.Value{
+			"tsig_key_name": types.StringValue(policy.TsigKeyName),
+			"domain":        types.StringValue(policy.Domain),
+			"allowed_types": stringListValue(policy.AllowedTypes),
+		}))
+	}
+	return types.ListValueMust(updatePolicyType, elements)
+}
+
+// API update security policies of the configured ones, nil if not set
+func updatePoliciesModel(list types.List) []model.DNSZoneUpdatePolicy {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+	policies := []model.DNSZoneUpdatePolicy{}
+	for _, element := range list.Elements() {
+		policy, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+		attributes := policy.Attributes()
+		tsigKeyName, _ := attributes["tsig_key_name"].(types.String)
+		domain, _ := attributes["domain"].(types.String)
+		allowedTypes, _ := attributes["allowed_types"].(types.List)
+		policies = append(policies, model.DNSZoneUpdatePolicy{
+			TsigKeyName:  tsigKeyName.ValueString(),
+			Domain:       domain.ValueString(),
+			AllowedTypes: stringListModel(allowedTypes),
+		})
+	}
+	return policies
+}
+
+// list of a read API list, null if it was not read
+func stringListValue(values []string) types.List {
+	if values == nil {
+		return types.ListNull(types.StringType)
+	}
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// API list of a configured list, nil if it is not set
+func stringListModel(list types.List) []string {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+	values := []string{}
+	for _, element := range list.Elements() {
+		if value, ok := element.(types.String); ok {
+			values = append(values, value.ValueString())
+		}
+	}
+	return values
+}
+
+// zone settings changed in place through zone options
+func tfZoneOptions(tfData tfDNSZone) model.DNSZoneOptionsUpdate {
+	return model.DNSZoneOptionsUpdate{
+		QueryAccess:            tfData.QueryAccess.ValueString(),
+		QueryAccessNetworkACL:  stringListModel(tfData.QueryAccessNetworkACL),
+		Update:                 tfData.DynamicUpdate.ValueString(),
+		UpdateNetworkACL:       stringListModel(tfData.DynamicUpdateNetworkACL),
+		UpdateSecurityPolicies: updatePoliciesModel(tfData.UpdateSecurityPolicies),
+		Notify:                 tfData.Notify.ValueString(),
+		NotifyNameServers:      stringListModel(tfData.NotifyNameServers),
+		ZoneTransfer:           tfData.ZoneTransfer.ValueString(),
+		ZoneTransferNetworkACL: stringListModel(tfData.ZoneTransferNameServers),
+		ValidateZone:           tfData.ValidateZone.ValueBoolPointer(),
+	}
+}
+
+// whether a setting only given at zone creation changes, computed ones not
+// known yet are not changed by the plan
+func createSettingsChanged(plan tfDNSZone, state tfDNSZone) bool {
+	changed := func(planValue attr.Value, stateValue attr.Value) bool {
+		return !planValue.IsUnknown() && !planValue.Equal(stateValue)
+	}
+	return changed(plan.Catalog, state.Catalog) ||
+		changed(plan.UseSoaSerialDateScheme, state.UseSoaSerialDateScheme) ||
+		changed(plan.PrimaryNameServerAddresses, state.PrimaryNameServerAddresses) ||
+		changed(plan.ZoneTransferProtocol, state.ZoneTransferProtocol) ||
+		changed(plan.TsigKeyName, state.TsigKeyName) ||
+		changed(plan.InitializeForwarder, state.InitializeForwarder) ||
+		// the FWD record of Forwarder zones is updated in place instead
+		(plan.Type.ValueString() != string(model.ZONE_FORWARDER) && forwarderSettingsChanged(plan, state))
+}
+
+// whether a setting of the FWD record at the apex of Forwarder zones changes
+func forwarderSettingsChanged(plan tfDNSZone, state tfDNSZone) bool {
+	changed := func(planValue attr.Value, stateValue attr.Value) bool {
+		return !planValue.IsUnknown() && !planValue.Equal(stateValue)
+	}
+	return changed(plan.Protocol, state.Protocol) ||
+		changed(plan.Forwarder, state.Forwarder) ||
+		changed(plan.DnssecValidation, state.DnssecValidation) ||
+		changed(plan.ProxyType, state.ProxyType) ||
+		changed(plan.ProxyAddress, state.ProxyAddress) ||
+		changed(plan.ProxyPort, state.ProxyPort) ||
+		changed(plan.ProxyUsername, state.ProxyUsername) ||
+		changed(plan.ProxyPassword, state.ProxyPassword) ||
+		changed(plan.ProxyPasswordWOVersion, state.ProxyPasswordWOVersion)
+}
+
+// apply the forwarder settings of a Forwarder zone to the FWD record at its
+// apex, as the record resource would: recreating the zone would drop its
+// other records. Settings not planned keep their current value.
+func updateForwarderRecord(ctx context.Context, client model.DNSApiClient, zone model.DNSZone, diags *diag.Diagnostics) error {
+	records, err := client.GetZoneRecords(ctx, zone.Name)
+	if err != nil {
+		return err
+	}
+	for _, current := range records {
+		if current.Type != model.REC_FWD || !sameHostName(string(current.Domain), zone.Name) {
+			continue
+		}
+		updated := current
+		if zone.Protocol != "" {
+			updated.Protocol = zone.Protocol
+		}
+		if zone.Forwarder != "" {
+			updated.Forwarder = zone.Forwarder
+		}
+		if zone.DnssecValidation != nil {
+			updated.DnssecValidation = *zone.DnssecValidation
+		}
+		if zone.ProxyType != "" {
+			updated.ProxyType = zone.ProxyType
+		}
+		if zone.ProxyAddress != "" {
+			updated.ProxyAddress = zone.ProxyAddress
+		}
+		if zone.ProxyPort != nil {
+			updated.ProxyPort = uint16(*zone.ProxyPort)
+		}
+		if zone.ProxyUsername != "" {
+			updated.ProxyUsername = zone.ProxyUsername
+		}
+		if zone.ProxyPassword != "" {
+			updated.ProxyPassword = zone.ProxyPassword
+		}
+		return replicaWarning(client.UpdateRecord(ctx, current, updated), diags)
+	}
+	return fmt.Errorf("zone %s has no FWD record at its apex, set initialize_forwarder to create one", zone.Name)
+}
+
+// refresh state with server data: optional settings are only updated if they
+// are tracked in prior state, the ones that cannot be read back are kept
+func mergeZoneState(prior tfDNSZone, server tfDNSZone) tfDNSZone {
+	result := server
+
+	keepIfUntracked := func(priorValue attr.Value, serverValue attr.Value) bool {
+		return priorValue.IsNull() && !serverValue.IsNull()
+	}
+	if sameDomainName(prior.Name.ValueString(), server.Name.ValueString()) {
+		// configured Unicode name of an IDN zone
+		result.Name = prior.Name
+	}
+	if keepIfUntracked(prior.Catalog, server.Catalog) {
+		result.Catalog = prior.Catalog
+	}
+	if keepIfUntracked(prior.PrimaryNameServerAddresses, server.PrimaryNameServerAddresses) ||
+		sameAddressList(prior.PrimaryNameServerAddresses.ValueString(), server.PrimaryNameServerAddresses.ValueString()) {
+		// also keep configured formatting (spaces) of the same address list
+		result.PrimaryNameServerAddresses = prior.PrimaryNameServerAddresses
+	}
+	if keepIfUntracked(prior.ZoneTransferProtocol, server.ZoneTransferProtocol) {
+		result.ZoneTransferProtocol = prior.ZoneTransferProtocol
+	}
+	if keepIfUntracked(prior.TsigKeyName, server.TsigKeyName) {
+		result.TsigKeyName = prior.TsigKeyName
+	}
+	if keepIfUntracked(prior.ValidateZone, server.ValidateZone) {
+		result.ValidateZone = prior.ValidateZone
+	}
+	// only returned for the zone types having a SOA record
+	if keepIfUntracked(prior.UseSoaSerialDateScheme, server.UseSoaSerialDateScheme) || server.UseSoaSerialDateScheme.IsNull() {
+		result.UseSoaSerialDateScheme = prior.UseSoaSerialDateScheme
+	}
+	if keepIfUntracked(prior.QueryAccess, server.QueryAccess) {
+		result.QueryAccess = prior.QueryAccess
+	}
+	if keepIfUntracked(prior.QueryAccessNetworkACL, server.QueryAccessNetworkACL) {
+		result.QueryAccessNetworkACL = prior.QueryAccessNetworkACL
+	}
+	if keepIfUntracked(prior.DynamicUpdate, server.DynamicUpdate) {
+		result.DynamicUpdate = prior.DynamicUpdate
+	}
+	if keepIfUntracked(prior.DynamicUpdateNetworkACL, server.DynamicUpdateNetworkACL) {
+		result.DynamicUpdateNetworkACL = prior.DynamicUpdateNetworkACL
+	}
+	if keepIfUntracked(prior.UpdateSecurityPolicies, server.UpdateSecurityPolicies) {
+		result.UpdateSecurityPolicies = prior.UpdateSecurityPolicies
+	}
+	if keepIfUntracked(prior.Notify, server.Notify) {
+		result.Notify = prior.Notify
+	}
+	if keepIfUntracked(prior.NotifyNameServers, server.NotifyNameServers) {
+		result.NotifyNameServers = prior.NotifyNameServers
+	}
+	if keepIfUntracked(prior.ZoneTransfer, server.ZoneTransfer) {
+		result.ZoneTransfer = prior.ZoneTransfer
+	}
+	if keepIfUntracked(prior.ZoneTransferNameServers, server.ZoneTransferNameServers) {
+		result.ZoneTransferNameServers = prior.ZoneTransferNameServers
+	}
+	// not returned by the API
+	result.InitializeForwarder = prior.InitializeForwarder
+	// the write-only password is not kept in state
+	result.ProxyPasswordWOVersion = prior.ProxyPasswordWOVersion
+	if !prior.ProxyPasswordWOVersion.IsNull() {
+		result.ProxyPassword = types.StringNull()
+	}
+	result.Timeouts = prior.Timeouts
+	result.WaitForSecondaries = prior.WaitForSecondaries
 
 	return result
 }
 
+// compare comma separated lists, ignoring spacing
+func sameAddressList(list1 string, list2 string) bool {
+	split := func(list string) []string {
+		var res []string
+		for _, item := range strings.Split(list, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				res = append(res, item)
+			}
+		}
+		return res
+	}
+	return slices.Equal(split(list1), split(list2))
+}
+
 func modelZone2tfDataSource(apiData model.DNSZone) tfDNSZoneDataSource {
 	return tfDNSZoneDataSource{
 		Name:         types.StringValue(apiData.Name),
@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &ZoneDiffDataSource{}
+)
+
+// records of the zone that are not compared by default: the SOA, and the
+// DNSSEC records maintained by the server
+var defaultZoneDiffIgnoredTypes = []string{"SOA", "DNSKEY", "RRSIG", "NSEC", "NSEC3", "NSEC3PARAM"}
+
+type tfZoneDiff struct {
+	Zone        types.String               `tfsdk:"zone"`
+	Records     map[string]tfDesiredRecord `tfsdk:"records"`
+	IgnoreTypes []types.String             `tfsdk:"ignore_types"`
+	ToAdd       []types.String             `tfsdk:"to_add"`
+	ToChange    []types.String             `tfsdk:"to_change"`
+	ToDelete    []tfManagedRecord          `tfsdk:"to_delete"`
+	InSync      types.Bool                 `tfsdk:"in_sync"`
+}
+
+type tfDesiredRecord struct {
+	Domain types.String `tfsdk:"domain"`
+	Type   types.String `tfsdk:"type"`
+	Value  types.String `tfsdk:"value"`
+	TTL    types.Int64  `tfsdk:"ttl"`
+}
+
+// ZoneDiffDataSource compares desired records with the records of a zone
+type ZoneDiffDataSource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func ZoneDiffDataSourceFactory(m *sync.Mutex) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &ZoneDiffDataSource{reqMutex: m}
+	}
+}
+
+func (d *ZoneDiffDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_diff"
+}
+
+func (d *ZoneDiffDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Compares desired records with the records of a zone, and reports the records to add, change and delete " +
+			"for the zone to hold exactly the desired ones. Nothing is changed.",
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The zone to compare.",
+				Required:            true,
+			},
+			"records": schema.MapNestedAttribute{
+				MarkdownDescription: "Desired records, by a key of your choice reported in `to_add` and `to_change`.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The domain name of the record (FQDN).",
+							Required:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The record type.",
+							Required:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The record value, in the format of the `value` part of import IDs, e.g. `10:mail.example.com` for MX records.",
+							Required:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "The record TTL. Not compared when not set.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"ignore_types": schema.ListAttribute{
+				MarkdownDescription: "Record types of the zone left out of the comparison. " +
+					"`SOA` and the DNSSEC types maintained by the server (`DNSKEY`, `RRSIG`, `NSEC`, `NSEC3`, `NSEC3PARAM`) by default.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			"to_add": schema.ListAttribute{
+				MarkdownDescription: "Keys of the desired records missing from the zone, sorted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"to_change": schema.ListAttribute{
+				MarkdownDescription: "Keys of the desired records present in the zone with another TTL, sorted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"to_delete": schema.ListNestedAttribute{
+				MarkdownDescription: "Records of the zone matching no desired record, in server order.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The domain name of the record.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The record type.",
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "The record TTL.",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The record value, in the format of the `value` part of import IDs.",
+							Computed:            true,
+						},
+						"comments": schema.StringAttribute{
+							MarkdownDescription: "The record comments.",
+							Computed:            true,
+						},
+						"import_id": schema.StringAttribute{
+							MarkdownDescription: "ID to import the record with into a `technitium_record`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"in_sync": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone holds exactly the desired records.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ZoneDiffDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ZoneDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config tfZoneDiff
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "zone", config.Zone.ValueString())
+	tflog.Info(ctx, "read zone diff: start")
+	defer tflog.Info(ctx, "read zone diff: end")
+	d.reqMutex.Lock()
+	defer d.reqMutex.Unlock()
+
+	if config.IgnoreTypes == nil {
+		config.IgnoreTypes = modelStrings2tf(defaultZoneDiffIgnoredTypes)
+	}
+
+	zoneName := domainToASCII(config.Zone.ValueString())
+	records, err := d.client.GetZoneRecords(ctx, zoneName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading records of zone %s: query failed: %s", zoneName, err))
+		return
+	}
+
+	// an empty comment matches every record
+	live := managedRecords(records, zoneName, "")
+	toAdd, toChange, toDelete := zoneDiff(live, config.Records, tfStrings2model(config.IgnoreTypes))
+	config.ToAdd = modelStrings2tf(toAdd)
+	config.ToChange = modelStrings2tf(toChange)
+	config.ToDelete = toDelete
+	config.InSync = types.BoolValue(len(toAdd) == 0 && len(toChange) == 0 && len(toDelete) == 0)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// keys of the desired records to add and to change, and the zone records to
+// delete. Records are the same when their domain, type and value are
+func zoneDiff(live []tfManagedRecord, desired map[string]tfDesiredRecord, ignoreTypes []string) ([]string, []string, []tfManagedRecord) {
+	same := func(record tfManagedRecord, want tfDesiredRecord) bool {
+		return sameDomainName(record.Domain.ValueString(), want.Domain.ValueString()) &&
+			strings.EqualFold(record.Type.ValueString(), want.Type.ValueString()) &&
+			record.Value.ValueString() == want.Value.ValueString()
+	}
+	ignored := func(record tfManagedRecord) bool {
+		return slices.ContainsFunc(ignoreTypes, func(t string) bool { return strings.EqualFold(t, record.Type.ValueString()) })
+	}
+
+	toAdd, toChange := []string{}, []string{}
+	for key, want := range desired {
+		i := slices.IndexFunc(live, func(record tfManagedRecord) bool { return same(record, want) })
+		switch {
+		case i < 0:
+			toAdd = append(toAdd, key)
+		case !want.TTL.IsNull() && !want.TTL.Equal(live[i].TTL):
+			toChange = append(toChange, key)
+		}
+	}
+	sort.Strings(toAdd)
+	sort.Strings(toChange)
+
+	toDelete := []tfManagedRecord{}
+	for _, record := range live {
+		if ignored(record) {
+			continue
+		}
+		isDesired := false
+		for _, want := range desired {
+			if same(record, want) {
+				isDesired = true
+				break
+			}
+		}
+		if !isDesired {
+			toDelete = append(toDelete, record)
+		}
+	}
+	return toAdd, toChange, toDelete
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestZoneDiff(t *testing.T) {
+	t.Parallel()
+	liveRecord := func(domain string, recordType string, value string, ttl int64) tfManagedRecord {
+		return tfManagedRecord{Domain: types.StringValue(domain), Type: types.StringValue(recordType), Value: types.StringValue(value), TTL: types.Int64Value(ttl)}
+	}
+	live := []tfManagedRecord{
+		liveRecord("example.com", "SOA", "ns1.example.com", 900),
+		liveRecord("www.example.com", "A", "192.0.2.1", 300),
+		liveRecord("example.com", "MX", "10:mail.example.com", 3600),
+		liveRecord("old.example.com", "CNAME", "www.example.com", 300),
+	}
+	desired := map[string]tfDesiredRecord{
+		"www":  {Domain: types.StringValue("WWW.example.com"), Type: types.StringValue("A"), Value: types.StringValue("192.0.2.1"), TTL: types.Int64Null()},
+		"mx":   {Domain: types.StringValue("example.com"), Type: types.StringValue("MX"), Value: types.StringValue("10:mail.example.com"), TTL: types.Int64Value(600)},
+		"api":  {Domain: types.StringValue("api.example.com"), Type: types.StringValue("A"), Value: types.StringValue("192.0.2.2"), TTL: types.Int64Null()},
+		"api6": {Domain: types.StringValue("api.example.com"), Type: types.StringValue("AAAA"), Value: types.StringValue("2001:db8::2"), TTL: types.Int64Null()},
+	}
+
+	toAdd, toChange, toDelete := zoneDiff(live, desired, defaultZoneDiffIgnoredTypes)
+	if want := []string{"api", "api6"}; !cmp.Equal(want, toAdd) {
+		t.Errorf("to add: %s", cmp.Diff(want, toAdd))
+	}
+	if want := []string{"mx"}; !cmp.Equal(want, toChange) {
+		t.Errorf("to change: %s", cmp.Diff(want, toChange))
+	}
+	if len(toDelete) != 1 || toDelete[0].Domain.ValueString() != "old.example.com" {
+		t.Errorf("to delete = %v, want old.example.com CNAME", toDelete)
+	}
+}
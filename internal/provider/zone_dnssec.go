@@ -0,0 +1,768 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &ZoneDnssecResource{}
+	_ resource.ResourceWithConfigure      = &ZoneDnssecResource{}
+	_ resource.ResourceWithImportState    = &ZoneDnssecResource{}
+	_ resource.ResourceWithValidateConfig = &ZoneDnssecResource{}
+)
+
+// server defaults of signing settings
+const (
+	DEFAULT_DNSKEY_TTL        = 3600
+	DEFAULT_ZSK_ROLLOVER_DAYS = 30
+)
+
+type tfZoneDnssec struct {
+	ID               types.String     `tfsdk:"id"`
+	Zone             types.String     `tfsdk:"zone"`
+	Algorithm        types.String     `tfsdk:"algorithm"`
+	HashAlgorithm    types.String     `tfsdk:"hash_algorithm"`
+	KskKeySize       types.Int64      `tfsdk:"ksk_key_size"`
+	ZskKeySize       types.Int64      `tfsdk:"zsk_key_size"`
+	Curve            types.String     `tfsdk:"curve"`
+	NxProof          types.String     `tfsdk:"nx_proof"`
+	Nsec3Iterations  types.Int64      `tfsdk:"nsec3_iterations"`
+	Nsec3SaltLength  types.Int64      `tfsdk:"nsec3_salt_length"`
+	DnsKeyTtl        types.Int64      `tfsdk:"dnskey_ttl"`
+	ZskRolloverDays  types.Int64      `tfsdk:"zsk_rollover_days"`
+	KskPrivateKeyPem types.String     `tfsdk:"ksk_private_key_pem"`
+	ZskPrivateKeyPem types.String     `tfsdk:"zsk_private_key_pem"`
+	DNSSecStatus     types.String     `tfsdk:"dnssec_status"`
+	Keys             []tfDnssecKey    `tfsdk:"keys"`
+	DSRecords        []tfDnssecDSInfo `tfsdk:"ds_records"`
+	Timeouts         timeouts.Value   `tfsdk:"timeouts"`
+}
+
+type tfDnssecKey struct {
+	KeyTag         types.Int64  `tfsdk:"key_tag"`
+	KeyType        types.String `tfsdk:"key_type"`
+	Algorithm      types.String `tfsdk:"algorithm"`
+	State          types.String `tfsdk:"state"`
+	StateChangedOn types.String `tfsdk:"state_changed_on"`
+	IsRetiring     types.Bool   `tfsdk:"is_retiring"`
+	RolloverDays   types.Int64  `tfsdk:"rollover_days"`
+}
+
+type tfDnssecDSInfo struct {
+	KeyTag    types.Int64      `tfsdk:"key_tag"`
+	Algorithm types.String     `tfsdk:"algorithm"`
+	KeyState  types.String     `tfsdk:"key_state"`
+	PublicKey types.String     `tfsdk:"public_key"`
+	Digests   []tfDnssecDigest `tfsdk:"digests"`
+}
+
+type tfDnssecDigest struct {
+	DigestType types.String `tfsdk:"digest_type"`
+	Digest     types.String `tfsdk:"digest"`
+}
+
+// ZoneDnssecResource manages DNSSEC signing of a primary zone
+type ZoneDnssecResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func ZoneDnssecResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &ZoneDnssecResource{reqMutex: m}
+	}
+}
+
+func (r *ZoneDnssecResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_dnssec"
+}
+
+func (r *ZoneDnssecResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		MarkdownDescription: "Manages DNSSEC signing of a primary zone in Technitium DNS Server. Destroying the resource unsigns the zone.",
+		Attributes: map[string]rschema.Attribute{
+			"id": rschema.StringAttribute{
+				MarkdownDescription: "The zone name.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone": rschema.StringAttribute{
+				MarkdownDescription: "The name of the primary zone to sign.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"algorithm": rschema.StringAttribute{
+				MarkdownDescription: "The signing algorithm. Valid values are `RSA`, `ECDSA`, `EDDSA`. " +
+					"Changing the algorithm, `hash_algorithm` or `curve` of a signed zone performs an algorithm rollover: " +
+					"keys of the new algorithm are published next to the current ones, which are retired once the new keys are active. " +
+					"The new key signing key becomes active only after its DS record (see `ds_records`) is published in the parent zone, " +
+					"until then the plan keeps showing the pending change and applying it again completes the rollover.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(model.DNSSEC_RSA, model.DNSSEC_ECDSA, model.DNSSEC_EDDSA),
+				},
+			},
+			"hash_algorithm": rschema.StringAttribute{
+				MarkdownDescription: "The hash algorithm of `RSA` keys, required with `RSA`. Valid values are `MD5`, `SHA1`, `SHA256`, `SHA512`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("MD5", "SHA1", "SHA256", "SHA512"),
+				},
+			},
+			"ksk_key_size": rschema.Int64Attribute{
+				MarkdownDescription: "The key size in bits of `RSA` key signing keys.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1024, 4096),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"zsk_key_size": rschema.Int64Attribute{
+				MarkdownDescription: "The key size in bits of `RSA` zone signing keys.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1024, 4096),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"curve": rschema.StringAttribute{
+				MarkdownDescription: "The curve of `ECDSA` (`P256`, `P384`) or `EDDSA` (`ED25519`, `ED448`) keys, required with these algorithms.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(model.DNSSEC_CURVE_P256, model.DNSSEC_CURVE_P384, model.DNSSEC_CURVE_ED25519, model.DNSSEC_CURVE_ED448),
+				},
+			},
+			"nx_proof": rschema.StringAttribute{
+				MarkdownDescription: "The proof of non-existence. Valid values are `NSEC`, `NSEC3`. Defaults to `NSEC`. Changed in place, converting the signed zone.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(model.DNSSEC_NSEC),
+				Validators: []validator.String{
+					stringvalidator.OneOf(model.DNSSEC_NSEC, model.DNSSEC_NSEC3),
+				},
+			},
+			"nsec3_iterations": rschema.Int64Attribute{
+				MarkdownDescription: "The number of NSEC3 hash iterations, only with `NSEC3`. Defaults to `0`, as recommended by RFC 9276. Changed in place.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 50),
+				},
+			},
+			"nsec3_salt_length": rschema.Int64Attribute{
+				MarkdownDescription: "The length in bytes of the NSEC3 salt, only with `NSEC3`. Defaults to `0`. Changed in place.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 32),
+				},
+			},
+			"dnskey_ttl": rschema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The TTL of DNSKEY records. Defaults to `%d`. Changed in place.", DEFAULT_DNSKEY_TTL),
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(DEFAULT_DNSKEY_TTL),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 604800),
+				},
+			},
+			"zsk_rollover_days": rschema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Days after which zone signing keys are rolled over automatically, `0` to disable. Defaults to `%d`. Changed in place.", DEFAULT_ZSK_ROLLOVER_DAYS),
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(DEFAULT_ZSK_ROLLOVER_DAYS),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 365),
+				},
+			},
+			"ksk_private_key_pem": rschema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key to use as key signing key instead of a generated one, e.g. an escrowed key. " +
+					"Only used when signing the zone, the server does not return private keys.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"zsk_private_key_pem": rschema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key to use as zone signing key instead of a generated one. " +
+					"Only used when signing the zone, the server does not return private keys.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dnssec_status": rschema.StringAttribute{
+				MarkdownDescription: "The DNSSEC status of the zone, `SignedWithNSEC` or `SignedWithNSEC3`.",
+				Computed:            true,
+			},
+			"keys": rschema.ListNestedAttribute{
+				MarkdownDescription: "The private keys of the zone, with their state.",
+				Computed:            true,
+				NestedObject: rschema.NestedAttributeObject{
+					Attributes: map[string]rschema.Attribute{
+						"key_tag": rschema.Int64Attribute{
+							MarkdownDescription: "The key tag.",
+							Computed:            true,
+						},
+						"key_type": rschema.StringAttribute{
+							MarkdownDescription: "`KeySigningKey` or `ZoneSigningKey`.",
+							Computed:            true,
+						},
+						"algorithm": rschema.StringAttribute{
+							MarkdownDescription: "The DNSSEC algorithm, e.g. `ECDSAP256SHA256`.",
+							Computed:            true,
+						},
+						"state": rschema.StringAttribute{
+							MarkdownDescription: "The key state, e.g. `Published`, `Ready`, `Active`, `Retired`.",
+							Computed:            true,
+						},
+						"state_changed_on": rschema.StringAttribute{
+							MarkdownDescription: "When the key entered its state.",
+							Computed:            true,
+						},
+						"is_retiring": rschema.BoolAttribute{
+							MarkdownDescription: "Whether the key is being retired.",
+							Computed:            true,
+						},
+						"rollover_days": rschema.Int64Attribute{
+							MarkdownDescription: "Days after which the key is rolled over automatically, `0` if never.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"ds_records": rschema.ListNestedAttribute{
+				MarkdownDescription: "The DS records to publish in the parent zone, with the public keys of the key signing keys.",
+				Computed:            true,
+				NestedObject: rschema.NestedAttributeObject{
+					Attributes: map[string]rschema.Attribute{
+						"key_tag": rschema.Int64Attribute{
+							MarkdownDescription: "The key tag.",
+							Computed:            true,
+						},
+						"algorithm": rschema.StringAttribute{
+							MarkdownDescription: "The DNSSEC algorithm.",
+							Computed:            true,
+						},
+						"key_state": rschema.StringAttribute{
+							MarkdownDescription: "The state of the key signing key.",
+							Computed:            true,
+						},
+						"public_key": rschema.StringAttribute{
+							MarkdownDescription: "The base64 DNSKEY public key.",
+							Computed:            true,
+						},
+						"digests": rschema.ListNestedAttribute{
+							MarkdownDescription: "The DS digests.",
+							Computed:            true,
+							NestedObject: rschema.NestedAttributeObject{
+								Attributes: map[string]rschema.Attribute{
+									"digest_type": rschema.StringAttribute{
+										MarkdownDescription: "The digest type, e.g. `SHA256`.",
+										Computed:            true,
+									},
+									"digest": rschema.StringAttribute{
+										MarkdownDescription: "The hex digest.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]rschema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ZoneDnssecResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneDnssecResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var nxProof types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("nx_proof"), &nxProof)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !nxProof.IsUnknown() && nxProof.ValueString() != model.DNSSEC_NSEC3 {
+		for _, name := range []string{"nsec3_iterations", "nsec3_salt_length"} {
+			var value types.Int64
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+			if value.ValueInt64() != 0 {
+				resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid NSEC3 parameter",
+					fmt.Sprintf("%s is only used when nx_proof is NSEC3", name))
+			}
+		}
+	}
+
+	var algorithm, hashAlgorithm, curve types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("algorithm"), &algorithm)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("hash_algorithm"), &hashAlgorithm)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("curve"), &curve)...)
+	if resp.Diagnostics.HasError() || algorithm.IsUnknown() || hashAlgorithm.IsUnknown() || curve.IsUnknown() {
+		return
+	}
+
+	switch algorithm.ValueString() {
+	case model.DNSSEC_RSA:
+		if hashAlgorithm.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("hash_algorithm"), "Missing hash algorithm",
+				"hash_algorithm is required with the RSA algorithm")
+		}
+		if !curve.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("curve"), "Invalid curve",
+				"curve is not used with the RSA algorithm")
+		}
+	case model.DNSSEC_ECDSA, model.DNSSEC_EDDSA:
+		curves := []string{model.DNSSEC_CURVE_P256, model.DNSSEC_CURVE_P384}
+		if algorithm.ValueString() == model.DNSSEC_EDDSA {
+			curves = []string{model.DNSSEC_CURVE_ED25519, model.DNSSEC_CURVE_ED448}
+		}
+		if !slices.Contains(curves, curve.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root("curve"), "Invalid curve",
+				fmt.Sprintf("curve must be one of %s with the %s algorithm", strings.Join(curves, ", "), algorithm.ValueString()))
+		}
+		if !hashAlgorithm.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("hash_algorithm"), "Invalid hash algorithm",
+				fmt.Sprintf("hash_algorithm is not used with the %s algorithm", algorithm.ValueString()))
+		}
+	}
+}
+
+func (r *ZoneDnssecResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfZoneDnssec
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	ctx = tflog.SetField(ctx, "zone", planData.Zone.ValueString())
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.client.SignZone(ctx, domainToASCII(planData.Zone.ValueString()), tfZoneSigning2model(planData))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to sign zone: %s", err))
+		return
+	}
+
+	planData.ID = planData.Zone
+	if err := r.readDnssec(ctx, &planData); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to read zone DNSSEC properties after signing: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *ZoneDnssecResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateData tfZoneDnssec
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "zone", stateData.Zone.ValueString())
+	tflog.Info(ctx, "read: start")
+	defer tflog.Info(ctx, "read: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	readTimeout, diags := stateData.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	signed, err := r.isSigned(ctx, stateData.Zone.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
+		return
+	}
+	if !signed {
+		// zone deleted or unsigned outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err := r.readDnssec(ctx, &stateData); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading zone DNSSEC properties: %s", err))
+		return
+	}
+	readPendingRollover(&stateData)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+func (r *ZoneDnssecResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData, stateData tfZoneDnssec
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "update")
+	ctx = tflog.SetField(ctx, "zone", planData.Zone.ValueString())
+	tflog.Info(ctx, "update: start")
+	defer tflog.Info(ctx, "update: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	updateTimeout, diags := planData.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	zoneName := domainToASCII(planData.Zone.ValueString())
+	if !planData.Algorithm.Equal(stateData.Algorithm) || !planData.HashAlgorithm.Equal(stateData.HashAlgorithm) ||
+		!planData.Curve.Equal(stateData.Curve) {
+		// private keys only apply when signing, they are of the previous algorithm
+		signing := tfZoneSigning2model(planData)
+		signing.PemKskPrivateKey, signing.PemZskPrivateKey = "", ""
+		if err := r.client.RolloverDnssecAlgorithm(ctx, zoneName, signing); err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Unable to roll over DNSSEC algorithm: %s", err))
+			return
+		}
+	}
+	if !planData.NxProof.Equal(stateData.NxProof) || !planData.Nsec3Iterations.Equal(stateData.Nsec3Iterations) ||
+		!planData.Nsec3SaltLength.Equal(stateData.Nsec3SaltLength) {
+		err := r.client.UpdateDnssecNxProof(ctx, zoneName, planData.NxProof.ValueString(),
+			planData.Nsec3Iterations.ValueInt64(), planData.Nsec3SaltLength.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Unable to update DNSSEC proof of non-existence: %s", err))
+			return
+		}
+	}
+	if !planData.DnsKeyTtl.Equal(stateData.DnsKeyTtl) {
+		if err := r.client.UpdateDnsKeyTtl(ctx, zoneName, planData.DnsKeyTtl.ValueInt64()); err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Unable to update DNSKEY TTL: %s", err))
+			return
+		}
+	}
+	if !planData.ZskRolloverDays.Equal(stateData.ZskRolloverDays) {
+		err := r.client.UpdateDnssecKeyRollover(ctx, zoneName, model.DNSSEC_ZONE_SIGNING_KEY, planData.ZskRolloverDays.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Unable to update zone signing key rollover: %s", err))
+			return
+		}
+	}
+
+	planData.ID = planData.Zone
+	if err := r.readDnssec(ctx, &planData); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to read zone DNSSEC properties after update: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *ZoneDnssecResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateData tfZoneDnssec
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "delete")
+	ctx = tflog.SetField(ctx, "zone", stateData.Zone.ValueString())
+	tflog.Info(ctx, "delete: start")
+	defer tflog.Info(ctx, "delete: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	deleteTimeout, diags := stateData.Timeouts.Delete(ctx, DEFAULT_DELETE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	signed, err := r.isSigned(ctx, stateData.Zone.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
+		return
+	}
+	if !signed {
+		return
+	}
+
+	if err := r.client.UnsignZone(ctx, domainToASCII(stateData.Zone.ValueString())); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to unsign zone: %s", err))
+	}
+}
+
+// terraform import technitium_zone_dnssec.example example.com
+func (r *ZoneDnssecResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	zoneName := domainToASCII(req.ID)
+
+	ctx = tflog.SetField(ctx, "operation", "import")
+	ctx = tflog.SetField(ctx, "zone", zoneName)
+	tflog.Info(ctx, "import: start")
+	defer tflog.Info(ctx, "import: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_READ_TIMEOUT)
+	defer cancel()
+
+	signed, err := r.isSigned(ctx, zoneName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
+		return
+	}
+	if !signed {
+		resp.Diagnostics.AddError("Zone not signed",
+			fmt.Sprintf("Zone '%s' does not exist or is not signed with DNSSEC", zoneName))
+		return
+	}
+
+	// as imported, the zone may be written in Unicode form in the configuration
+	stateData := tfZoneDnssec{
+		ID:       types.StringValue(req.ID),
+		Zone:     types.StringValue(req.ID),
+		Timeouts: nullTimeouts(),
+	}
+	if err := r.readDnssec(ctx, &stateData); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading zone DNSSEC properties: %s", err))
+		return
+	}
+
+	// signing settings are derived from the active key signing key
+	properties, err := r.client.GetDnssecProperties(ctx, zoneName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading zone DNSSEC properties: %s", err))
+		return
+	}
+	for _, key := range properties.DnssecPrivateKeys {
+		if key.KeyType == model.DNSSEC_KEY_SIGNING_KEY && !key.IsRetiring {
+			algorithm, hashAlgorithm, curve := parseDnssecAlgorithm(key.Algorithm)
+			stateData.Algorithm = types.StringValue(algorithm)
+			if hashAlgorithm != "" {
+				stateData.HashAlgorithm = types.StringValue(hashAlgorithm)
+			}
+			if curve != "" {
+				stateData.Curve = types.StringValue(curve)
+			}
+		}
+		if key.KeyType == model.DNSSEC_ZONE_SIGNING_KEY && !key.IsRetiring {
+			stateData.ZskRolloverDays = types.Int64Value(key.RolloverDays)
+		}
+	}
+	if stateData.ZskRolloverDays.IsNull() {
+		stateData.ZskRolloverDays = types.Int64Value(DEFAULT_ZSK_ROLLOVER_DAYS)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+}
+
+// whether the zone exists and is signed
+func (r *ZoneDnssecResource) isSigned(ctx context.Context, zoneName string) (bool, error) {
+	zones, err := r.client.ListZones(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, zone := range zones {
+		if sameDomainName(zone.Name, zoneName) {
+			return zone.DNSSecStatus != "" && zone.DNSSecStatus != model.DNSSEC_STATUS_UNSIGNED, nil
+		}
+	}
+	return false, nil
+}
+
+// refresh server side settings, keys and DS records of data
+func (r *ZoneDnssecResource) readDnssec(ctx context.Context, data *tfZoneDnssec) error {
+	zoneName := domainToASCII(data.Zone.ValueString())
+	properties, err := r.client.GetDnssecProperties(ctx, zoneName)
+	if err != nil {
+		return err
+	}
+	dsRecords, err := r.client.GetDSRecords(ctx, zoneName)
+	if err != nil {
+		return err
+	}
+
+	data.DNSSecStatus = types.StringValue(properties.DNSSecStatus)
+	data.NxProof = types.StringValue(model.DNSSEC_NSEC)
+	data.Nsec3Iterations = types.Int64Value(0)
+	data.Nsec3SaltLength = types.Int64Value(0)
+	if properties.DNSSecStatus == model.DNSSEC_STATUS_SIGNED_WITH_NSEC3 {
+		data.NxProof = types.StringValue(model.DNSSEC_NSEC3)
+		data.Nsec3Iterations = types.Int64Value(properties.Nsec3Iterations)
+		data.Nsec3SaltLength = types.Int64Value(properties.Nsec3SaltLength)
+	}
+	data.DnsKeyTtl = types.Int64Value(properties.DnsKeyTtl)
+
+	data.Keys = []tfDnssecKey{}
+	for _, key := range properties.DnssecPrivateKeys {
+		data.Keys = append(data.Keys, tfDnssecKey{
+			KeyTag:         types.Int64Value(key.KeyTag),
+			KeyType:        types.StringValue(key.KeyType),
+			Algorithm:      types.StringValue(key.Algorithm),
+			State:          types.StringValue(key.State),
+			StateChangedOn: types.StringValue(key.StateChangedOn),
+			IsRetiring:     types.BoolValue(key.IsRetiring),
+			RolloverDays:   types.Int64Value(key.RolloverDays),
+		})
+	}
+
+	data.DSRecords = []tfDnssecDSInfo{}
+	for _, ds := range dsRecords {
+		info := tfDnssecDSInfo{
+			KeyTag:    types.Int64Value(ds.KeyTag),
+			Algorithm: types.StringValue(ds.Algorithm),
+			KeyState:  types.StringValue(ds.DnsKeyState),
+			PublicKey: types.StringValue(ds.PublicKey),
+			Digests:   []tfDnssecDigest{},
+		}
+		for _, digest := range ds.Digests {
+			info.Digests = append(info.Digests, tfDnssecDigest{
+				DigestType: types.StringValue(digest.DigestType),
+				Digest:     types.StringValue(digest.Digest),
+			})
+		}
+		data.DSRecords = append(data.DSRecords, info)
+	}
+
+	return nil
+}
+
+// report the algorithm of the current key signing key while an algorithm
+// rollover is unfinished, so the plan shows the pending change
+func readPendingRollover(data *tfZoneDnssec) {
+	algorithm := model.DnssecAlgorithmName(data.Algorithm.ValueString(), data.HashAlgorithm.ValueString(), data.Curve.ValueString())
+	for _, key := range data.Keys {
+		if key.KeyType.ValueString() != model.DNSSEC_KEY_SIGNING_KEY || key.IsRetiring.ValueBool() ||
+			key.State.ValueString() != model.DNSSEC_KEY_STATE_ACTIVE || key.Algorithm.ValueString() == algorithm {
+			continue
+		}
+		pending, hashAlgorithm, curve := parseDnssecAlgorithm(key.Algorithm.ValueString())
+		data.Algorithm = types.StringValue(pending)
+		data.HashAlgorithm = types.StringNull()
+		if hashAlgorithm != "" {
+			data.HashAlgorithm = types.StringValue(hashAlgorithm)
+		}
+		data.Curve = types.StringNull()
+		if curve != "" {
+			data.Curve = types.StringValue(curve)
+		}
+		return
+	}
+}
+
+func tfZoneSigning2model(data tfZoneDnssec) model.DNSZoneSigning {
+	return model.DNSZoneSigning{
+		Algorithm:        data.Algorithm.ValueString(),
+		HashAlgorithm:    data.HashAlgorithm.ValueString(),
+		KskKeySize:       data.KskKeySize.ValueInt64(),
+		ZskKeySize:       data.ZskKeySize.ValueInt64(),
+		Curve:            data.Curve.ValueString(),
+		DnsKeyTtl:        data.DnsKeyTtl.ValueInt64Pointer(),
+		ZskRolloverDays:  data.ZskRolloverDays.ValueInt64Pointer(),
+		NxProof:          data.NxProof.ValueString(),
+		Iterations:       data.Nsec3Iterations.ValueInt64Pointer(),
+		SaltLength:       data.Nsec3SaltLength.ValueInt64Pointer(),
+		PemKskPrivateKey: data.KskPrivateKeyPem.ValueString(),
+		PemZskPrivateKey: data.ZskPrivateKeyPem.ValueString(),
+	}
+}
+
+// signing algorithm, RSA hash algorithm and curve of a DNSSEC algorithm name
+// like RSASHA256 or ECDSAP256SHA256
+func parseDnssecAlgorithm(name string) (algorithm string, hashAlgorithm string, curve string) {
+	switch {
+	case strings.HasPrefix(name, "RSA"):
+		hashAlgorithm = strings.TrimPrefix(name, "RSA")
+		hashAlgorithm = strings.TrimSuffix(hashAlgorithm, "NSEC3SHA1")
+		if hashAlgorithm == "" {
+			hashAlgorithm = "SHA1"
+		}
+		return model.DNSSEC_RSA, hashAlgorithm, ""
+	case strings.HasPrefix(name, "ECDSAP256"):
+		return model.DNSSEC_ECDSA, "", model.DNSSEC_CURVE_P256
+	case strings.HasPrefix(name, "ECDSAP384"):
+		return model.DNSSEC_ECDSA, "", model.DNSSEC_CURVE_P384
+	case name == model.DNSSEC_CURVE_ED25519, name == model.DNSSEC_CURVE_ED448:
+		return model.DNSSEC_EDDSA, "", name
+	}
+	return name, "", ""
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource              = &ZoneResyncResource{}
+	_ resource.ResourceWithConfigure = &ZoneResyncResource{}
+)
+
+type tfZoneResync struct {
+	Zone     types.String   `tfsdk:"zone"`
+	Triggers types.Map      `tfsdk:"triggers"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ZoneResyncResource makes a secondary zone refresh from its primary server
+// when created
+type ZoneResyncResource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func ZoneResyncResourceFactory(m *sync.Mutex) func() resource.Resource {
+	return func() resource.Resource {
+		return &ZoneResyncResource{reqMutex: m}
+	}
+}
+
+func (r *ZoneResyncResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_resync"
+}
+
+func (r *ZoneResyncResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		MarkdownDescription: "Makes a `Secondary`, `SecondaryForwarder`, `SecondaryCatalog` or `Stub` zone refresh from its primary server now, " +
+			"as a NOTIFY from the primary would, instead of at its next scheduled refresh. " +
+			"Primary zones already send NOTIFY on every change: in split primary/secondary setups, use this resource " +
+			"with a provider configuration of the secondary server, and `triggers` depending on the records changed on the primary. " +
+			"The zone is refreshed when the resource is created, again when `zone` or `triggers` change. Destroying the resource does nothing.",
+		Attributes: map[string]rschema.Attribute{
+			"zone": rschema.StringAttribute{
+				MarkdownDescription: "The zone to refresh.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": rschema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that refresh the zone again when changed, e.g. the `fqdn` and values of records of the primary zone.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]rschema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ZoneResyncResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// or it will panic on none
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneResyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var planData tfZoneResync
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "create")
+	ctx = tflog.SetField(ctx, "zone", planData.Zone.ValueString())
+	tflog.Info(ctx, "create: start")
+	defer tflog.Info(ctx, "create: end")
+	r.reqMutex.Lock()
+	defer r.reqMutex.Unlock()
+
+	createTimeout, diags := planData.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	zones, err := r.client.ListZones(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
+		return
+	}
+	zoneName := domainToASCII(planData.Zone.ValueString())
+	zone, found := recordZone(zones, zoneName, "")
+	switch {
+	case !found:
+		resp.Diagnostics.AddAttributeError(path.Root("zone"), "Zone not found",
+			fmt.Sprintf("Zone '%s' does not exist", planData.Zone.ValueString()))
+		return
+	case !isReadOnlyZoneType(zone.Type):
+		resp.Diagnostics.AddAttributeError(path.Root("zone"), "Not a secondary zone",
+			fmt.Sprintf("Zone '%s' is a %s zone: only zones replicated from a primary server can be refreshed, "+
+				"primary zones send NOTIFY to their secondaries on every change", zone.Name, zone.Type))
+		return
+	}
+
+	if err := r.client.ResyncZone(ctx, zone.Name); err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to resync zone: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+// nothing to refresh, the zone was refreshed at creation
+func (r *ZoneResyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// every attribute requires replacement
+func (r *ZoneResyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData tfZoneResync
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *ZoneResyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "delete: nothing to do, zone resync is not reverted")
+}
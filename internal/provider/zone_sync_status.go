@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &ZoneSyncStatusDataSource{}
+)
+
+type tfZoneSyncStatus struct {
+	Zone          types.String `tfsdk:"zone"`
+	Type          types.String `tfsdk:"type"`
+	Expiry        types.String `tfsdk:"expiry"`
+	IsExpired     types.Bool   `tfsdk:"is_expired"`
+	SyncFailed    types.Bool   `tfsdk:"sync_failed"`
+	LastRefreshed types.String `tfsdk:"last_refreshed"`
+	Healthy       types.Bool   `tfsdk:"healthy"`
+}
+
+// ZoneSyncStatusDataSource reports whether a zone replicated from a primary
+// server is kept in sync with it
+type ZoneSyncStatusDataSource struct {
+	client   model.DNSApiClient
+	reqMutex *sync.Mutex
+}
+
+func ZoneSyncStatusDataSourceFactory(m *sync.Mutex) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &ZoneSyncStatusDataSource{reqMutex: m}
+	}
+}
+
+func (d *ZoneSyncStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_sync_status"
+}
+
+func (d *ZoneSyncStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the sync status of a `Secondary`, `SecondaryForwarder`, `SecondaryCatalog` or `Stub` zone " +
+			"with its primary server, to alert on broken zone transfers, e.g. with a `check` block.",
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The zone to report on.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The zone type.",
+				Computed:            true,
+			},
+			"expiry": schema.StringAttribute{
+				MarkdownDescription: "When the zone expires if it cannot be refreshed from the primary server.",
+				Computed:            true,
+			},
+			"is_expired": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone expired: the server stopped answering for it.",
+				Computed:            true,
+			},
+			"sync_failed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the last refresh from the primary server failed.",
+				Computed:            true,
+			},
+			"last_refreshed": schema.StringAttribute{
+				MarkdownDescription: "When the zone was last modified by a refresh from the primary server.",
+				Computed:            true,
+			},
+			"healthy": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone is neither expired nor failing to sync.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ZoneSyncStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(model.DNSApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Internal error: expected *model.DNSApiClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ZoneSyncStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config tfZoneSyncStatus
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "operation", "read")
+	ctx = tflog.SetField(ctx, "zone", config.Zone.ValueString())
+	tflog.Info(ctx, "read zone sync status: start")
+	defer tflog.Info(ctx, "read zone sync status: end")
+	d.reqMutex.Lock()
+	defer d.reqMutex.Unlock()
+
+	zones, err := d.client.ListZones(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Reading DNS zones: query failed: %s", err))
+		return
+	}
+
+	zoneSyncStatus(zones, &config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// fill the sync status of the configured zone, which must be replicated from
+// a primary server
+func zoneSyncStatus(zones []model.DNSZone, status *tfZoneSyncStatus, diags *diag.Diagnostics) {
+	zone, found := recordZone(zones, domainToASCII(status.Zone.ValueString()), "")
+	switch {
+	case !found:
+		diags.AddAttributeError(path.Root("zone"), "Zone not found",
+			fmt.Sprintf("Zone '%s' does not exist", status.Zone.ValueString()))
+		return
+	case !isReadOnlyZoneType(zone.Type):
+		diags.AddAttributeError(path.Root("zone"), "Not a secondary zone",
+			fmt.Sprintf("Zone '%s' is a %s zone, only zones replicated from a primary server are synced", zone.Name, zone.Type))
+		return
+	}
+
+	status.Type = types.StringValue(string(zone.Type))
+	status.Expiry = types.StringValue(zone.Expiry)
+	status.IsExpired = types.BoolValue(zone.IsExpired)
+	status.SyncFailed = types.BoolValue(zone.SyncFailed)
+	status.LastRefreshed = types.StringValue(zone.LastModified)
+	status.Healthy = types.BoolValue(!zone.IsExpired && !zone.SyncFailed)
+}
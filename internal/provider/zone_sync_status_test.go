@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestZoneSyncStatus(t *testing.T) {
+	t.Parallel()
+	zones := []model.DNSZone{
+		{Name: "example.com", Type: model.ZONE_PRIMARY},
+		{Name: "example.net", Type: model.ZONE_SECONDARY, Expiry: "2026-10-23T08:00:00Z", SyncFailed: true, LastModified: "2026-10-16T08:00:00Z"},
+	}
+	tests := []struct {
+		name      string
+		zone      string
+		wantError string
+	}{
+		{name: "failing secondary", zone: "Example.NET"},
+		{name: "primary", zone: "example.com", wantError: "Not a secondary zone"},
+		{name: "missing", zone: "example.org", wantError: "Zone not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			status := tfZoneSyncStatus{Zone: types.StringValue(tt.zone)}
+			var diags diag.Diagnostics
+			zoneSyncStatus(zones, &status, &diags)
+			if tt.wantError != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.wantError {
+					t.Fatalf("diagnostics = %v, want error %q", diags, tt.wantError)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !status.SyncFailed.ValueBool() || status.Healthy.ValueBool() ||
+				status.LastRefreshed.ValueString() != "2026-10-16T08:00:00Z" {
+				t.Errorf("status = %+v", status)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kevynb/terraform-provider-technitium/internal/model"
+)
+
+func TestMergeZoneStateStatus(t *testing.T) {
+	t.Parallel()
+	prior := modelZone2tf(model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY, DNSSecStatus: "Unsigned", SOASerial: 1})
+	server := modelZone2tf(model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY, DNSSecStatus: "SignedWithNSEC",
+		SOASerial: 2, LastModified: "2026-10-16T08:00:00Z", Disabled: true})
+
+	got := mergeZoneState(prior, server)
+	if !got.DNSSecStatus.Equal(types.StringValue("SignedWithNSEC")) {
+		t.Errorf("dnssec_status = %s, want SignedWithNSEC", got.DNSSecStatus)
+	}
+	if !got.SOASerial.Equal(types.Int64Value(2)) {
+		t.Errorf("soa_serial = %s, want 2", got.SOASerial)
+	}
+	if !got.LastModified.Equal(types.StringValue("2026-10-16T08:00:00Z")) {
+		t.Errorf("last_modified = %s, want 2026-10-16T08:00:00Z", got.LastModified)
+	}
+	if !got.Disabled.Equal(types.BoolValue(true)) {
+		t.Errorf("disabled = %s, want true", got.Disabled)
+	}
+}
+
+func TestReadZone_Forwarder(t *testing.T) {
+	t.Parallel()
+	client := model.NewFakeDNSApiClient()
+	r := &ZoneResource{client: client}
+	enabled, proxyPort := true, int64(1080)
+	created := model.DNSZone{Name: "example.com", Type: model.ZONE_FORWARDER, Forwarder: "192.0.2.53", Protocol: "Tls",
+		DnssecValidation: &enabled, ProxyType: "Socks5", ProxyAddress: "proxy.example.net", ProxyPort: &proxyPort}
+	if err := client.CreateZone(context.Background(), created); err != nil {
+		t.Fatal(err)
+	}
+
+	// settings sent at creation are read back from the FWD record
+	got, err := r.readZone(context.Background(), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil {
+		t.Fatal("zone not found")
+	}
+	if got.Forwarder != "192.0.2.53" || got.Protocol != "Tls" || got.ProxyType != "Socks5" || got.ProxyAddress != "proxy.example.net" {
+		t.Errorf("forwarder settings not read back: %+v", got)
+	}
+	if got.DnssecValidation == nil || !*got.DnssecValidation {
+		t.Error("dnssec_validation not read back")
+	}
+	if got.ProxyPort == nil || *got.ProxyPort != 1080 {
+		t.Error("proxy_port not read back")
+	}
+}
+
+func TestMergeZoneState_SoaSerialDateScheme(t *testing.T) {
+	t.Parallel()
+	enabled, disabled := true, false
+	tests := []struct {
+		name   string
+		prior  types.Bool
+		server *bool
+		want   types.Bool
+	}{
+		{name: "changed on the server", prior: types.BoolValue(true), server: &disabled, want: types.BoolValue(false)},
+		{name: "not configured", prior: types.BoolNull(), server: &enabled, want: types.BoolNull()},
+		{name: "not returned by the server", prior: types.BoolValue(true), server: nil, want: types.BoolValue(true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			prior := modelZone2tf(model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY})
+			prior.UseSoaSerialDateScheme = tt.prior
+			server := modelZone2tf(model.DNSZone{Name: "example.com", Type: model.ZONE_PRIMARY, UseSoaSerialDateScheme: tt.server})
+
+			if got := mergeZoneState(prior, server).UseSoaSerialDateScheme; !got.Equal(tt.want) {
+				t.Errorf("use_soa_serial_date_scheme = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeZoneState_ProxyPasswordWO(t *testing.T) {
+	t.Parallel()
+	server := modelZone2tf(model.DNSZone{Name: "example.com", Type: model.ZONE_FORWARDER, Forwarder: "192.0.2.53", ProxyPassword: "secret"})
+
+	prior := modelZone2tf(model.DNSZone{Name: "example.com", Type: model.ZONE_FORWARDER})
+	prior.ProxyPasswordWOVersion = types.Int64Value(2)
+	got := mergeZoneState(prior, server)
+	if !got.ProxyPassword.IsNull() {
+		t.Errorf("proxy_password = %s, want null with the write-only password", got.ProxyPassword)
+	}
+	if !got.ProxyPasswordWOVersion.Equal(types.Int64Value(2)) {
+		t.Errorf("proxy_password_wo_version = %s, want 2", got.ProxyPasswordWOVersion)
+	}
+
+	changedVersion := got
+	changedVersion.ProxyPasswordWOVersion = types.Int64Value(3)
+	if createSettingsChanged(changedVersion, got) || !forwarderSettingsChanged(changedVersion, got) {
+		t.Error("a new write-only password version must update the FWD record, not recreate the zone")
+	}
+}
+
+func TestUpdateForwarderRecord(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := model.NewFakeDNSApiClient()
+	zone := model.DNSZone{Name: "example.com", Type: model.ZONE_FORWARDER, Forwarder: "192.0.2.53", ProxyType: "Http", ProxyPassword: "old"}
+	if err := client.CreateZone(ctx, zone); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddRecord(ctx, model.DNSRecord{Type: model.REC_A, Domain: "www.example.com", IPAddress: "192.0.2.1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var diags diag.Diagnostics
+	rotated := model.DNSZone{Name: "example.com", Type: model.ZONE_FORWARDER, ProxyPassword: "new"}
+	if err := updateForwarderRecord(ctx, client, rotated, &diags); err != nil {
+		t.Fatal(err)
+	}
+	records, err := client.GetZoneRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("zone records = %+v, want the FWD and A records", records)
+	}
+	for _, record := range records {
+		if record.Type == model.REC_FWD && (record.ProxyPassword != "new" || record.Forwarder != "192.0.2.53" || record.ProxyType != "Http") {
+			t.Errorf("FWD record = %+v, want the new password and the other settings kept", record)
+		}
+	}
+}
+
+// IDN zones are imported by their Unicode name too
+func TestZoneImportUnicodeName(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := model.NewFakeDNSApiClient()
+	if err := client.CreateZone(ctx, model.DNSZone{Name: "xn--bcher-kva.example", Type: model.ZONE_PRIMARY, DNSSecStatus: model.DNSSEC_STATUS_SIGNED_WITH_NSEC}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &ZoneResource{client: client, reqMutex: &sync.Mutex{}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	resp := &resource.ImportStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "bücher.example"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var imported tfDNSZone
+	resp.Diagnostics.Append(resp.State.Get(ctx, &imported)...)
+	if imported.Name.ValueString() != "bücher.example" || imported.Type.ValueString() != string(model.ZONE_PRIMARY) {
+		t.Errorf("imported name = %s, type = %s, want the Primary zone bücher.example", imported.Name, imported.Type)
+	}
+
+	dnssec := &ZoneDnssecResource{client: client, reqMutex: &sync.Mutex{}}
+	if signed, err := dnssec.isSigned(ctx, "bücher.example"); err != nil || !signed {
+		t.Errorf("isSigned(bücher.example) = %t, %v, want signed", signed, err)
+	}
+}
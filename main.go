@@ -31,8 +31,8 @@ func main() {
 		Debug:   debug,
 	}
 
-	apiClientFactory := func(apiURL, apiToken string, skipCertificateVerification bool) (model.DNSApiClient, error) {
-		return client.NewClient(apiURL, apiToken, skipCertificateVerification)
+	apiClientFactory := func(conf client.Config) (model.DNSApiClient, error) {
+		return client.NewClient(conf)
 	}
 
 	err := providerserver.Serve(context.Background(), provider.New(version, apiClientFactory), opts)